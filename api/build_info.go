@@ -0,0 +1,33 @@
+package api
+
+import "time"
+
+// Build metadata stamped into production bundles by `thunder build` and
+// `thunder deploy` with -ldflags -X. Development builds leave them empty.
+var (
+	buildVersion string
+	buildCommit  string
+	buildTime    string
+)
+
+// VersionInfo describes the build of the running app.
+type VersionInfo struct {
+	// Version identifies the deployed bundle: its UTC build time as
+	// YYYYMMDDhhmmss, matching the versions listed by `thunder rollback --list`.
+	Version string
+	// Commit is the app's short git SHA, suffixed -dirty when built with
+	// uncommitted changes.
+	Commit string
+	// BuiltAt is when the bundle was built; zero if unknown.
+	BuiltAt time.Time
+}
+
+// BuildInfo returns the version stamped into the running bundle. All fields
+// are empty for builds that were not stamped, such as `thunder serve`.
+func BuildInfo() VersionInfo {
+	info := VersionInfo{Version: buildVersion, Commit: buildCommit}
+	if t, err := time.Parse(time.RFC3339, buildTime); err == nil {
+		info.BuiltAt = t
+	}
+	return info
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBuildInfoUnstamped verifies an unstamped build reports empty info
+func TestBuildInfoUnstamped(t *testing.T) {
+	if info := BuildInfo(); info != (VersionInfo{}) {
+		t.Errorf("Expected empty build info, got %+v", info)
+	}
+}
+
+// TestBuildInfoStamped verifies the stamped linker variables are exposed
+func TestBuildInfoStamped(t *testing.T) {
+	defer func(v, c, b string) { buildVersion, buildCommit, buildTime = v, c, b }(buildVersion, buildCommit, buildTime)
+	buildVersion, buildCommit, buildTime = "20240102030405", "abc1234", "2024-01-02T03:04:05Z"
+
+	info := BuildInfo()
+	if info.Version != "20240102030405" || info.Commit != "abc1234" {
+		t.Errorf("Unexpected build info: %+v", info)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !info.BuiltAt.Equal(want) {
+		t.Errorf("Expected BuiltAt %v, got %v", want, info.BuiltAt)
+	}
+}
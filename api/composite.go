@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -52,10 +53,8 @@ func (ce *CompositeErrors) Error() string {
 
 	var errorMessages []string
 	for _, err := range ce.Errors {
-		if body, ok := err.Body.(map[string]interface{}); ok {
-			if message, exists := body["message"]; exists {
-				errorMessages = append(errorMessages, fmt.Sprintf("ref %s: %v", err.ReferenceID, message))
-			}
+		for _, se := range subResponseErrors(err.Body) {
+			errorMessages = append(errorMessages, fmt.Sprintf("ref %s: %v", err.ReferenceID, se.Message))
 		}
 	}
 
@@ -122,3 +121,122 @@ func parseCompositeResponse(data []byte) (*CompositeErrors, error) {
 
 	return nil, fmt.Errorf("unable to parse composite response")
 }
+
+// SaveResult reports the outcome of saving one record with UpdateRecords.
+type SaveResult struct {
+	Id      string
+	Success bool
+	Errors  []SaveError
+}
+
+// SaveError describes why a record failed to save. Fields lists the fields
+// the error applies to, when Salesforce reports them.
+type SaveError struct {
+	Message   string   `json:"message"`
+	ErrorCode string   `json:"errorCode"`
+	Fields    []string `json:"fields,omitempty"`
+}
+
+// maxCompositeSubrequests is the most subrequests Salesforce accepts in a
+// single composite request.
+const maxCompositeSubrequests = 25
+
+// UpdateRecords saves changes to existing records of objectName, such as the
+// drafts from an inline-edited data table. Each record must include its Id
+// plus the fields to update. Records are sent as composite requests of up to
+// 25 updates; allOrNone rolls back every update in a request if any fails.
+// A SaveResult is returned per record, in order, so failures can be shown
+// against the row and field they apply to.
+func UpdateRecords(objectName string, records []map[string]interface{}, allOrNone bool) ([]SaveResult, error) {
+	return updateRecords(Post, objectName, records, allOrNone)
+}
+
+func updateRecords(post func(string, []byte) ([]byte, error), objectName string, records []map[string]interface{}, allOrNone bool) ([]SaveResult, error) {
+	results := make([]SaveResult, 0, len(records))
+	for start := 0; start < len(records); start += maxCompositeSubrequests {
+		end := min(start+maxCompositeSubrequests, len(records))
+		batch := records[start:end]
+
+		req := CompositeRequest{AllOrNone: allOrNone}
+		ids := make(map[string]string, len(batch))
+		for i, record := range batch {
+			id, _ := record["Id"].(string)
+			if id == "" {
+				return nil, fmt.Errorf("record %d has no Id", start+i)
+			}
+			body := make(map[string]interface{}, len(record))
+			for field, v := range record {
+				if field != "Id" {
+					body[field] = v
+				}
+			}
+			ref := fmt.Sprintf("record%d", i)
+			ids[ref] = id
+			req.CompositeRequest = append(req.CompositeRequest, CompositeSubRequest{
+				Method:      "PATCH",
+				URL:         fmt.Sprintf("/services/data/v63.0/sobjects/%s/%s", objectName, id),
+				ReferenceID: ref,
+				Body:        body,
+			})
+		}
+		payload, err := json.Marshal(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode update: %w", err)
+		}
+		data, err := post("/services/data/v63.0/composite", payload)
+		// Sub-request failures are reported per record below.
+		var compositeErrs *CompositeErrors
+		if err != nil && !errors.As(err, &compositeErrs) {
+			return nil, fmt.Errorf("update failed: %w", err)
+		}
+		batchResults, err := parseSaveResults(data, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range batch {
+			results = append(results, batchResults[fmt.Sprintf("record%d", i)])
+		}
+	}
+	return results, nil
+}
+
+// parseSaveResults reads a composite response into a SaveResult per
+// referenceId. Failed sub-requests carry either a list of errors (Salesforce)
+// or a single error object (GoBridge).
+func parseSaveResults(data []byte, ids map[string]string) (map[string]SaveResult, error) {
+	var resp struct {
+		CompositeResponse []struct {
+			Body           json.RawMessage `json:"body"`
+			HTTPStatusCode int             `json:"httpStatusCode"`
+			ReferenceID    string          `json:"referenceId"`
+		} `json:"compositeResponse"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil || len(resp.CompositeResponse) == 0 {
+		var single CompositeError
+		if json.Unmarshal(data, &single) == nil && single.Message != "" {
+			return nil, fmt.Errorf("update failed: %s", single.Message)
+		}
+		return nil, fmt.Errorf("unable to parse composite response")
+	}
+	results := make(map[string]SaveResult, len(ids))
+	// Records missing from the response were skipped after an earlier
+	// failure in an allOrNone request.
+	for ref, id := range ids {
+		results[ref] = SaveResult{Id: id, Errors: []SaveError{{Message: "record was not processed"}}}
+	}
+	for _, sub := range resp.CompositeResponse {
+		result := SaveResult{Id: ids[sub.ReferenceID], Success: sub.HTTPStatusCode < 400}
+		if !result.Success {
+			var list []SaveError
+			if json.Unmarshal(sub.Body, &list) != nil {
+				var one SaveError
+				if json.Unmarshal(sub.Body, &one) == nil {
+					list = []SaveError{one}
+				}
+			}
+			result.Errors = list
+		}
+		results[sub.ReferenceID] = result
+	}
+	return results, nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -270,3 +271,88 @@ func TestCompositeRequestJSON(t *testing.T) {
 		t.Errorf("CompositeRequest length = %d, want %d", len(parsed.CompositeRequest), len(req.CompositeRequest))
 	}
 }
+
+func TestUpdateRecordsBuildsCompositeRequest(t *testing.T) {
+	var sent CompositeRequest
+	post := func(url string, body []byte) ([]byte, error) {
+		if url != "/services/data/v63.0/composite" {
+			t.Errorf("Expected composite URL, got %s", url)
+		}
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Fatal(err)
+		}
+		return []byte(`{"compositeResponse":[
+			{"body":null,"httpStatusCode":204,"referenceId":"record0"},
+			{"body":[{"message":"Amount must be positive","errorCode":"FIELD_CUSTOM_VALIDATION_EXCEPTION","fields":["Amount"]}],"httpStatusCode":400,"referenceId":"record1"}]}`), nil
+	}
+
+	results, err := updateRecords(post, "Opportunity", []map[string]interface{}{
+		{"Id": "006A", "StageName": "Closed Won"},
+		{"Id": "006B", "Amount": -1.0},
+	}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if sent.AllOrNone || len(sent.CompositeRequest) != 2 {
+		t.Fatalf("Unexpected request: %+v", sent)
+	}
+	sub := sent.CompositeRequest[0]
+	if sub.Method != "PATCH" || sub.URL != "/services/data/v63.0/sobjects/Opportunity/006A" {
+		t.Errorf("Unexpected sub-request: %+v", sub)
+	}
+	if body, _ := sub.Body.(map[string]interface{}); body["StageName"] != "Closed Won" || body["Id"] != nil {
+		t.Errorf("Expected body with only the changed fields, got %v", sub.Body)
+	}
+
+	if len(results) != 2 || !results[0].Success || results[0].Id != "006A" {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if results[1].Success || len(results[1].Errors) != 1 || results[1].Errors[0].Fields[0] != "Amount" {
+		t.Errorf("Expected a field error for 006B, got %+v", results[1])
+	}
+}
+
+func TestUpdateRecordsHandlesBridgeErrorsAndBatches(t *testing.T) {
+	var requests int
+	post := func(url string, body []byte) ([]byte, error) {
+		requests++
+		// Fail the first record of each batch the way GoBridge reports it and
+		// skip the rest, as an allOrNone request does.
+		return []byte(`{"compositeResponse":[{"body":{"message":"locked","errorCode":"COMPOSITE_SUB_REQUEST_ERROR"},"httpStatusCode":400,"referenceId":"record0"}]}`), nil
+	}
+
+	var records []map[string]interface{}
+	for i := 0; i < 30; i++ {
+		records = append(records, map[string]interface{}{"Id": fmt.Sprintf("001%02d", i), "Name": "x"})
+	}
+	results, err := updateRecords(post, "Account", records, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 composite requests for 30 records, got %d", requests)
+	}
+	if len(results) != 30 {
+		t.Fatalf("Expected 30 results, got %d", len(results))
+	}
+	if results[0].Success || results[0].Errors[0].Message != "locked" {
+		t.Errorf("Expected the GoBridge error on the first record, got %+v", results[0])
+	}
+	if results[1].Success || results[1].Id != "00101" {
+		t.Errorf("Expected the skipped record to be reported as failed, got %+v", results[1])
+	}
+	if results[25].Id != "00125" || results[25].Errors[0].Message != "locked" {
+		t.Errorf("Expected the second batch to start at record 25, got %+v", results[25])
+	}
+}
+
+func TestUpdateRecordsRequiresId(t *testing.T) {
+	post := func(string, []byte) ([]byte, error) {
+		t.Fatal("Expected no request")
+		return nil, nil
+	}
+	if _, err := updateRecords(post, "Account", []map[string]interface{}{{"Name": "x"}}, false); err == nil {
+		t.Error("Expected an error for a record without an Id")
+	}
+}
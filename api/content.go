@@ -0,0 +1,24 @@
+//go:build js && !dev
+// +build js,!dev
+
+package api
+
+// insertContentVersion creates a ContentVersion through GoBridge, which
+// decodes the base64 VersionData.
+func insertContentVersion(f File) ([]byte, error) {
+	body, err := contentVersionJSON(f)
+	if err != nil {
+		return nil, err
+	}
+	return Post("/services/data/v63.0/sobjects/ContentVersion", body)
+}
+
+// contentVersionData queries a ContentVersion's VersionData through GoBridge,
+// which returns it encoded as base64.
+func contentVersionData(id string) ([]byte, error) {
+	data, err := Get(versionDataQueryURL(id))
+	if err != nil {
+		return nil, err
+	}
+	return decodeVersionData(data)
+}
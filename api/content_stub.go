@@ -0,0 +1,14 @@
+//go:build !js
+// +build !js
+
+package api
+
+// insertContentVersion is a stub implementation for non-WASM builds and will panic if called.
+func insertContentVersion(f File) ([]byte, error) {
+	panic("api.UploadFiles is not supported outside the WASM environment")
+}
+
+// contentVersionData is a stub implementation for non-WASM builds and will panic if called.
+func contentVersionData(id string) ([]byte, error) {
+	panic("api.FileData is not supported outside the WASM environment")
+}
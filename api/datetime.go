@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateTimeLayouts are the forms in which the REST API returns DateTime
+// values.
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05.000Z",
+	time.RFC3339Nano,
+}
+
+// ParseDateTime parses a DateTime field value as returned by the REST API,
+// e.g. "2025-03-14T17:30:00.000+0000".
+func ParseDateTime(s string) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid DateTime %q", s)
+}
+
+// FormatDateTime formats t as a DateTime field value for the REST API, in
+// UTC.
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// ParseDate parses a Date field value such as "2025-03-14". The date is
+// returned at midnight UTC.
+func ParseDate(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Date %q", s)
+	}
+	return t, nil
+}
+
+// FormatDate formats the calendar date of t as a Date field value.
+func FormatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	want := time.Date(2025, 3, 14, 17, 30, 0, 0, time.UTC)
+	for _, s := range []string{"2025-03-14T17:30:00.000+0000", "2025-03-14T17:30:00.000Z", "2025-03-14T10:30:00-07:00"} {
+		got, err := ParseDateTime(s)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDateTime(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseDateTime("March 14"); err == nil {
+		t.Error("expected an error for an invalid DateTime")
+	}
+}
+
+func TestFormatDateTimeUsesUTC(t *testing.T) {
+	loc, err := UserInfo{TimeZone: "America/Los_Angeles"}.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := time.Date(2025, 3, 14, 10, 30, 0, 0, loc)
+	if got := FormatDateTime(local); got != "2025-03-14T17:30:00.000Z" {
+		t.Errorf("FormatDateTime() = %q", got)
+	}
+}
+
+func TestParseAndFormatDate(t *testing.T) {
+	d, err := ParseDate("2025-03-14")
+	if err != nil || FormatDate(d) != "2025-03-14" {
+		t.Errorf("unexpected date %v, %v", d, err)
+	}
+	if _, err := ParseDate("2025-02-30"); err == nil {
+		t.Error("expected an error for an invalid Date")
+	}
+}
+
+func TestUserInfoLocation(t *testing.T) {
+	if loc, err := (UserInfo{}).Location(); err != nil || loc != time.UTC {
+		t.Errorf("expected UTC for an empty zone, got %v, %v", loc, err)
+	}
+	if loc, err := (UserInfo{TimeZone: "Not/AZone"}).Location(); err == nil || loc != time.UTC {
+		t.Errorf("expected UTC and an error for an unknown zone, got %v, %v", loc, err)
+	}
+}
+
+func TestGetUserInfoStub(t *testing.T) {
+	info, err := GetUserInfo()
+	if err != nil || info.TimeZone != "UTC" || info.Locale != "en_US" {
+		t.Errorf("unexpected stub user info %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,327 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	forcequery "github.com/ForceCLI/force/lib/query"
+)
+
+// File is a file's name, MIME type and contents, such as one chosen in a
+// components.FileSelector.
+type File struct {
+	Name string
+	Type string
+	Data []byte
+}
+
+// UploadOptions configures UploadFiles.
+type UploadOptions struct {
+	// RecordID is the record the files are linked to. It defaults to the
+	// current record from RecordId; when there is none, the files are only
+	// added to the user's own files.
+	RecordID string
+	// OnProgress, if set, is called with the bytes uploaded so far and the
+	// total: once before the first file and again as each file is saved.
+	OnProgress func(sent, total int64)
+}
+
+// UploadedFile identifies a file saved by UploadFiles.
+type UploadedFile struct {
+	Name              string
+	ContentVersionID  string
+	ContentDocumentID string
+}
+
+// UploadFiles saves each file as a ContentVersion and links the resulting
+// ContentDocument to a record with a ContentDocumentLink, so the files show
+// in the record's Files related list. Files are uploaded one at a time; if
+// one fails, the files already uploaded are returned along with the error.
+//
+// Under Lightning, GoBridge decodes each upload in Apex, whose heap limit
+// caps files at a few megabytes. thunder serve sends files as multipart
+// requests, which Salesforce accepts up to 2 GB.
+func UploadFiles(files []File, opts UploadOptions) ([]UploadedFile, error) {
+	if opts.RecordID == "" {
+		if id, err := RecordId(); err == nil {
+			opts.RecordID = id
+		}
+	}
+	return uploadFiles(insertContentVersion, Get, Post, files, opts)
+}
+
+func uploadFiles(insert func(File) ([]byte, error), get forcequery.HttpGetter, post func(string, []byte) ([]byte, error), files []File, opts UploadOptions) ([]UploadedFile, error) {
+	var sent, total int64
+	for _, f := range files {
+		total += int64(len(f.Data))
+	}
+	progress := func() {
+		if opts.OnProgress != nil {
+			opts.OnProgress(sent, total)
+		}
+	}
+	progress()
+
+	uploaded := make([]UploadedFile, 0, len(files))
+	for _, f := range files {
+		file, err := uploadFile(insert, get, post, f, opts.RecordID)
+		if err != nil {
+			return uploaded, fmt.Errorf("upload of %s failed: %w", f.Name, err)
+		}
+		uploaded = append(uploaded, file)
+		sent += int64(len(f.Data))
+		progress()
+	}
+	return uploaded, nil
+}
+
+func uploadFile(insert func(File) ([]byte, error), get forcequery.HttpGetter, post func(string, []byte) ([]byte, error), f File, recordID string) (UploadedFile, error) {
+	file := UploadedFile{Name: f.Name}
+	data, err := insert(f)
+	if err != nil {
+		return file, err
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &created); err != nil || created.ID == "" {
+		return file, fmt.Errorf("unexpected response: %s", data)
+	}
+	file.ContentVersionID = created.ID
+
+	soql := fmt.Sprintf("SELECT ContentDocumentId FROM ContentVersion WHERE Id = '%s'", created.ID)
+	data, err = get("/services/data/v63.0/query?q=" + url.QueryEscape(soql))
+	if err != nil {
+		return file, err
+	}
+	var versions struct {
+		Records []struct {
+			ContentDocumentID string `json:"ContentDocumentId"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(data, &versions); err != nil || len(versions.Records) == 0 {
+		return file, fmt.Errorf("ContentVersion %s not found", created.ID)
+	}
+	file.ContentDocumentID = versions.Records[0].ContentDocumentID
+
+	if recordID == "" {
+		return file, nil
+	}
+	link, err := json.Marshal(map[string]string{
+		"ContentDocumentId": file.ContentDocumentID,
+		"LinkedEntityId":    recordID,
+		"ShareType":         "V",
+	})
+	if err != nil {
+		return file, err
+	}
+	if _, err := post("/services/data/v63.0/sobjects/ContentDocumentLink", link); err != nil {
+		return file, fmt.Errorf("failed to link to %s: %w", recordID, err)
+	}
+	return file, nil
+}
+
+// contentVersionFields returns the fields of the ContentVersion saving f,
+// other than its data.
+func contentVersionFields(f File) map[string]string {
+	title := strings.TrimSuffix(f.Name, path.Ext(f.Name))
+	if title == "" {
+		title = f.Name
+	}
+	return map[string]string{
+		"Title":        title,
+		"PathOnClient": f.Name,
+	}
+}
+
+// contentVersionJSON returns the body of a request creating a ContentVersion
+// with f's data encoded as base64.
+func contentVersionJSON(f File) ([]byte, error) {
+	fields := contentVersionFields(f)
+	fields["VersionData"] = base64.StdEncoding.EncodeToString(f.Data)
+	return json.Marshal(fields)
+}
+
+// contentVersionMultipart returns the body and content type of a multipart
+// request creating a ContentVersion, which sends f's data unencoded.
+func contentVersionMultipart(f File) ([]byte, string, error) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	fields, err := json.Marshal(contentVersionFields(f))
+	if err != nil {
+		return nil, "", err
+	}
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="entity_content"`)
+	h.Set("Content-Type", "application/json")
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return nil, "", err
+	}
+	part.Write(fields)
+
+	mimeType := f.Type
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	h = textproto.MIMEHeader{}
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="VersionData"; filename="%s"`, multipartEscaper.Replace(f.Name)))
+	h.Set("Content-Type", mimeType)
+	part, err = w.CreatePart(h)
+	if err != nil {
+		return nil, "", err
+	}
+	part.Write(f.Data)
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), w.FormDataContentType(), nil
+}
+
+var multipartEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// RecordFile describes a file linked to a record, as listed by RecordFiles.
+type RecordFile struct {
+	ContentDocumentID string
+	// LatestVersionID is the ContentVersion holding the file's current
+	// contents, as read by FileData.
+	LatestVersionID string
+	Title           string
+	// FileType is Salesforce's name for the type of file, such as "PDF",
+	// "PNG" or "WORD_X".
+	FileType         string
+	FileExtension    string
+	ContentSize      int64
+	OwnerName        string
+	LastModifiedDate time.Time
+}
+
+// FileName returns the file's title with its extension, for saving it.
+func (f RecordFile) FileName() string {
+	if f.FileExtension == "" || strings.HasSuffix(strings.ToLower(f.Title), "."+strings.ToLower(f.FileExtension)) {
+		return f.Title
+	}
+	return f.Title + "." + f.FileExtension
+}
+
+// MimeType returns the MIME type of the file's extension, or
+// "application/octet-stream" if it is unknown.
+func (f RecordFile) MimeType() string {
+	if t := mime.TypeByExtension("." + strings.ToLower(f.FileExtension)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// recordIDPattern matches 15 and 18 character record Ids.
+var recordIDPattern = regexp.MustCompile(`^[a-zA-Z0-9]{15}(?:[a-zA-Z0-9]{3})?$`)
+
+// RecordFiles returns the files linked to a record through
+// ContentDocumentLinks, most recently modified first.
+func RecordFiles(recordID string) ([]RecordFile, error) {
+	return recordFiles(Get, recordID)
+}
+
+func recordFiles(get forcequery.HttpGetter, recordID string) ([]RecordFile, error) {
+	if !recordIDPattern.MatchString(recordID) {
+		return nil, fmt.Errorf("invalid record Id %q", recordID)
+	}
+	soql := "SELECT ContentDocumentId, ContentDocument.Title, ContentDocument.FileType, " +
+		"ContentDocument.FileExtension, ContentDocument.ContentSize, ContentDocument.Owner.Name, " +
+		"ContentDocument.LastModifiedDate, ContentDocument.LatestPublishedVersionId " +
+		"FROM ContentDocumentLink WHERE LinkedEntityId = '" + recordID + "' " +
+		"ORDER BY ContentDocument.LastModifiedDate DESC"
+	raw, err := forcequery.Eager(
+		forcequery.InstanceUrl(""),
+		forcequery.ApiVersion("v63.0"),
+		forcequery.QS(soql),
+		forcequery.HttpGet(get),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query of files failed: %w", err)
+	}
+	files := make([]RecordFile, len(raw))
+	for i, r := range raw {
+		record := Record{r}
+		str := func(path string) string {
+			s, _ := record.StringValue(path)
+			return s
+		}
+		file := RecordFile{
+			ContentDocumentID: str("ContentDocumentId"),
+			LatestVersionID:   str("ContentDocument.LatestPublishedVersionId"),
+			Title:             str("ContentDocument.Title"),
+			FileType:          str("ContentDocument.FileType"),
+			FileExtension:     str("ContentDocument.FileExtension"),
+			OwnerName:         str("ContentDocument.Owner.Name"),
+		}
+		if size, err := record.Value("ContentDocument.ContentSize"); err == nil {
+			if n, ok := size.(float64); ok {
+				file.ContentSize = int64(n)
+			}
+		}
+		file.LastModifiedDate, _ = ParseDateTime(str("ContentDocument.LastModifiedDate"))
+		files[i] = file
+	}
+	return files, nil
+}
+
+// FileData returns the contents of a file version, such as a RecordFile's
+// LatestVersionID. Under Lightning the contents pass through Apex, whose
+// heap limit caps the files that can be read at a few megabytes.
+func FileData(contentVersionID string) ([]byte, error) {
+	if !recordIDPattern.MatchString(contentVersionID) {
+		return nil, fmt.Errorf("invalid ContentVersion Id %q", contentVersionID)
+	}
+	data, err := contentVersionData(contentVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("download of %s failed: %w", contentVersionID, err)
+	}
+	return data, nil
+}
+
+// DownloadFile reads a file's latest version with FileData and saves it to
+// the user's computer with Download.
+func DownloadFile(file RecordFile) error {
+	data, err := FileData(file.LatestVersionID)
+	if err != nil {
+		return err
+	}
+	Download(file.FileName(), file.MimeType(), data)
+	return nil
+}
+
+// versionDataQueryURL returns the URL of a query for a ContentVersion's
+// VersionData.
+func versionDataQueryURL(id string) string {
+	return "/services/data/v63.0/query?q=" + url.QueryEscape("SELECT VersionData FROM ContentVersion WHERE Id = '"+id+"'")
+}
+
+// decodeVersionData reads the VersionData from a query of a ContentVersion
+// run in Apex, which serializes it as base64.
+func decodeVersionData(data []byte) ([]byte, error) {
+	var resp struct {
+		Records []struct {
+			VersionData string `json:"VersionData"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Records) == 0 {
+		return nil, fmt.Errorf("ContentVersion not found")
+	}
+	return base64.StdEncoding.DecodeString(resp.Records[0].VersionData)
+}
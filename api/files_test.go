@@ -0,0 +1,274 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestUploadFilesLinksContentDocuments verifies each file is saved as a
+// ContentVersion and its ContentDocument linked to the record
+func TestUploadFilesLinksContentDocuments(t *testing.T) {
+	var inserted []string
+	insert := func(f File) ([]byte, error) {
+		inserted = append(inserted, f.Name)
+		return []byte(`{"id":"068` + f.Name[:1] + `","success":true,"errors":[]}`), nil
+	}
+	var queried []string
+	get := func(u string) ([]byte, error) {
+		queried = append(queried, u)
+		return []byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"ContentVersion"},"ContentDocumentId":"069X"}]}`), nil
+	}
+	var links []map[string]string
+	post := func(u string, body []byte) ([]byte, error) {
+		if u != "/services/data/v63.0/sobjects/ContentDocumentLink" {
+			t.Errorf("Unexpected POST to %s", u)
+		}
+		var link map[string]string
+		if err := json.Unmarshal(body, &link); err != nil {
+			t.Fatalf("Invalid link body: %v", err)
+		}
+		links = append(links, link)
+		return []byte(`{"id":"06AX","success":true,"errors":[]}`), nil
+	}
+	var progress [][2]int64
+	files := []File{
+		{Name: "a.txt", Type: "text/plain", Data: []byte("hello")},
+		{Name: "b.png", Type: "image/png", Data: []byte("abc")},
+	}
+
+	uploaded, err := uploadFiles(insert, get, post, files, UploadOptions{
+		RecordID:   "001A",
+		OnProgress: func(sent, total int64) { progress = append(progress, [2]int64{sent, total}) },
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if strings.Join(inserted, ",") != "a.txt,b.png" {
+		t.Errorf("Unexpected inserts: %v", inserted)
+	}
+	want := "/services/data/v63.0/query?q=" + url.QueryEscape("SELECT ContentDocumentId FROM ContentVersion WHERE Id = '068a'")
+	if len(queried) != 2 || queried[0] != want {
+		t.Errorf("Unexpected queries: %v", queried)
+	}
+	if len(links) != 2 || links[0]["ContentDocumentId"] != "069X" || links[0]["LinkedEntityId"] != "001A" || links[0]["ShareType"] != "V" {
+		t.Errorf("Unexpected links: %v", links)
+	}
+	if len(uploaded) != 2 || uploaded[1] != (UploadedFile{Name: "b.png", ContentVersionID: "068b", ContentDocumentID: "069X"}) {
+		t.Errorf("Unexpected uploaded files: %+v", uploaded)
+	}
+	wantProgress := [][2]int64{{0, 8}, {5, 8}, {8, 8}}
+	if len(progress) != len(wantProgress) {
+		t.Fatalf("Expected progress %v, got %v", wantProgress, progress)
+	}
+	for i := range wantProgress {
+		if progress[i] != wantProgress[i] {
+			t.Errorf("Expected progress %v, got %v", wantProgress, progress)
+			break
+		}
+	}
+}
+
+// TestUploadFilesWithoutRecord verifies files are not linked without a record
+func TestUploadFilesWithoutRecord(t *testing.T) {
+	insert := func(File) ([]byte, error) { return []byte(`{"id":"068A"}`), nil }
+	get := func(string) ([]byte, error) {
+		return []byte(`{"records":[{"ContentDocumentId":"069A"}]}`), nil
+	}
+	post := func(u string, _ []byte) ([]byte, error) {
+		t.Errorf("Unexpected POST to %s", u)
+		return nil, nil
+	}
+	uploaded, err := uploadFiles(insert, get, post, []File{{Name: "a.txt"}}, UploadOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(uploaded) != 1 || uploaded[0].ContentDocumentID != "069A" {
+		t.Errorf("Unexpected uploaded files: %+v", uploaded)
+	}
+}
+
+// TestUploadFilesStopsAtFailure verifies the files uploaded before a failure
+// are returned with an error naming the failed file
+func TestUploadFilesStopsAtFailure(t *testing.T) {
+	insert := func(f File) ([]byte, error) {
+		if f.Name == "b.txt" {
+			return nil, newRestError("POST", "/services/data/v63.0/sobjects/ContentVersion", 400,
+				`[{"message":"File too large","errorCode":"STRING_TOO_LONG","fields":["VersionData"]}]`)
+		}
+		return []byte(`{"id":"068A"}`), nil
+	}
+	get := func(string) ([]byte, error) {
+		return []byte(`{"records":[{"ContentDocumentId":"069A"}]}`), nil
+	}
+	post := func(string, []byte) ([]byte, error) { return []byte(`{"id":"06AA"}`), nil }
+	files := []File{{Name: "a.txt"}, {Name: "b.txt"}, {Name: "c.txt"}}
+
+	uploaded, err := uploadFiles(insert, get, post, files, UploadOptions{RecordID: "001A"})
+	if err == nil || !strings.Contains(err.Error(), "b.txt") {
+		t.Fatalf("Expected an error naming b.txt, got: %v", err)
+	}
+	var restErr *RestError
+	if !errors.As(err, &restErr) {
+		t.Errorf("Expected the RestError to be wrapped, got: %v", err)
+	}
+	if len(uploaded) != 1 || uploaded[0].Name != "a.txt" {
+		t.Errorf("Expected only a.txt uploaded, got %+v", uploaded)
+	}
+}
+
+// TestContentVersionJSON verifies the file is titled without its extension
+// and its data encoded as base64
+func TestContentVersionJSON(t *testing.T) {
+	body, err := contentVersionJSON(File{Name: "report.final.pdf", Data: []byte("hello")})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var fields map[string]string
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("Invalid body: %v", err)
+	}
+	want := map[string]string{"Title": "report.final", "PathOnClient": "report.final.pdf", "VersionData": "aGVsbG8="}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("Expected %s %q, got %q", k, v, fields[k])
+		}
+	}
+}
+
+// TestContentVersionMultipart verifies the fields and unencoded data are sent
+// as the parts Salesforce expects
+func TestContentVersionMultipart(t *testing.T) {
+	body, contentType, err := contentVersionMultipart(File{Name: `a "b".png`, Type: "image/png", Data: []byte{0, 1, 2}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Unexpected content type %q (%v)", contentType, err)
+	}
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+
+	part, err := r.NextPart()
+	if err != nil {
+		t.Fatalf("Missing entity_content part: %v", err)
+	}
+	if part.FormName() != "entity_content" || part.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected first part: %v", part.Header)
+	}
+	var fields map[string]string
+	if err := json.NewDecoder(part).Decode(&fields); err != nil {
+		t.Fatalf("Invalid entity_content: %v", err)
+	}
+	if fields["Title"] != `a "b"` || fields["PathOnClient"] != `a "b".png` || fields["VersionData"] != "" {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+
+	part, err = r.NextPart()
+	if err != nil {
+		t.Fatalf("Missing VersionData part: %v", err)
+	}
+	if part.FormName() != "VersionData" || part.FileName() != `a "b".png` || part.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("Unexpected VersionData part: %v", part.Header)
+	}
+	data, _ := io.ReadAll(part)
+	if !bytes.Equal(data, []byte{0, 1, 2}) {
+		t.Errorf("Expected raw data, got %v", data)
+	}
+}
+
+// TestRecordFilesReadsContentDocuments verifies a record's files are queried
+// through its ContentDocumentLinks
+func TestRecordFilesReadsContentDocuments(t *testing.T) {
+	var requested string
+	get := func(u string) ([]byte, error) {
+		requested = u
+		return []byte(`{"totalSize":1,"done":true,"records":[{
+			"attributes":{"type":"ContentDocumentLink"},
+			"ContentDocumentId":"069A",
+			"ContentDocument":{
+				"attributes":{"type":"ContentDocument"},
+				"Title":"Q3 Report","FileType":"PDF","FileExtension":"pdf","ContentSize":123456,
+				"LastModifiedDate":"2025-03-14T17:30:00.000+0000","LatestPublishedVersionId":"068A",
+				"Owner":{"attributes":{"type":"User"},"Name":"Ann Admin"}}}]}`), nil
+	}
+	files, err := recordFiles(get, "001000000000001AAA")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(requested, url.QueryEscape("FROM ContentDocumentLink WHERE LinkedEntityId = '001000000000001AAA'")) {
+		t.Errorf("Unexpected request URL: %q", requested)
+	}
+	want := RecordFile{
+		ContentDocumentID: "069A",
+		LatestVersionID:   "068A",
+		Title:             "Q3 Report",
+		FileType:          "PDF",
+		FileExtension:     "pdf",
+		ContentSize:       123456,
+		OwnerName:         "Ann Admin",
+		LastModifiedDate:  time.Date(2025, 3, 14, 17, 30, 0, 0, time.UTC),
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(files))
+	}
+	got := files[0]
+	if !got.LastModifiedDate.Equal(want.LastModifiedDate) {
+		t.Errorf("Expected LastModifiedDate %v, got %v", want.LastModifiedDate, got.LastModifiedDate)
+	}
+	got.LastModifiedDate = want.LastModifiedDate
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+// TestRecordFilesRejectsInvalidIds verifies record Ids are checked before
+// being put in a query
+func TestRecordFilesRejectsInvalidIds(t *testing.T) {
+	get := func(string) ([]byte, error) {
+		t.Fatal("Expected no request")
+		return nil, nil
+	}
+	if _, err := recordFiles(get, "001' OR Id != '"); err == nil {
+		t.Error("Expected an error for an invalid Id")
+	}
+}
+
+// TestRecordFileNameAndMimeType verifies the extension is added to titles
+// that lack it and determines the MIME type
+func TestRecordFileNameAndMimeType(t *testing.T) {
+	f := RecordFile{Title: "Q3 Report", FileExtension: "pdf"}
+	if f.FileName() != "Q3 Report.pdf" || f.MimeType() != "application/pdf" {
+		t.Errorf("Unexpected name %q and type %q", f.FileName(), f.MimeType())
+	}
+	f = RecordFile{Title: "logo.PNG", FileExtension: "png"}
+	if f.FileName() != "logo.PNG" || f.MimeType() != "image/png" {
+		t.Errorf("Unexpected name %q and type %q", f.FileName(), f.MimeType())
+	}
+	f = RecordFile{Title: "data", FileExtension: "thunderunknown"}
+	if f.MimeType() != "application/octet-stream" {
+		t.Errorf("Expected application/octet-stream, got %q", f.MimeType())
+	}
+}
+
+// TestDecodeVersionData verifies VersionData queried in Apex is decoded from
+// base64
+func TestDecodeVersionData(t *testing.T) {
+	if u := versionDataQueryURL("068A"); u != "/services/data/v63.0/query?q="+url.QueryEscape("SELECT VersionData FROM ContentVersion WHERE Id = '068A'") {
+		t.Errorf("Unexpected query URL: %q", u)
+	}
+	data, err := decodeVersionData([]byte(`{"totalSize":1,"done":true,"records":[{"attributes":{"type":"ContentVersion"},"VersionData":"aGVsbG8="}]}`))
+	if err != nil || string(data) != "hello" {
+		t.Errorf("Expected hello, got %q (%v)", data, err)
+	}
+	if _, err := decodeVersionData([]byte(`{"totalSize":0,"done":true,"records":[]}`)); err == nil {
+		t.Error("Expected an error when the version is missing")
+	}
+}
@@ -0,0 +1,31 @@
+//go:build js && !dev
+// +build js,!dev
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetLabels retrieves the named Custom Labels in language, or the running
+// user's language when it is empty. Labels that do not exist are left out.
+func GetLabels(language string, names ...string) (*Labels, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"language": language,
+		"names":    names,
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData, err := Post("/services/apexrest/GoBridge/getLabels", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve labels: %w", err)
+	}
+
+	var labels Labels
+	if err := json.Unmarshal(responseData, &labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal labels: %w", err)
+	}
+	return &labels, nil
+}
@@ -0,0 +1,84 @@
+//go:build dev
+// +build dev
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetLabels retrieves the named Custom Labels in language, or the running
+// user's language when it is empty, by querying the Tooling API through the
+// thunder serve proxy. Labels that do not exist are left out.
+func GetLabels(language string, names ...string) (*Labels, error) {
+	labels := &Labels{Translated: map[string]string{}, Defaults: map[string]string{}}
+	if len(names) == 0 {
+		return labels, nil
+	}
+	if language == "" {
+		info, err := GetUserInfo()
+		if err != nil {
+			return nil, err
+		}
+		language = info.Language
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + strings.ReplaceAll(name, "'", `\'`) + "'"
+	}
+	var masters struct {
+		Records []struct {
+			ID       string `json:"Id"`
+			Name     string `json:"Name"`
+			Value    string `json:"Value"`
+			Language string `json:"Language"`
+		} `json:"records"`
+	}
+	if err := toolingQuery(fmt.Sprintf(
+		"SELECT Id, Name, Value, Language FROM ExternalString WHERE NamespacePrefix = null AND Name IN (%s)",
+		strings.Join(quoted, ", ")), &masters); err != nil {
+		return nil, fmt.Errorf("failed to retrieve labels: %w", err)
+	}
+	if len(masters.Records) == 0 {
+		return labels, nil
+	}
+
+	ids := make([]string, 0, len(masters.Records))
+	byID := make(map[string]string, len(masters.Records))
+	for _, r := range masters.Records {
+		labels.Defaults[r.Name] = r.Value
+		if r.Language == language {
+			labels.Translated[r.Name] = r.Value
+		}
+		ids = append(ids, "'"+r.ID+"'")
+		byID[r.ID] = r.Name
+	}
+	var translations struct {
+		Records []struct {
+			ExternalStringID string `json:"ExternalStringId"`
+			Value            string `json:"Value"`
+		} `json:"records"`
+	}
+	if err := toolingQuery(fmt.Sprintf(
+		"SELECT ExternalStringId, Value FROM ExternalStringLocalization WHERE Language = '%s' AND ExternalStringId IN (%s)",
+		strings.ReplaceAll(language, "'", `\'`), strings.Join(ids, ", ")), &translations); err != nil {
+		return nil, fmt.Errorf("failed to retrieve label translations: %w", err)
+	}
+	for _, r := range translations.Records {
+		labels.Translated[byID[r.ExternalStringID]] = r.Value
+	}
+	return labels, nil
+}
+
+// toolingQuery runs a Tooling API query and unmarshals the response into v.
+func toolingQuery(soql string, v interface{}) error {
+	data, err := Get("/services/data/v63.0/tooling/query?q=" + url.QueryEscape(soql))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,10 @@
+//go:build !js && !dev
+// +build !js,!dev
+
+package api
+
+// GetLabels stub implementation for non-WASM, non-dev builds. No labels
+// exist, so it returns empty maps.
+func GetLabels(language string, names ...string) (*Labels, error) {
+	return &Labels{Translated: map[string]string{}, Defaults: map[string]string{}}, nil
+}
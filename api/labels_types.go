@@ -0,0 +1,9 @@
+package api
+
+// Labels holds Custom Label values returned by GetLabels.
+type Labels struct {
+	// Translated holds the labels translated into the requested language.
+	Translated map[string]string `json:"translated"`
+	// Defaults holds each label's value in the org's default language.
+	Defaults map[string]string `json:"defaults"`
+}
@@ -100,3 +100,22 @@ func UnmarshalObjectInfo(data []byte) (ObjectInfo, error) {
 	}
 	return info, nil
 }
+
+// ReferenceToInfo describes an object a lookup field can reference.
+type ReferenceToInfo struct {
+	APIName    string   `json:"apiName"`
+	NameFields []string `json:"nameFields"`
+}
+
+// ReferenceTargets returns the objects the lookup field can reference. A
+// polymorphic field, such as WhoId, returns more than one.
+func (f FieldInfo) ReferenceTargets() []ReferenceToInfo {
+	var targets []ReferenceToInfo
+	for _, raw := range f.ReferenceToInfos {
+		var info ReferenceToInfo
+		if err := json.Unmarshal(raw, &info); err == nil && info.APIName != "" {
+			targets = append(targets, info)
+		}
+	}
+	return targets
+}
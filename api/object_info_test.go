@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -113,3 +114,25 @@ func TestUnmarshalObjectInfo(t *testing.T) {
 		t.Error("expected field Name to be present")
 	}
 }
+
+// TestFieldInfoReferenceTargets verifies the referenced objects of a
+// polymorphic lookup are parsed from referenceToInfos
+func TestFieldInfoReferenceTargets(t *testing.T) {
+	var field FieldInfo
+	data := `{"apiName":"WhoId","dataType":"Reference","referenceToInfos":[
+		{"apiName":"Contact","nameFields":["FirstName","LastName","Name"]},
+		{"apiName":"Lead","nameFields":["Name"]}]}`
+	if err := json.Unmarshal([]byte(data), &field); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	targets := field.ReferenceTargets()
+	if len(targets) != 2 {
+		t.Fatalf("Expected 2 targets, got %d", len(targets))
+	}
+	if targets[0].APIName != "Contact" || len(targets[0].NameFields) != 3 {
+		t.Errorf("Unexpected first target: %+v", targets[0])
+	}
+	if targets[1].APIName != "Lead" {
+		t.Errorf("Expected Lead, got %q", targets[1].APIName)
+	}
+}
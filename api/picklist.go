@@ -1,6 +1,13 @@
 package api
 
-import "encoding/json"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
 
 // PicklistFieldValue holds the details for a single picklist field.
 type PicklistFieldValue struct {
@@ -32,3 +39,159 @@ func UnmarshalPicklistFieldValues(data []byte) (map[string]PicklistFieldValue, e
 	}
 	return resp.PicklistFieldValues, nil
 }
+
+// ControllingValue converts the value of a controlling field to the key used
+// in ControllerValues: the picklist value itself, or "true"/"false" for a
+// checkbox controlling field.
+func ControllingValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// SplitMultiSelect splits a multi-select picklist value, which Salesforce
+// stores as semicolon-delimited text (e.g. "Red;Green"), into its values.
+// Empty values are dropped.
+func SplitMultiSelect(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// JoinMultiSelect joins values into the semicolon-delimited text stored in a
+// multi-select picklist field. Empty and repeated values are dropped.
+func JoinMultiSelect(values []string) string {
+	var kept []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(kept, v) {
+			kept = append(kept, v)
+		}
+	}
+	return strings.Join(kept, ";")
+}
+
+// IsDependent reports whether the picklist depends on a controlling field.
+func (p PicklistFieldValue) IsDependent() bool {
+	return len(p.ControllerValues) > 0
+}
+
+// ValidValues returns the values of a dependent picklist that are valid for
+// the controlling field's value (see ControllingValue). Each value's ValidFor
+// lists the indexes, from ControllerValues, of the controlling values it is
+// valid for. A picklist that is not dependent returns all its values; an
+// unknown or empty controlling value returns none.
+func (p PicklistFieldValue) ValidValues(controllingValue string) []PicklistValue {
+	if !p.IsDependent() {
+		return p.Values
+	}
+	index, ok := p.ControllerValues[controllingValue]
+	if !ok || controllingValue == "" {
+		return nil
+	}
+	var valid []PicklistValue
+	for _, v := range p.Values {
+		if slices.Contains(v.ValidFor, index) {
+			valid = append(valid, v)
+		}
+	}
+	return valid
+}
+
+// IsValidFor reports whether value is a valid choice of a dependent picklist
+// for the controlling field's value.
+func (p PicklistFieldValue) IsValidFor(value, controllingValue string) bool {
+	for _, v := range p.ValidValues(controllingValue) {
+		if v.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeValidFor decodes the base64 validFor bitset returned by the sObject
+// describe API into the indexes of the controlling values a dependent value is
+// valid for, matching PicklistValue.ValidFor from the UI API. Bits are read
+// most significant first within each byte.
+func DecodeValidFor(validFor string) ([]int, error) {
+	bits, err := base64.StdEncoding.DecodeString(validFor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validFor bitset: %w", err)
+	}
+	var indexes []int
+	for i, b := range bits {
+		for bit := 0; bit < 8; bit++ {
+			if b&(0x80>>bit) != 0 {
+				indexes = append(indexes, i*8+bit)
+			}
+		}
+	}
+	return indexes, nil
+}
+
+// ReconcileDependentPicklists returns the dependent picklist fields whose value
+// in values is no longer valid for their controlling field, mapped to nil so
+// they can be cleared. A dependent multi-select picklist only loses the values
+// that are no longer valid: it maps to the remaining values, joined with
+// JoinMultiSelect, or to nil when none remain. Dependencies are followed
+// through several levels (e.g. Region → Country → City): clearing a field also
+// clears the fields it controls. Controlling fields come from
+// FieldInfo.ControllerName.
+func ReconcileDependentPicklists(info ObjectInfo, picklists map[string]PicklistFieldValue, values map[string]interface{}) map[string]interface{} {
+	current := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		current[k] = v
+	}
+	reconciled := make(map[string]interface{})
+	// Each pass clears one more level of a dependency chain.
+	for changed := true; changed; {
+		changed = false
+		for name, field := range info.Fields {
+			if field.ControllerName == nil {
+				continue
+			}
+			value := ControllingValue(current[name])
+			if value == "" {
+				continue
+			}
+			picklist, ok := picklists[name]
+			if !ok {
+				continue
+			}
+			controlling := ControllingValue(current[*field.ControllerName])
+			if field.DataType == "MultiPicklist" {
+				selected := SplitMultiSelect(value)
+				kept := slices.DeleteFunc(slices.Clone(selected), func(v string) bool {
+					return !picklist.IsValidFor(v, controlling)
+				})
+				if len(kept) == len(selected) {
+					continue
+				}
+				var next interface{}
+				if len(kept) > 0 {
+					next = JoinMultiSelect(kept)
+				}
+				current[name] = next
+				reconciled[name] = next
+				changed = true
+				continue
+			}
+			if !picklist.IsValidFor(value, controlling) {
+				current[name] = nil
+				reconciled[name] = nil
+				changed = true
+			}
+		}
+	}
+	return reconciled
+}
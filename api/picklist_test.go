@@ -1,6 +1,7 @@
 package api
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -117,3 +118,150 @@ func TestUnmarshalPicklistFieldValues(t *testing.T) {
 		t.Errorf("expected first picklist value Advertisement, got %q", first.Value)
 	}
 }
+
+var regionPicklists = map[string]PicklistFieldValue{
+	"Region__c": {Values: []PicklistValue{{Label: "Europe", Value: "EU"}, {Label: "Americas", Value: "AM"}}},
+	"Country__c": {
+		ControllerValues: map[string]int{"EU": 0, "AM": 1},
+		Values: []PicklistValue{
+			{Label: "France", Value: "FR", ValidFor: []int{0}},
+			{Label: "Canada", Value: "CA", ValidFor: []int{1}},
+		},
+	},
+	"City__c": {
+		ControllerValues: map[string]int{"FR": 0, "CA": 1},
+		Values: []PicklistValue{
+			{Label: "Paris", Value: "Paris", ValidFor: []int{0}},
+			{Label: "Toronto", Value: "Toronto", ValidFor: []int{1}},
+		},
+	},
+	"Discount__c": {
+		ControllerValues: map[string]int{"false": 0, "true": 1},
+		Values: []PicklistValue{
+			{Label: "None", Value: "None", ValidFor: []int{0}},
+			{Label: "Partner", Value: "Partner", ValidFor: []int{1}},
+			{Label: "Volume", Value: "Volume", ValidFor: []int{1}},
+		},
+	},
+}
+
+func TestValidValues(t *testing.T) {
+	got := regionPicklists["Country__c"].ValidValues("AM")
+	if len(got) != 1 || got[0].Value != "CA" {
+		t.Errorf("Expected only Canada for AM, got %v", got)
+	}
+	if got := regionPicklists["Country__c"].ValidValues(""); len(got) != 0 {
+		t.Errorf("Expected no values without a controlling value, got %v", got)
+	}
+	if got := regionPicklists["Region__c"].ValidValues(""); len(got) != 2 {
+		t.Errorf("Expected every value of an independent picklist, got %v", got)
+	}
+	// Checkbox controlling fields use "true" and "false".
+	if got := regionPicklists["Discount__c"].ValidValues(ControllingValue(true)); len(got) != 2 {
+		t.Errorf("Expected 2 values for a checked controller, got %v", got)
+	}
+}
+
+func TestDecodeValidFor(t *testing.T) {
+	// 0b10100000 0b00000001: indexes 0, 2 and 15.
+	got, err := DecodeValidFor("oAE=")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 2, 15}) {
+		t.Errorf("Expected [0 2 15], got %v", got)
+	}
+	if _, err := DecodeValidFor("not base64!"); err == nil {
+		t.Error("Expected an error for an invalid bitset")
+	}
+}
+
+func TestReconcileDependentPicklists(t *testing.T) {
+	info := ObjectInfo{Fields: map[string]FieldInfo{
+		"Region__c":   {DataType: "Picklist"},
+		"Country__c":  {DataType: "Picklist", ControllerName: ptr("Region__c")},
+		"City__c":     {DataType: "Picklist", ControllerName: ptr("Country__c")},
+		"Partner__c":  {DataType: "Boolean"},
+		"Discount__c": {DataType: "Picklist", ControllerName: ptr("Partner__c")},
+	}}
+
+	// Changing the region invalidates the country, which in turn
+	// invalidates the city.
+	got := ReconcileDependentPicklists(info, regionPicklists, map[string]interface{}{
+		"Region__c": "EU", "Country__c": "CA", "City__c": "Toronto",
+		"Partner__c": true, "Discount__c": "Volume",
+	})
+	want := map[string]interface{}{"Country__c": nil, "City__c": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got = ReconcileDependentPicklists(info, regionPicklists, map[string]interface{}{
+		"Partner__c": false, "Discount__c": "Volume",
+	})
+	if _, ok := got["Discount__c"]; !ok {
+		t.Errorf("Expected the discount to be cleared when the checkbox is unchecked, got %v", got)
+	}
+}
+
+func TestReconcileDependentPicklists_multiSelect(t *testing.T) {
+	info := ObjectInfo{Fields: map[string]FieldInfo{
+		"Country__c":   {DataType: "Picklist"},
+		"Languages__c": {DataType: "MultiPicklist", ControllerName: ptr("Country__c")},
+	}}
+	picklists := map[string]PicklistFieldValue{
+		"Languages__c": {
+			ControllerValues: map[string]int{"CH": 0, "FR": 1},
+			Values: []PicklistValue{
+				{Label: "German", Value: "de", ValidFor: []int{0}},
+				{Label: "French", Value: "fr", ValidFor: []int{0, 1}},
+				{Label: "Italian", Value: "it", ValidFor: []int{0}},
+			},
+		},
+	}
+
+	// Every selected value is valid: nothing changes.
+	got := ReconcileDependentPicklists(info, picklists, map[string]interface{}{
+		"Country__c": "CH", "Languages__c": "de;fr",
+	})
+	if len(got) != 0 {
+		t.Errorf("Expected no changes, got %v", got)
+	}
+
+	// Only the values no longer valid are dropped.
+	got = ReconcileDependentPicklists(info, picklists, map[string]interface{}{
+		"Country__c": "FR", "Languages__c": "de;fr;it",
+	})
+	if want := map[string]interface{}{"Languages__c": "fr"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// The field is cleared when no value remains valid.
+	got = ReconcileDependentPicklists(info, picklists, map[string]interface{}{
+		"Country__c": "FR", "Languages__c": "de;it",
+	})
+	if want := map[string]interface{}{"Languages__c": nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func ptr(s string) *string { return &s }
+
+func TestSplitMultiSelect(t *testing.T) {
+	got := SplitMultiSelect("Red; Green;;Blue")
+	if want := []string{"Red", "Green", "Blue"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := SplitMultiSelect(""); len(got) != 0 {
+		t.Errorf("expected no values, got %v", got)
+	}
+}
+
+func TestJoinMultiSelect(t *testing.T) {
+	if got := JoinMultiSelect([]string{"Red", "", "Green", "Red"}); got != "Red;Green" {
+		t.Errorf("expected Red;Green, got %q", got)
+	}
+	if got := JoinMultiSelect(nil); got != "" {
+		t.Errorf("expected empty value, got %q", got)
+	}
+}
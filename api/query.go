@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 
 	forcequery "github.com/ForceCLI/force/lib/query"
@@ -26,3 +27,65 @@ func Query(soql string) ([]Record, error) {
 	}
 	return records, nil
 }
+
+// QueryResult is a single page of SOQL query results.
+type QueryResult struct {
+	Records   []Record
+	TotalSize int
+	// Done reports whether this is the last page.
+	Done bool
+	// NextRecordsURL locates the next page when Done is false.
+	NextRecordsURL string
+}
+
+// QueryPage executes the SOQL query and returns only its first page of
+// results. When the result is not Done, pass its NextRecordsURL to QueryMore
+// to fetch the next page, e.g. from a data table's load-more callback.
+func QueryPage(soql string) (QueryResult, error) {
+	return queryPage(Get, forcequery.ApiVersion("v63.0"), forcequery.QS(soql))
+}
+
+// QueryMore fetches the page of results located by nextRecordsURL, as
+// returned in a previous QueryResult.
+func QueryMore(nextRecordsURL string) (QueryResult, error) {
+	return queryPage(Get, forcequery.Tail(nextRecordsURL))
+}
+
+// queryPage fetches one page of results with get, keeping the paging fields
+// the query library does not expose.
+func queryPage(get forcequery.HttpGetter, opts ...forcequery.Option) (QueryResult, error) {
+	var body []byte
+	capture := func(url string) ([]byte, error) {
+		b, err := get(url)
+		// The first request is the page itself; later ones page through
+		// subquery results.
+		if body == nil {
+			body = b
+		}
+		return b, err
+	}
+	var page QueryResult
+	opts = append([]forcequery.Option{forcequery.InstanceUrl(""), forcequery.HttpGet(capture)}, opts...)
+	err := forcequery.Query(func(records []forcequery.Record) bool {
+		page.Records = make([]Record, len(records))
+		for i, r := range records {
+			page.Records[i] = Record{r}
+		}
+		return false
+	}, opts...)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("query failed: %w", err)
+	}
+	var meta struct {
+		TotalSize      int    `json:"totalSize"`
+		Done           bool   `json:"done"`
+		NextRecordsUrl string `json:"nextRecordsUrl"`
+	}
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return QueryResult{}, fmt.Errorf("query failed: %w", err)
+	}
+	page.TotalSize = meta.TotalSize
+	page.Done = meta.Done
+	page.NextRecordsURL = meta.NextRecordsUrl
+	return page, nil
+}
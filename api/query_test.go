@@ -0,0 +1,58 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	forcequery "github.com/ForceCLI/force/lib/query"
+)
+
+// TestQueryPageReturnsFirstPage verifies only the first page is fetched and
+// its paging fields are exposed
+func TestQueryPageReturnsFirstPage(t *testing.T) {
+	var requested []string
+	get := func(url string) ([]byte, error) {
+		requested = append(requested, url)
+		return []byte(`{"totalSize":3,"done":false,"nextRecordsUrl":"/services/data/v63.0/query/01g-2","records":[
+			{"attributes":{"type":"Account"},"Name":"Acme"},
+			{"attributes":{"type":"Account"},"Name":"Globex"}]}`), nil
+	}
+
+	page, err := queryPage(get, forcequery.ApiVersion("v63.0"), forcequery.QS("SELECT Name FROM Account"))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(requested) != 1 || !strings.HasPrefix(requested[0], "/services/data/v63.0/query?q=") {
+		t.Errorf("Expected a single query request, got %v", requested)
+	}
+	if len(page.Records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(page.Records))
+	}
+	if name, err := page.Records[1].StringValue("Name"); err != nil || name != "Globex" {
+		t.Errorf("Expected Globex, got %q (%v)", name, err)
+	}
+	if page.Done || page.TotalSize != 3 || page.NextRecordsURL != "/services/data/v63.0/query/01g-2" {
+		t.Errorf("Unexpected paging fields: %+v", page)
+	}
+}
+
+// TestQueryPageFollowsNextRecordsURL verifies QueryMore-style requests use the
+// locator URL as-is
+func TestQueryPageFollowsNextRecordsURL(t *testing.T) {
+	var requested string
+	get := func(url string) ([]byte, error) {
+		requested = url
+		return []byte(`{"totalSize":3,"done":true,"records":[{"attributes":{"type":"Account"},"Name":"Initech"}]}`), nil
+	}
+
+	page, err := queryPage(get, forcequery.Tail("/services/data/v63.0/query/01g-2"))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if requested != "/services/data/v63.0/query/01g-2" {
+		t.Errorf("Expected locator URL, got %q", requested)
+	}
+	if !page.Done || page.NextRecordsURL != "" || len(page.Records) != 1 {
+		t.Errorf("Unexpected last page: %+v", page)
+	}
+}
@@ -93,7 +93,7 @@ func Get(url string) ([]byte, error) {
 }
 
 // Post performs a POST via JS proxy, automatically following Salesforce cursor pagination.
-// It returns an error if the underlying promise is rejected.
+// It returns a *RestError if the underlying promise is rejected.
 // For composite requests, it returns CompositeErrors if any sub-requests fail.
 func Post(url string, body []byte) ([]byte, error) {
 	dataCh := make(chan []byte)
@@ -104,7 +104,7 @@ func Post(url string, body []byte) ([]byte, error) {
 			return nil
 		})).
 		Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			errCh <- errors.New(args[0].String())
+			errCh <- newRestError("POST", url, 0, args[0].String())
 			return nil
 		}))
 	for {
@@ -127,7 +127,7 @@ func Post(url string, body []byte) ([]byte, error) {
 }
 
 // Patch performs a PATCH via JS proxy, automatically following Salesforce cursor pagination.
-// It returns an error if the underlying promise is rejected.
+// It returns a *RestError if the underlying promise is rejected.
 func Patch(url string, body []byte) ([]byte, error) {
 	dataCh := make(chan []byte)
 	errCh := make(chan error)
@@ -137,7 +137,7 @@ func Patch(url string, body []byte) ([]byte, error) {
 			return nil
 		})).
 		Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			errCh <- errors.New(args[0].String())
+			errCh <- newRestError("PATCH", url, 0, args[0].String())
 			return nil
 		}))
 	for {
@@ -154,7 +154,7 @@ func Patch(url string, body []byte) ([]byte, error) {
 }
 
 // Delete performs a DELETE via JS proxy.
-// It returns an error if the underlying promise is rejected.
+// It returns a *RestError if the underlying promise is rejected.
 func Delete(url string) ([]byte, error) {
 	dataCh := make(chan []byte)
 	errCh := make(chan error)
@@ -164,7 +164,7 @@ func Delete(url string) ([]byte, error) {
 			return nil
 		})).
 		Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			errCh <- errors.New(args[0].String())
+			errCh <- newRestError("DELETE", url, 0, args[0].String())
 			return nil
 		}))
 	for {
@@ -40,6 +40,7 @@ func Get(url string) ([]byte, error) {
 }
 
 // Post performs an HTTP POST against the local dev server and returns the response body.
+// Non-2xx responses are returned as a *RestError.
 // For composite requests, it returns CompositeErrors if any sub-requests fail.
 func Post(url string, body []byte) ([]byte, error) {
 	fmt.Printf("POST %s %s\n", url, string(body))
@@ -53,13 +54,7 @@ func Post(url string, body []byte) ([]byte, error) {
 		return nil, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var sfErrs []struct {
-			Message string `json:"message"`
-		}
-		if err := json.Unmarshal(data, &sfErrs); err == nil && len(sfErrs) > 0 {
-			return nil, fmt.Errorf("POST %s returned status %d: %s", url, resp.StatusCode, sfErrs[0].Message)
-		}
-		return nil, fmt.Errorf("POST %s returned status %d: %s", url, resp.StatusCode, string(data))
+		return nil, newRestError("POST", url, resp.StatusCode, string(data))
 	}
 
 	// Check if this is a composite request response
@@ -73,6 +68,7 @@ func Post(url string, body []byte) ([]byte, error) {
 }
 
 // Patch performs an HTTP PATCH against the local dev server and returns the response body.
+// Non-2xx responses are returned as a *RestError.
 func Patch(url string, body []byte) ([]byte, error) {
 	fmt.Printf("PATCH %s %s\n", url, string(body))
 	req, err := http.NewRequest("PATCH", url, bytes.NewReader(body))
@@ -90,18 +86,13 @@ func Patch(url string, body []byte) ([]byte, error) {
 		return nil, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var sfErrs []struct {
-			Message string `json:"message"`
-		}
-		if err := json.Unmarshal(data, &sfErrs); err == nil && len(sfErrs) > 0 {
-			return nil, fmt.Errorf("PATCH %s returned status %d: %s", url, resp.StatusCode, sfErrs[0].Message)
-		}
-		return nil, fmt.Errorf("PATCH %s returned status %d: %s", url, resp.StatusCode, string(data))
+		return nil, newRestError("PATCH", url, resp.StatusCode, string(data))
 	}
 	return data, nil
 }
 
 // Delete performs an HTTP DELETE against the local dev server and returns the response body.
+// Non-2xx responses are returned as a *RestError.
 func Delete(url string) ([]byte, error) {
 	fmt.Printf("DELETE %s\n", url)
 	req, err := http.NewRequest("DELETE", url, nil)
@@ -118,13 +109,7 @@ func Delete(url string) ([]byte, error) {
 		return nil, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var sfErrs []struct {
-			Message string `json:"message"`
-		}
-		if err := json.Unmarshal(data, &sfErrs); err == nil && len(sfErrs) > 0 {
-			return nil, fmt.Errorf("DELETE %s returned status %d: %s", url, resp.StatusCode, sfErrs[0].Message)
-		}
-		return nil, fmt.Errorf("DELETE %s returned status %d: %s", url, resp.StatusCode, string(data))
+		return nil, newRestError("DELETE", url, resp.StatusCode, string(data))
 	}
 	return data, nil
 }
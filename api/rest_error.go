@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RestError is returned by Post, Patch and Delete when Salesforce rejects a
+// request. Errors holds the REST API's error list, whose Fields name the
+// fields each error applies to, e.g. for REQUIRED_FIELD_MISSING or a
+// validation rule shown on a field.
+type RestError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Errors     []SaveError
+	// Body is the raw response, for errors that are not an error list.
+	Body string
+}
+
+// Error implements the error interface for RestError.
+func (e *RestError) Error() string {
+	msg := e.Body
+	if len(e.Errors) > 0 {
+		msg = e.Errors[0].Message
+	}
+	if e.StatusCode == 0 {
+		return msg
+	}
+	return fmt.Sprintf("%s %s returned status %d: %s", e.Method, e.URL, e.StatusCode, msg)
+}
+
+// newRestError builds a RestError from a failed response body, reading the
+// error list when the body is one.
+func newRestError(method, url string, statusCode int, body string) *RestError {
+	e := &RestError{Method: method, URL: url, StatusCode: statusCode, Body: body}
+	var list []SaveError
+	if json.Unmarshal([]byte(strings.TrimSpace(body)), &list) == nil {
+		for _, se := range list {
+			if se.Message != "" {
+				e.Errors = append(e.Errors, se)
+			}
+		}
+	}
+	return e
+}
+
+// SaveErrors returns the errors behind a failed save: the error list of a
+// RestError, or the errors of each failed sub-request of CompositeErrors.
+// Any other error is returned as a single SaveError naming no fields.
+func SaveErrors(err error) []SaveError {
+	if err == nil {
+		return nil
+	}
+	var restErr *RestError
+	if errors.As(err, &restErr) && len(restErr.Errors) > 0 {
+		return restErr.Errors
+	}
+	var compositeErrs *CompositeErrors
+	if errors.As(err, &compositeErrs) {
+		var list []SaveError
+		for _, sub := range compositeErrs.Errors {
+			list = append(list, subResponseErrors(sub.Body)...)
+		}
+		if len(list) > 0 {
+			return list
+		}
+	}
+	return []SaveError{{Message: err.Error()}}
+}
+
+// subResponseErrors reads the body of a failed composite sub-request, which
+// is an error list (Salesforce) or a single error object (GoBridge).
+func subResponseErrors(body interface{}) []SaveError {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil
+	}
+	var list []SaveError
+	if json.Unmarshal(data, &list) != nil {
+		var one SaveError
+		if json.Unmarshal(data, &one) != nil || one.Message == "" {
+			return nil
+		}
+		list = []SaveError{one}
+	}
+	return list
+}
+
+// FieldErrors splits save errors into a message per field and the messages
+// that name no field. A field with several errors gets them joined by
+// newlines.
+func FieldErrors(errs []SaveError) (fields map[string]string, other []string) {
+	fields = make(map[string]string)
+	for _, se := range errs {
+		if len(se.Fields) == 0 {
+			other = append(other, se.Message)
+			continue
+		}
+		for _, f := range se.Fields {
+			if fields[f] != "" {
+				fields[f] += "\n" + se.Message
+			} else {
+				fields[f] = se.Message
+			}
+		}
+	}
+	return fields, other
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRestErrorReadsErrorList(t *testing.T) {
+	body := `[{"message":"Required fields are missing: [Name]","errorCode":"REQUIRED_FIELD_MISSING","fields":["Name"]}]`
+	err := newRestError("POST", "/services/data/v63.0/sobjects/Account", 400, body)
+	if got, want := err.Error(), "POST /services/data/v63.0/sobjects/Account returned status 400: Required fields are missing: [Name]"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	want := []SaveError{{Message: "Required fields are missing: [Name]", ErrorCode: "REQUIRED_FIELD_MISSING", Fields: []string{"Name"}}}
+	if got := SaveErrors(fmt.Errorf("save failed: %w", err)); !reflect.DeepEqual(got, want) {
+		t.Errorf("SaveErrors() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRestErrorWithoutErrorList(t *testing.T) {
+	err := newRestError("PATCH", "/x", 0, "Insufficient access")
+	if err.Error() != "Insufficient access" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if got := SaveErrors(err); len(got) != 1 || got[0].Message != "Insufficient access" || got[0].Fields != nil {
+		t.Errorf("unexpected errors %+v", got)
+	}
+	if SaveErrors(nil) != nil {
+		t.Error("expected no errors for nil")
+	}
+}
+
+func TestSaveErrorsFromCompositeErrors(t *testing.T) {
+	err := &CompositeErrors{Errors: []CompositeSubResponse{
+		{ReferenceID: "ref1", HTTPStatusCode: 400, Body: []interface{}{
+			map[string]interface{}{"message": "Close Date must be in the future", "errorCode": "FIELD_CUSTOM_VALIDATION_EXCEPTION", "fields": []interface{}{"CloseDate"}},
+		}},
+		{ReferenceID: "ref2", HTTPStatusCode: 400, Body: map[string]interface{}{"message": "Invalid object", "errorCode": "COMPOSITE_SUB_REQUEST_ERROR"}},
+	}}
+	got := SaveErrors(err)
+	want := []SaveError{
+		{Message: "Close Date must be in the future", ErrorCode: "FIELD_CUSTOM_VALIDATION_EXCEPTION", Fields: []string{"CloseDate"}},
+		{Message: "Invalid object", ErrorCode: "COMPOSITE_SUB_REQUEST_ERROR"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SaveErrors() = %+v, want %+v", got, want)
+	}
+	if msg := err.Error(); msg != "composite request failed: ref ref1: Close Date must be in the future; ref ref2: Invalid object" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	fields, other := FieldErrors([]SaveError{
+		{Message: "Required fields are missing: [Name, Phone]", Fields: []string{"Name", "Phone"}},
+		{Message: "Phone must be 10 digits", Fields: []string{"Phone"}},
+		{Message: "Record is locked"},
+	})
+	want := map[string]string{
+		"Name":  "Required fields are missing: [Name, Phone]",
+		"Phone": "Required fields are missing: [Name, Phone]\nPhone must be 10 digits",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+	if !reflect.DeepEqual(other, []string{"Record is locked"}) {
+		t.Errorf("other = %v", other)
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	forcequery "github.com/ForceCLI/force/lib/query"
+)
+
+// Search performs a SOSL search and returns the matching records of every
+// object searched, e.g.
+//
+//	api.Search("FIND {Acme*} IN NAME FIELDS RETURNING Account(Id, Name)")
+func Search(sosl string) ([]Record, error) {
+	return search(Get, sosl)
+}
+
+func search(get forcequery.HttpGetter, sosl string) ([]Record, error) {
+	data, err := get("/services/data/v63.0/search?q=" + url.QueryEscape(sosl))
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	var resp struct {
+		SearchRecords []json.RawMessage `json:"searchRecords"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	// Reshape the results as a query response so records are wrapped the
+	// same way as Query results.
+	page, err := json.Marshal(map[string]interface{}{
+		"totalSize": len(resp.SearchRecords),
+		"done":      true,
+		"records":   resp.SearchRecords,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	raw, err := forcequery.Eager(
+		forcequery.InstanceUrl(""),
+		forcequery.Tail("/search"),
+		forcequery.HttpGet(func(string) ([]byte, error) { return page, nil }),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	records := make([]Record, len(raw))
+	for i, r := range raw {
+		records[i] = Record{r}
+	}
+	return records, nil
+}
+
+// SearchTarget describes an object searched by SearchRecords.
+type SearchTarget struct {
+	ObjectName string
+	// NameField is the field matched against the search text; defaults to
+	// "Name". See ObjectInfo.NameFields.
+	NameField string
+	// Fields are extra fields to return, e.g. for a lookup's secondary text.
+	Fields []string
+	// Limit caps the number of records returned; defaults to 10.
+	Limit int
+}
+
+// SearchRecords finds records of target whose name matches text, as a lookup
+// does. Text of two or more characters uses a SOSL search across name fields;
+// shorter text, which SOSL does not accept, falls back to a SOQL prefix match
+// on NameField.
+func SearchRecords(text string, target SearchTarget) ([]Record, error) {
+	query, isSOSL := searchRecordsQuery(text, target)
+	if isSOSL {
+		return Search(query)
+	}
+	return Query(query)
+}
+
+// searchRecordsQuery builds the SOSL or SOQL statement for SearchRecords.
+func searchRecordsQuery(text string, target SearchTarget) (query string, isSOSL bool) {
+	nameField := target.NameField
+	if nameField == "" {
+		nameField = "Name"
+	}
+	limit := target.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	fields := []string{"Id", nameField}
+	for _, f := range target.Fields {
+		if f != "Id" && f != nameField {
+			fields = append(fields, f)
+		}
+	}
+	text = strings.TrimSpace(text)
+	if len([]rune(text)) >= 2 {
+		return fmt.Sprintf("FIND {%s*} IN NAME FIELDS RETURNING %s(%s ORDER BY %s LIMIT %d)",
+			escapeSOSL(text), target.ObjectName, strings.Join(fields, ", "), nameField, limit), true
+	}
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s LIKE '%s%%' ORDER BY %s LIMIT %d",
+		strings.Join(fields, ", "), target.ObjectName, nameField, escapeSOQLLike(text), nameField, limit), false
+}
+
+// escapeSOSL escapes SOSL reserved characters in search text.
+func escapeSOSL(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`?&|!{}[]()^~*:\"'+-`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeSOQLLike escapes text for use in a SOQL LIKE string literal.
+func escapeSOQLLike(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '\'', '%', '_':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
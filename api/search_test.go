@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestSearchWrapsSearchRecords verifies SOSL results are returned as Records
+func TestSearchWrapsSearchRecords(t *testing.T) {
+	var requested string
+	get := func(u string) ([]byte, error) {
+		requested = u
+		return []byte(`{"searchRecords":[
+			{"attributes":{"type":"Account","url":"/services/data/v63.0/sobjects/Account/001A"},"Id":"001A","Name":"Acme"},
+			{"attributes":{"type":"Contact","url":"/services/data/v63.0/sobjects/Contact/003B"},"Id":"003B","Name":"Ann Acme"}]}`), nil
+	}
+
+	sosl := "FIND {Acme*} IN NAME FIELDS RETURNING Account(Id, Name), Contact(Id, Name)"
+	records, err := search(get, sosl)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if requested != "/services/data/v63.0/search?q="+url.QueryEscape(sosl) {
+		t.Errorf("Unexpected request URL: %q", requested)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[1].Attributes.Type != "Contact" {
+		t.Errorf("Expected Contact, got %q", records[1].Attributes.Type)
+	}
+	if name, err := records[0].StringValue("Name"); err != nil || name != "Acme" {
+		t.Errorf("Expected Acme, got %q (%v)", name, err)
+	}
+}
+
+// TestSearchRecordsQueryUsesSOSL verifies longer search text builds an escaped
+// SOSL search over name fields
+func TestSearchRecordsQueryUsesSOSL(t *testing.T) {
+	query, isSOSL := searchRecordsQuery(" Acme-Co ", SearchTarget{
+		ObjectName: "Account",
+		Fields:     []string{"Id", "BillingCity"},
+		Limit:      5,
+	})
+	if !isSOSL {
+		t.Fatal("Expected a SOSL search")
+	}
+	want := `FIND {Acme\-Co*} IN NAME FIELDS RETURNING Account(Id, Name, BillingCity ORDER BY Name LIMIT 5)`
+	if query != want {
+		t.Errorf("Expected %q, got %q", want, query)
+	}
+}
+
+// TestSearchRecordsQueryFallsBackToSOQL verifies single-character text uses an
+// escaped SOQL prefix match on the name field
+func TestSearchRecordsQueryFallsBackToSOQL(t *testing.T) {
+	query, isSOSL := searchRecordsQuery("_", SearchTarget{ObjectName: "Case", NameField: "CaseNumber"})
+	if isSOSL {
+		t.Fatal("Expected a SOQL query")
+	}
+	want := `SELECT Id, CaseNumber FROM Case WHERE CaseNumber LIKE '\_%' ORDER BY CaseNumber LIMIT 10`
+	if query != want {
+		t.Errorf("Expected %q, got %q", want, query)
+	}
+	if query, _ := searchRecordsQuery("'", SearchTarget{ObjectName: "Account"}); !strings.Contains(query, `LIKE '\'%'`) {
+		t.Errorf("Expected quote to be escaped, got %q", query)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build js && !dev
+// +build js,!dev
+
+package api
+
+import (
+	"syscall/js"
+)
+
+// ShowPlatformToast shows toast using Lightning's ShowToastEvent. It returns
+// false when the app is not running inside the thunder LWC, e.g. in a
+// Visualforce page, so the caller can render the toast itself.
+func ShowPlatformToast(toast PlatformToast) bool {
+	fn := js.Global().Get("thunderShowToast")
+	if fn.Type() != js.TypeFunction {
+		return false
+	}
+	fn.Invoke(map[string]interface{}{
+		"title":     toast.Title,
+		"message":   toast.Message,
+		"variant":   toast.Variant,
+		"mode":      toast.Mode,
+		"linkLabel": toast.LinkLabel,
+		"linkUrl":   toast.LinkURL,
+	})
+	return true
+}
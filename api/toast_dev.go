@@ -0,0 +1,10 @@
+//go:build js && dev
+// +build js,dev
+
+package api
+
+// ShowPlatformToast reports the platform toast unavailable under thunder
+// serve, which has no Lightning container, so apps render their own toasts.
+func ShowPlatformToast(toast PlatformToast) bool {
+	return false
+}
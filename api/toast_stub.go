@@ -0,0 +1,10 @@
+//go:build !js
+// +build !js
+
+package api
+
+// ShowPlatformToast reports the platform toast unavailable outside the
+// browser, so callers render their own toasts.
+func ShowPlatformToast(toast PlatformToast) bool {
+	return false
+}
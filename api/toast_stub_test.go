@@ -0,0 +1,12 @@
+//go:build !js
+// +build !js
+
+package api
+
+import "testing"
+
+func Test_show_platform_toast_reports_unavailable_on_host(t *testing.T) {
+	if ShowPlatformToast(PlatformToast{Title: "Saved", Variant: "success"}) {
+		t.Error("expected ShowPlatformToast to report the platform toast unavailable on the host")
+	}
+}
@@ -0,0 +1,14 @@
+package api
+
+// PlatformToast describes a toast shown by Lightning's ShowToastEvent.
+type PlatformToast struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	// Variant is "success", "error", "warning" or "info".
+	Variant string `json:"variant"`
+	// Mode is "dismissible", "pester" or "sticky".
+	Mode string `json:"mode"`
+	// LinkLabel and LinkURL add a link after the message.
+	LinkLabel string `json:"linkLabel,omitempty"`
+	LinkURL   string `json:"linkUrl,omitempty"`
+}
@@ -0,0 +1,7 @@
+//go:build js
+
+package api
+
+// Browsers give WASM no zoneinfo files, so embed the time zone database for
+// UserInfo.Location.
+import _ "time/tzdata"
@@ -0,0 +1,24 @@
+//go:build js && !dev
+// +build js,!dev
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetUserInfo retrieves the running user's identity and regional settings
+// from Salesforce.
+func GetUserInfo() (*UserInfo, error) {
+	responseData, err := Post("/services/apexrest/GoBridge/getUserInfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user info: %w", err)
+	}
+
+	var info UserInfo
+	if err := json.Unmarshal(responseData, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user info: %w", err)
+	}
+	return &info, nil
+}
@@ -0,0 +1,45 @@
+//go:build dev
+// +build dev
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetUserInfo retrieves the running user's identity and regional settings
+// from the OAuth userinfo endpoint, via the thunder serve proxy. The endpoint
+// does not report a currency, so Currency is empty.
+func GetUserInfo() (*UserInfo, error) {
+	responseData, err := Get("/services/oauth2/userinfo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user info from dev server: %w", err)
+	}
+	return parseOAuthUserInfo(responseData)
+}
+
+// parseOAuthUserInfo converts an OAuth userinfo response to UserInfo.
+func parseOAuthUserInfo(data []byte) (*UserInfo, error) {
+	var resp struct {
+		UserID            string `json:"user_id"`
+		Name              string `json:"name"`
+		PreferredUsername string `json:"preferred_username"`
+		Email             string `json:"email"`
+		ZoneInfo          string `json:"zoneinfo"`
+		Locale            string `json:"locale"`
+		Language          string `json:"language"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user info: %w", err)
+	}
+	return &UserInfo{
+		ID:       resp.UserID,
+		Name:     resp.Name,
+		Username: resp.PreferredUsername,
+		Email:    resp.Email,
+		TimeZone: resp.ZoneInfo,
+		Locale:   resp.Locale,
+		Language: resp.Language,
+	}, nil
+}
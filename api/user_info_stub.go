@@ -0,0 +1,15 @@
+//go:build !js && !dev
+// +build !js,!dev
+
+package api
+
+// GetUserInfo stub implementation for non-WASM, non-dev builds. It returns a
+// user in UTC with the en_US locale.
+func GetUserInfo() (*UserInfo, error) {
+	return &UserInfo{
+		TimeZone: "UTC",
+		Locale:   "en_US",
+		Language: "en_US",
+		Currency: "USD",
+	}, nil
+}
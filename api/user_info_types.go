@@ -0,0 +1,35 @@
+package api
+
+import (
+	"time"
+)
+
+// UserInfo describes the running user's identity and regional settings.
+type UserInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// TimeZone is the user's IANA time zone, e.g. "America/Los_Angeles".
+	TimeZone string `json:"timeZone"`
+	// Locale formats numbers and dates, e.g. "en_US".
+	Locale string `json:"locale"`
+	// Language is the user's language, e.g. "en_US".
+	Language string `json:"language"`
+	// Currency is the user's default ISO currency code, e.g. "USD".
+	Currency string `json:"currency"`
+}
+
+// Location returns the user's time zone, for showing Salesforce's UTC
+// DateTime values in local time. An unknown or empty zone returns UTC and an
+// error.
+func (u UserInfo) Location() (*time.Location, error) {
+	if u.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(u.TimeZone)
+	if err != nil {
+		return time.UTC, err
+	}
+	return loc, nil
+}
@@ -5,7 +5,10 @@ import (
 	"bufio"
 	"bytes"
 	"compress/flate"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net"
@@ -15,6 +18,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -52,11 +56,34 @@ var (
 	deployThunderDev  bool
 	deployVisualforce bool
 	deployName        string
+	deployCheckOnly   bool
+	deployDryRun      bool
+	deployOutput      string
+	deployKeep        int
+	// rollback command flags
+	rollbackTo   string
+	rollbackList bool
 	// build command flags
 	buildDev    bool
 	buildOutput string
+	// wasmCompiler selects the compiler for serve, build, deploy and diff
+	wasmCompiler string
 )
 
+// Supported values of --compiler.
+const (
+	compilerGo     = "go"
+	compilerTinyGo = "tinygo"
+)
+
+// sldsVersion is the Salesforce Lightning Design System release thunder serve
+// proxies icon sprites from, pinned so the sprites don't change under an app.
+const sldsVersion = "2.22.2"
+
+// sldsAssetsURL is where thunder serve loads the Salesforce Lightning Design
+// System assets from in development.
+var sldsAssetsURL = "https://unpkg.com/@salesforce-ux/design-system@" + sldsVersion + "/assets"
+
 // indexHTML is the HTML template served for the Thunder app root.
 const indexHTML = `<!DOCTYPE html>
 <html>
@@ -66,6 +93,7 @@ const indexHTML = `<!DOCTYPE html>
     <link rel="stylesheet" href="https://unpkg.com/@salesforce-ux/design-system@latest/assets/styles/salesforce-lightning-design-system.min.css">
     <script src="wasm_exec.js"></script>
     <script>
+        globalThis.thunderIconSpritePath = "/_slds/icons";
         const go = new Go();
         WebAssembly.instantiateStreaming(fetch("bundle.wasm"), go.importObject).then((result) => {
             go.run(result.instance);
@@ -96,6 +124,22 @@ var deployCmd = &cobra.Command{
 	RunE:  runDeploy,
 }
 
+// diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [dir]",
+	Short: "Show how a deploy would change the app's metadata in the org",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runDiff,
+}
+
+// rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback [dir]",
+	Short: "Re-point the app at a bundle version retained by deploy --keep",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runRollback,
+}
+
 // build command
 var buildCmd = &cobra.Command{
 	Use:   "build [dir]",
@@ -107,6 +151,10 @@ var buildCmd = &cobra.Command{
 func init() {
 	// serve flags (port only; app dir is optional positional arg)
 	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8000, "Port to serve on")
+	// every command that compiles the app accepts --compiler
+	for _, c := range []*cobra.Command{serveCmd, deployCmd, diffCmd, buildCmd} {
+		c.Flags().StringVar(&wasmCompiler, "compiler", compilerGo, "Compiler for the WASM bundle: go or tinygo")
+	}
 	// deploy flags (app dir is optional positional arg)
 	deployCmd.Flags().BoolVarP(&deployTab, "tab", "t", false, "Deploy and open a CustomTab for the app")
 	deployCmd.Flags().BoolVarP(&deployWatch, "watch", "w", false, "Watch for changes and automatically redeploy WASM bundle")
@@ -115,12 +163,28 @@ func init() {
 	deployCmd.Flags().BoolVar(&deployThunderDev, "thunder-dev", false, "Deploy unpackaged thunder dependencies instead of using the osgo package")
 	deployCmd.Flags().BoolVar(&deployVisualforce, "visualforce", false, "Deploy the app as a Visualforce page (runs outside Lightning Web Security; needed for apps that use Web Workers)")
 	deployCmd.Flags().StringVar(&deployName, "name", "", "Name for the app and tab (defaults to directory name)")
+	deployCmd.Flags().BoolVar(&deployCheckOnly, "check-only", false, "Validate the deployment and run tests without saving changes to the org")
+	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Write the generated metadata to disk instead of deploying it")
+	deployCmd.Flags().IntVar(&deployKeep, "keep", 0, "Retain the newest N bundle versions in the org for thunder rollback (0 disables retention)")
+	deployCmd.Flags().StringVarP(&deployOutput, "output", "o", "", "Output directory for --dry-run metadata, cleared before writing (implies --dry-run; default a new temporary directory)")
+	// rollback flags
+	rollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "Version to roll back to (defaults to the one before the live version)")
+	rollbackCmd.Flags().BoolVar(&rollbackList, "list", false, "List the retained versions instead of rolling back")
+	// diff flags select the same metadata as the matching deploy flags
+	diffCmd.Flags().BoolVarP(&deployTab, "tab", "t", false, "Include the app's CustomTab")
+	diffCmd.Flags().BoolVar(&deployAppOnly, "app-only", false, "Compare only the static resource (WASM bundle)")
+	diffCmd.Flags().BoolVar(&deployThunderDev, "thunder-dev", false, "Include the unpackaged thunder dependencies")
+	diffCmd.Flags().BoolVar(&deployVisualforce, "visualforce", false, "Compare the Visualforce page deployment")
+	diffCmd.Flags().StringVar(&deployName, "name", "", "Name for the app and tab (defaults to directory name)")
+	diffCmd.Flags().BoolVar(&deployDebug, "debug", false, "Enable debug output")
 	// build flags
 	buildCmd.Flags().BoolVarP(&buildDev, "dev", "d", false, "Build with development tags")
 	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", "./build", "Output directory for build artifacts")
 	// add subcommands
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(deployCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(rollbackCmd)
 	rootCmd.AddCommand(buildCmd)
 }
 
@@ -139,7 +203,8 @@ func buildWASM(appDir string) (string, error) {
 	}
 	// build WASM binary
 	outWasm := filepath.Join(buildDir, "bundle.wasm")
-	cmd := exec.Command("go", "build", "-o", outWasm, "-tags", "dev")
+	args := wasmBuildCommand(wasmCompiler, true, outWasm, bundleManifest{})
+	cmd := exec.Command(args[0], args[1:]...)
 
 	// Set up environment with smart GOWORK handling
 	env := append(os.Environ(), "GOOS=js", "GOARCH=wasm")
@@ -159,8 +224,11 @@ func buildWASM(appDir string) (string, error) {
 	if err := cmd.Run(); err != nil {
 		return "", err
 	}
-	// copy wasm_exec.js from Go SDK
-	wasmExecSrc := filepath.Join(runtime.GOROOT(), "lib", "wasm", "wasm_exec.js")
+	// copy wasm_exec.js from the SDK that built the bundle
+	wasmExecSrc, err := wasmExecPath(wasmCompiler)
+	if err != nil {
+		return "", err
+	}
 	wasmExecDst := filepath.Join(buildDir, "wasm_exec.js")
 	if err := copyFile(wasmExecSrc, wasmExecDst); err != nil {
 		return "", err
@@ -552,6 +620,30 @@ func wasmExecHandler(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, filepath.Join(dirPath, "wasm_exec.js"))
 }
 
+// sldsIconsHandler serves the SLDS icon sprites at /_slds/icons/, which the
+// served page sets as the app's icon sprite path. Browsers only resolve SVG
+// <use> references to same-origin sprites, so they are proxied from
+// sldsAssetsURL rather than linked like the stylesheet.
+func sldsIconsHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/_slds/icons/")
+	if name == "" || strings.Contains(name, "..") {
+		http.NotFound(w, r)
+		return
+	}
+	resp, err := http.Get(sldsAssetsURL + "/icons/" + name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		w.Header().Set("Content-Type", ct)
+	}
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
 // indexHandler serves the indexHTML template directly.
 func indexHandler(w http.ResponseWriter, r *http.Request) {
 	// Only serve index for root path and paths that don't match other handlers
@@ -602,6 +694,9 @@ func runServe(cmd *cobra.Command, args []string) error {
 	if err != nil || !info.IsDir() {
 		return fmt.Errorf("Invalid app directory: %s", serveDir)
 	}
+	if err := checkCompiler(wasmCompiler); err != nil {
+		return err
+	}
 
 	// Set up environment for package validation
 	env := os.Environ()
@@ -654,6 +749,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 	http.HandleFunc("/api/settings", settingsHandler)
 	http.HandleFunc("/bundle.wasm", wasmHandler)
 	http.HandleFunc("/wasm_exec.js", wasmExecHandler)
+	http.HandleFunc("/_slds/icons/", sldsIconsHandler)
 	http.HandleFunc("/", indexHandler)
 
 	// Start the server in a goroutine so we can open browser after it starts
@@ -754,6 +850,9 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	if err != nil || !info.IsDir() {
 		return fmt.Errorf("Invalid app directory: %s", buildDir)
 	}
+	if err := checkCompiler(wasmCompiler); err != nil {
+		return err
+	}
 
 	// Build the WASM
 	fmt.Printf("Building Thunder app from %s...\n", buildDir)
@@ -761,7 +860,7 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	if buildDev {
 		tempBuildDir, err = buildWASM(buildDir)
 	} else {
-		tempBuildDir, err = buildProdWASM(buildDir)
+		tempBuildDir, err = buildProdWASM(buildDir, newBuildStamp(buildDir))
 	}
 	if err != nil {
 		return fmt.Errorf("Error building WASM: %w", err)
@@ -784,9 +883,14 @@ func runBuild(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Failed to copy WASM bundle: %w", err)
 	}
 
-	// Copy wasm_exec.js for dev builds
-	if buildDev {
-		wasmExecSrc := filepath.Join(tempBuildDir, "wasm_exec.js")
+	// Copy wasm_exec.js for dev builds, and for TinyGo builds, whose bundles
+	// can't run on the standard Go runtime the thunder LWC otherwise provides
+	withRuntime := buildDev || wasmCompiler == compilerTinyGo
+	if withRuntime {
+		wasmExecSrc, err := wasmExecPath(wasmCompiler)
+		if err != nil {
+			return err
+		}
 		wasmExecDst := filepath.Join(outputDir, "wasm_exec.js")
 		if err := copyFile(wasmExecSrc, wasmExecDst); err != nil {
 			return fmt.Errorf("Failed to copy wasm_exec.js: %w", err)
@@ -802,7 +906,7 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Output directory: %s\n", outputDir)
 	fmt.Printf("Files generated:\n")
 	fmt.Printf("  - bundle.wasm\n")
-	if buildDev {
+	if withRuntime {
 		fmt.Printf("  - wasm_exec.js\n")
 	}
 
@@ -933,102 +1037,223 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	} else {
 		deployDir = "."
 	}
+	if deployOutput != "" {
+		deployDryRun = true
+	}
+	if deployCheckOnly && deployDryRun {
+		return fmt.Errorf("--check-only cannot be combined with --dry-run or --output")
+	}
+	if deployWatch && (deployCheckOnly || deployDryRun) {
+		return fmt.Errorf("--watch cannot be combined with --check-only, --dry-run or --output")
+	}
+	if err := checkCompiler(wasmCompiler); err != nil {
+		return err
+	}
+	if err := checkMainPackage(deployDir); err != nil {
+		return err
+	}
+
+	// Check for osgo package installation and install if needed (unless using
+	// --thunder-dev). A dry run never touches the org, so skip the check.
+	if !deployThunderDev && !deployDryRun {
+		if err := ensureOsgoPackageInstalled(); err != nil {
+			return fmt.Errorf("failed to ensure osgo package is installed: %w", err)
+		}
+	}
+	d, err := generateDeployment(deployDir, newBuildStamp(deployDir))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Cleanup temporary build directory
+		if rmErr := os.RemoveAll(d.buildDir); rmErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove temp dir %s: %v\n", d.buildDir, rmErr)
+		}
+	}()
+
+	// With --dry-run (or --output), write the generated metadata to disk instead
+	// of deploying it, e.g. to inspect it or hand it to another release pipeline.
+	if deployDryRun {
+		outputDir := deployOutput
+		if outputDir == "" {
+			outputDir, err = os.MkdirTemp("", "thunder-metadata-*")
+			if err != nil {
+				return err
+			}
+		} else {
+			if !filepath.IsAbs(outputDir) {
+				outputDir = filepath.Join(deployDir, outputDir)
+			}
+			if err := clearMetadataOutput(outputDir); err != nil {
+				return err
+			}
+		}
+		if err := writeMetadataFiles(outputDir, d.files); err != nil {
+			return fmt.Errorf("failed to write metadata: %w", err)
+		}
+		fmt.Printf("Wrote deployment metadata to %s:\n", outputDir)
+		for _, name := range sortedFileNames(d.files) {
+			fmt.Printf("  - %s\n", name)
+		}
+		return nil
+	}
+
+	if deployKeep > 0 {
+		if err := addVersionPruning(d.files, d.staticResourceName, d.version, deployKeep); err != nil {
+			return err
+		}
+	}
+
+	// Only the LWC deployment opens its tab from performDeployment; Visualforce
+	// apps are opened below. A validation deploy changes nothing, so there is
+	// nothing to open.
+	openTab := deployTab && d.appComp != "" && !deployCheckOnly
+	if err := performDeployment(d.files, d.staticResourceName, d.appComp, openTab, d.runTests); err != nil {
+		return err
+	}
+	if deployCheckOnly {
+		return nil
+	}
+	if deployVisualforce && !deployAppOnly {
+		openVisualforceApp(d.appClass, d.tabName, deployTab)
+	}
+
+	// If watch flag is set, start watching for changes
+	if deployWatch {
+		fmt.Printf("Watching for changes in %s (WASM-only redeploys)...\n", deployDir)
+		return watchAndRedeploy(deployDir, d.staticResourceName)
+	}
+
+	return nil
+}
+
+// checkMainPackage verifies that dir is a directory holding a loadable Go main
+// package.
+func checkMainPackage(dir string) error {
 	// Validate app directory
-	info, err := os.Stat(deployDir)
+	info, err := os.Stat(dir)
 	if err != nil || !info.IsDir() {
-		return fmt.Errorf("Invalid app directory: %s", deployDir)
+		return fmt.Errorf("Invalid app directory: %s", dir)
 	}
 
 	// Set up environment for package validation
 	env := os.Environ()
-	if shouldDisableWorkspace(deployDir) {
+	if shouldDisableWorkspace(dir) {
 		env = append(env, "GOWORK=off")
 	}
 
 	cfg := &packages.Config{
 		Mode: packages.NeedName,
-		Dir:  deployDir,
+		Dir:  dir,
 		Env:  env,
 	}
 	pkgs, err := packages.Load(cfg, ".")
 	if err != nil {
-		return fmt.Errorf("failed to load Go package in %s: %w", deployDir, err)
+		return fmt.Errorf("failed to load Go package in %s: %w", dir, err)
 	}
 	if loadErr := packageLoadError(pkgs); loadErr != nil {
-		return fmt.Errorf("failed to load Go package in %s: %w", deployDir, loadErr)
+		return fmt.Errorf("failed to load Go package in %s: %w", dir, loadErr)
 	}
 	if pkgs[0].Name != "main" {
-		return fmt.Errorf("deploy directory %s is not package main", deployDir)
+		return fmt.Errorf("deploy directory %s is not package main", dir)
 	}
+	return nil
+}
 
-	// Check for osgo package installation and install if needed (unless using --thunder-dev)
-	if !deployThunderDev {
-		if err := ensureOsgoPackageInstalled(); err != nil {
-			return fmt.Errorf("failed to ensure osgo package is installed: %w", err)
-		}
-	}
+// deployment is the in-memory metadata generated for an app, along with the
+// names needed to deploy, open and redeploy it.
+type deployment struct {
+	files              forcecli.ForceMetadataFiles
+	buildDir           string
+	staticResourceName string
+	resourceNames      []string
+	appClass           string
+	// appComp is the app's LWC; empty for --app-only and --visualforce.
+	appComp  string
+	tabName  string
+	runTests []string
+	// version is the build version stamped into the bundle's parts.json.
+	version string
+}
+
+// generateDeployment builds the production bundle for the app in appDir,
+// stamped with stamp, and generates the metadata selected by the deploy flags
+// (--app-only, --visualforce, --thunder-dev, --tab, --name). The caller
+// removes d.buildDir when done.
+func generateDeployment(appDir string, stamp bundleManifest) (*deployment, error) {
 	// Build production WASM bundle
-	fmt.Printf("Building production WASM bundle in %s...\n", deployDir)
-	absDir, _ := filepath.Abs(deployDir)
+	fmt.Printf("Building production WASM bundle in %s...\n", appDir)
+	absDir, _ := filepath.Abs(appDir)
 
 	// Always use directory name for file names to avoid conflicts
 	baseName := filepath.Base(absDir)
 	staticResourceName := sanitizeStaticResourceName(baseName)
 	lwcName := sanitizeComponentName(baseName)
 	appClass := toPascalCase(lwcName)
-	buildDir, err := buildProdWASM(deployDir)
+	buildDir, err := buildProdWASM(appDir, stamp)
 	if err != nil {
-		return fmt.Errorf("Error building production WASM: %w", err)
+		return nil, fmt.Errorf("Error building production WASM: %w", err)
 	}
 	fmt.Printf("Built production bundle at %s\n", buildDir)
-	// Prepare metadata files in memory
-	files := make(forcecli.ForceMetadataFiles)
+	d := &deployment{
+		files:              make(forcecli.ForceMetadataFiles),
+		buildDir:           buildDir,
+		staticResourceName: staticResourceName,
+		appClass:           appClass,
+		tabName:            toUpperSnakeCase(lwcName),
+		version:            stamp.Version,
+	}
+	files := d.files
 	// Compress WASM bundle into one or more zip static resources, splitting it
 	// into multiple pieces when it would exceed Salesforce's per-resource limit.
 	wasmData, err := os.ReadFile(filepath.Join(buildDir, "bundle.wasm"))
 	if err != nil {
-		return err
+		return d, err
 	}
-	firstChunkExtras, err := runtimeExtras()
+	firstChunkExtras, err := runtimeExtras(wasmCompiler)
 	if err != nil {
-		return err
+		return d, err
 	}
-	zipChunks, err := splitAndZip(wasmData, firstChunkExtras...)
+	zipChunks, err := splitAndZip(wasmData, stamp, firstChunkExtras...)
 	if err != nil {
-		return err
+		return d, err
 	}
 	resourceNames := staticResourceNames(staticResourceName, len(zipChunks))
+	d.resourceNames = resourceNames
 	if len(zipChunks) > 1 {
 		fmt.Printf("WASM bundle exceeds the static resource limit; splitting into %d resources\n", len(zipChunks))
 	}
 	for i, chunk := range zipChunks {
 		addStaticResource(files, resourceNames[i], chunk)
 	}
+	// With --keep, also deploy a copy of the bundle under versioned names so
+	// `thunder rollback` can restore it later. The copies only need listing in
+	// package.xml; the app itself always loads the base names.
+	packageResources := resourceNames
+	if deployKeep > 0 {
+		versioned := staticResourceNames(versionedResourceName(staticResourceName, stamp.Version), len(zipChunks))
+		for i, chunk := range zipChunks {
+			addStaticResource(files, versioned[i], chunk)
+		}
+		packageResources = append(append([]string{}, resourceNames...), versioned...)
+	}
 
 	// If --app-only flag is set, only deploy the static resource(s)
 	if deployAppOnly {
-		// Generate minimal package.xml for just the static resource(s)
-		pkg := `<?xml version="1.0" encoding="UTF-8"?>
-<Package xmlns="http://soap.sforce.com/2006/04/metadata">
-  <types>
-` + staticResourceMembersXML(resourceNames) + `    <name>StaticResource</name>
-  </types>
-  <version>58.0</version>
-</Package>`
-		files["package.xml"] = []byte(pkg)
-
-		// Perform deployment
-		err = performDeployment(files, staticResourceName, "", false, nil)
-		if err != nil {
-			return err
-		}
+		files["package.xml"] = []byte(staticResourcePackageXML(packageResources))
+		return d, nil
+	}
 
-		// Cleanup temporary build directory
-		if rmErr := os.RemoveAll(buildDir); rmErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to remove temp dir %s: %v\n", buildDir, rmErr)
-		}
+	// Use deployName for appName if provided, otherwise use appClass
+	appName := deployName
+	if appName == "" {
+		appName = appClass
+	}
 
-		return nil
+	// Ship the app's default message bundle as Custom Labels
+	labels, err := readAppLabels(appDir)
+	if err != nil {
+		return d, err
 	}
 
 	// If --visualforce is set, deploy the app as a Visualforce page instead of an
@@ -1037,11 +1262,6 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	// with "Unsupported MIME type") run there. The REST proxy reaches the same
 	// GoBridge logic through JavaScript Remoting rather than the @AuraEnabled path.
 	if deployVisualforce {
-		appName := deployName
-		if appName == "" {
-			appName = appClass
-		}
-		tabName := toUpperSnakeCase(lwcName)
 		// Like the LWC deployment, the page reaches GoBridge from the osgo
 		// managed package unless --thunder-dev deploys it unmanaged; the
 		// controller and RemoteAction references are namespaced accordingly. The
@@ -1051,30 +1271,17 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		if deployThunderDev {
 			controllerClass = "GoBridge"
 		}
-		if err := addVisualforceMetadata(files, resourceNames, appClass, appName, tabName, controllerClass, deployThunderDev); err != nil {
-			return err
+		if err := addVisualforceMetadata(files, resourceNames, appClass, appName, d.tabName, controllerClass, deployThunderDev); err != nil {
+			return d, err
 		}
-		files["package.xml"] = []byte(buildVisualforcePackageXML(resourceNames, appClass, tabName, deployTab, deployThunderDev))
+		files["package.xml"] = []byte(buildVisualforcePackageXML(packageResources, appClass, d.tabName, deployTab, deployThunderDev))
+		addCustomLabels(files, labels)
 
 		// Only the unmanaged GoBridge deployment includes GoBridgeTest to run.
-		var runTests []string
 		if deployThunderDev {
-			runTests = []string{"GoBridgeTest"}
-		}
-		if err := performDeployment(files, staticResourceName, "", false, runTests); err != nil {
-			return err
-		}
-		openVisualforceApp(appClass, tabName, deployTab)
-
-		if rmErr := os.RemoveAll(buildDir); rmErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to remove temp dir %s: %v\n", buildDir, rmErr)
+			d.runTests = []string{"GoBridgeTest"}
 		}
-
-		if deployWatch {
-			fmt.Printf("Watching for changes in %s (WASM-only redeploys)...\n", deployDir)
-			return watchAndRedeploy(deployDir, staticResourceName)
-		}
-		return nil
+		return d, nil
 	}
 
 	// Deploy thunder dependencies if using --thunder-dev flag
@@ -1115,26 +1322,19 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	// Generate LWC for the deployed app
 	appComp := lwcName
+	d.appComp = appComp
 	// JS wrapper for the app, importing the static resource
 	// Import thunder from the appropriate namespace
 	thunderImport := "osgo/thunder"
 	if deployThunderDev {
 		thunderImport = "c/thunder"
 	}
-	// Use deployName for appName if provided, otherwise use appClass
-	appName := deployName
-	if appName == "" {
-		appName = appClass
-	}
 
 	js := generateAppJS(thunderImport, appClass, appName, staticResourceName)
 	files[fmt.Sprintf("lwc/%s/%s.js", appComp, appComp)] = []byte(js)
 	// JS meta
 	files[fmt.Sprintf("lwc/%s/%s.js-meta.xml", appComp, appComp)] = []byte(lwcMetaXML(appClass))
 
-	// Calculate tab name for both tab generation and package.xml
-	tabName := toUpperSnakeCase(lwcName)
-
 	// If requested, generate a CustomTab for the deployed app
 	if deployTab {
 		tabXml := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
@@ -1143,55 +1343,286 @@ func runDeploy(cmd *cobra.Command, args []string) error {
     <lwcComponent>%s</lwcComponent>
     <motif>Custom75: Default</motif>
 </CustomTab>`, appName, appComp)
-		files[fmt.Sprintf("tabs/%s.tab-meta.xml", tabName)] = []byte(tabXml)
+		files[fmt.Sprintf("tabs/%s.tab-meta.xml", d.tabName)] = []byte(tabXml)
 	}
 	// Generate package.xml for the deployment
-	files["package.xml"] = []byte(buildPackageXML(resourceNames, appComp, tabName, deployThunderDev, deployTab))
-	// Perform initial deployment. When deploying the unpackaged thunder
-	// dependencies (which include GoBridgeTest), run only that test.
-	var runTests []string
+	files["package.xml"] = []byte(buildPackageXML(packageResources, appComp, d.tabName, deployThunderDev, deployTab))
+	addCustomLabels(files, labels)
+	// When deploying the unpackaged thunder dependencies (which include
+	// GoBridgeTest), run only that test.
 	if deployThunderDev {
-		runTests = []string{"GoBridgeTest"}
+		d.runTests = []string{"GoBridgeTest"}
 	}
-	err = performDeployment(files, staticResourceName, appComp, deployTab, runTests)
-	if err != nil {
+	return d, nil
+}
+
+// runDiff retrieves the app's currently deployed metadata and reports how the
+// metadata `thunder deploy` would generate differs from it, so changes made
+// directly in the org are not clobbered unnoticed.
+func runDiff(cmd *cobra.Command, args []string) error {
+	deployDir = "."
+	if len(args) > 0 {
+		deployDir = args[0]
+	}
+	if err := checkCompiler(wasmCompiler); err != nil {
 		return err
 	}
+	if err := checkMainPackage(deployDir); err != nil {
+		return err
+	}
+	creds, err := forcecli.ActiveCredentials(false)
+	if err != nil {
+		return fmt.Errorf("failed to load Salesforce credentials: %w", err)
+	}
+	fm := forcecli.NewForce(&creds)
 
-	// Cleanup temporary build directory
-	if rmErr := os.RemoveAll(buildDir); rmErr != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to remove temp dir %s: %v\n", buildDir, rmErr)
+	// Build with the deployed bundle's stamp so that unchanged source
+	// reproduces the deployed bundle instead of differing by build time.
+	stamp := newBuildStamp(deployDir)
+	absDir, _ := filepath.Abs(deployDir)
+	base := sanitizeStaticResourceName(filepath.Base(absDir))
+	deployed, err := retrieveStaticResources(fm, []string{base})
+	if err != nil {
+		return err
+	}
+	if data, ok := deployed[base]; ok {
+		if manifest, err := readBundleManifest(data); err == nil {
+			stamp = withDeployedStamp(stamp, manifest)
+		}
+	}
+	d, err := generateDeployment(deployDir, stamp)
+	if err != nil {
+		return err
 	}
+	defer os.RemoveAll(d.buildDir)
 
-	// If watch flag is set, start watching for changes
-	if deployWatch {
-		fmt.Printf("Watching for changes in %s (WASM-only redeploys)...\n", deployDir)
-		return watchAndRedeploy(deployDir, staticResourceName)
+	fmt.Printf("Retrieving deployed metadata from %s...\n", creds.InstanceUrl)
+	remote, problems, err := fm.Metadata.RetrieveByPackageXmlContents(d.files["package.xml"])
+	if err != nil {
+		return fmt.Errorf("retrieve failed: %w", err)
+	}
+	// Components that have never been deployed are reported as problems by
+	// the retrieve; they show up as additions below.
+	if deployDebug {
+		for _, p := range problems {
+			fmt.Printf("Debug: %s\n", p)
+		}
 	}
 
+	changes := diffMetadata(d.files, remote)
+	if len(changes) == 0 {
+		fmt.Println("No differences; the org matches the local build.")
+		return nil
+	}
+	for _, c := range changes {
+		fmt.Print(c.String())
+	}
 	return nil
 }
 
-// performDeployment deploys the given metadata files to Salesforce
+// metadataChange describes how one metadata file differs between the local
+// build and the org.
+type metadataChange struct {
+	name   string
+	kind   byte // '+' new, '-' only in the org, '~' modified
+	detail []string
+}
+
+func (c metadataChange) String() string {
+	var b strings.Builder
+	switch c.kind {
+	case '+':
+		fmt.Fprintf(&b, "+ %s (not in org)\n", c.name)
+	case '-':
+		fmt.Fprintf(&b, "- %s (only in org; deploy will not remove it)\n", c.name)
+	default:
+		fmt.Fprintf(&b, "~ %s\n", c.name)
+	}
+	for _, line := range c.detail {
+		fmt.Fprintf(&b, "    %s\n", line)
+	}
+	return b.String()
+}
+
+// diffMetadata compares locally generated metadata with files retrieved from
+// the org and returns the differences ordered by file name. package.xml is
+// ignored since it is not itself deployed.
+func diffMetadata(local, remote forcecli.ForceMetadataFiles) []metadataChange {
+	localFiles := normalizeMetadataNames(local)
+	remoteFiles := normalizeMetadataNames(remote)
+	delete(localFiles, "package.xml")
+	delete(remoteFiles, "package.xml")
+
+	var changes []metadataChange
+	for _, name := range sortedFileNames(localFiles) {
+		l := localFiles[name]
+		r, ok := remoteFiles[name]
+		switch {
+		case !ok:
+			changes = append(changes, metadataChange{name: name, kind: '+'})
+		case strings.HasSuffix(name, ".resource"):
+			if detail := diffStaticResource(l, r); len(detail) > 0 {
+				changes = append(changes, metadataChange{name: name, kind: '~', detail: detail})
+			}
+		case !bytes.Equal(normalizeText(l), normalizeText(r)):
+			changes = append(changes, metadataChange{name: name, kind: '~', detail: diffLines(string(r), string(l))})
+		}
+	}
+	for _, name := range sortedFileNames(remoteFiles) {
+		if _, ok := localFiles[name]; !ok {
+			changes = append(changes, metadataChange{name: name, kind: '-'})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].name < changes[j].name })
+	return changes
+}
+
+// normalizeMetadataNames maps deploy-time file names onto the names the
+// Metadata API uses on retrieve. Tabs are deployed as tabs/<Name>.tab-meta.xml
+// but retrieved as tabs/<Name>.tab.
+func normalizeMetadataNames(files forcecli.ForceMetadataFiles) forcecli.ForceMetadataFiles {
+	out := make(forcecli.ForceMetadataFiles, len(files))
+	for name, data := range files {
+		if strings.HasPrefix(name, "tabs/") {
+			name = strings.TrimSuffix(name, "-meta.xml")
+		}
+		out[name] = data
+	}
+	return out
+}
+
+// normalizeText ignores line-ending and trailing whitespace differences
+// introduced when the org round-trips a file.
+func normalizeText(data []byte) []byte {
+	s := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return []byte(strings.TrimSpace(s))
+}
+
+// diffStaticResource compares two zip static resources by size, hash and the
+// contents of each archived file. It returns nil when the archives hold the
+// same files.
+func diffStaticResource(local, remote []byte) []string {
+	localEntries, lerr := zipEntryHashes(local)
+	remoteEntries, rerr := zipEntryHashes(remote)
+	if lerr != nil || rerr != nil {
+		if bytes.Equal(local, remote) {
+			return nil
+		}
+		return []string{resourceSummary("org", remote), resourceSummary("local", local)}
+	}
+	var entries []string
+	for name, h := range localEntries {
+		if remoteEntries[name] != h {
+			entries = append(entries, name)
+		}
+	}
+	for name := range remoteEntries {
+		if _, ok := localEntries[name]; !ok {
+			entries = append(entries, name)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	sort.Strings(entries)
+	detail := []string{resourceSummary("org", remote), resourceSummary("local", local)}
+	for _, name := range entries {
+		_, inLocal := localEntries[name]
+		_, inRemote := remoteEntries[name]
+		switch {
+		case !inRemote:
+			detail = append(detail, name+": added")
+		case !inLocal:
+			detail = append(detail, name+": removed")
+		default:
+			detail = append(detail, name+": changed")
+		}
+	}
+	return detail
+}
+
+// resourceSummary formats a static resource's size and short sha256.
+func resourceSummary(label string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%-5s %d bytes, sha256 %s", label+":", len(data), hex.EncodeToString(sum[:])[:12])
+}
+
+// zipEntryHashes returns the sha256 of every file in a zip archive, keyed by
+// file name.
+func zipEntryHashes(data []byte) (map[string]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		hashes[f.Name] = hex.EncodeToString(h.Sum(nil))
+	}
+	return hashes, nil
+}
+
+// diffLines returns a line diff turning from into to, with removed lines
+// prefixed "- " and added lines "+ ". Unchanged lines are omitted.
+func diffLines(from, to string) []string {
+	a := strings.Split(string(normalizeText([]byte(from))), "\n")
+	b := strings.Split(string(normalizeText([]byte(to))), "\n")
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var out []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	return out
+}
+
+// performDeployment deploys the given metadata files to Salesforce. With
+// --check-only it runs a validation deploy instead, which compiles the
+// metadata and runs tests without committing anything to the org.
 func performDeployment(files forcecli.ForceMetadataFiles, staticResourceName, appComp string, openTab bool, runTests []string) error {
 	creds, err := forcecli.ActiveCredentials(false)
 	if err != nil {
 		return fmt.Errorf("failed to load Salesforce credentials: %w", err)
 	}
 	fm := forcecli.NewForce(&creds)
-	opts := forcecli.ForceDeployOptions{
-		SinglePackage:   true,
-		RollbackOnError: true,
-	}
-	// Run only the named Apex tests when any are deploying (e.g. GoBridgeTest),
-	// rather than the org's entire test suite. With no tests, skip execution.
-	if len(runTests) > 0 {
-		opts.TestLevel = "RunSpecifiedTests"
-		opts.RunTests = runTests
+	opts := deployOptions(runTests, deployCheckOnly)
+	if deployCheckOnly {
+		fmt.Printf("Validating metadata against %s (check only, test level %s)...\n", creds.InstanceUrl, opts.TestLevel)
 	} else {
-		opts.RunTests = []string{}
+		fmt.Printf("Deploying metadata to %s...\n", creds.InstanceUrl)
 	}
-	fmt.Printf("Deploying metadata to %s...\n", creds.InstanceUrl)
 	result, err := fm.Metadata.Deploy(files, opts)
 	if err != nil {
 		return fmt.Errorf("deployment failed: %w", err)
@@ -1204,8 +1635,15 @@ func performDeployment(files forcecli.ForceMetadataFiles, staticResourceName, ap
 		for _, tf := range result.Details.RunTestResult.TestFailures {
 			fmt.Fprintf(os.Stderr, "- Test %s.%s: %s\n", tf.Name, tf.MethodName, tf.Message)
 		}
+		if deployCheckOnly {
+			return fmt.Errorf("metadata validation completed with errors")
+		}
 		return fmt.Errorf("metadata deployment completed with errors")
 	}
+	if deployCheckOnly {
+		fmt.Printf("Validation succeeded (deploy id %s, %d tests run); no changes were made to the org\n", result.Id, result.NumberTestsCompleted)
+		return nil
+	}
 	fmt.Printf("Deployment complete: %+v\n", result)
 
 	// Open new tab in Salesforce if requested
@@ -1221,6 +1659,71 @@ func performDeployment(files forcecli.ForceMetadataFiles, staticResourceName, ap
 	return nil
 }
 
+// deployOptions returns the Metadata API deploy options. Regular deploys run
+// only the named Apex tests (e.g. GoBridgeTest) rather than the org's entire
+// test suite, and skip execution when there are none. A check-only validation
+// always runs tests: the named ones when given, otherwise the org's local tests.
+func deployOptions(runTests []string, checkOnly bool) forcecli.ForceDeployOptions {
+	opts := forcecli.ForceDeployOptions{
+		SinglePackage:   true,
+		RollbackOnError: true,
+		CheckOnly:       checkOnly,
+	}
+	switch {
+	case len(runTests) > 0:
+		opts.TestLevel = "RunSpecifiedTests"
+		opts.RunTests = runTests
+	case checkOnly:
+		opts.TestLevel = "RunLocalTests"
+	default:
+		opts.RunTests = []string{}
+	}
+	return opts
+}
+
+// clearMetadataOutput removes the metadata an earlier dry run wrote to dir, so
+// files no longer generated do not linger. A directory that is not empty and
+// holds no package.xml is left alone and reported as an error rather than
+// risking the removal of unrelated files.
+func clearMetadataOutput(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) || (err == nil && len(entries) == 0) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "package.xml")); err != nil {
+		return fmt.Errorf("output directory %s is not empty and holds no earlier metadata output", dir)
+	}
+	return os.RemoveAll(dir)
+}
+
+// writeMetadataFiles writes the metadata files under dir, preserving their
+// relative paths, so the result can be deployed by other tools.
+func writeMetadataFiles(dir string, files forcecli.ForceMetadataFiles) error {
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedFileNames returns the metadata file names in lexical order.
+func sortedFileNames(files forcecli.ForceMetadataFiles) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // watchAndRedeploy watches for Go source file changes and redeploys only the WASM bundle
 func watchAndRedeploy(appDir, staticResourceName string) error {
 	return watchFiles(appDir, func() error {
@@ -1240,7 +1743,8 @@ func watchAndRedeploy(appDir, staticResourceName string) error {
 // chunk count changes between builds.
 func redeployWASM(appDir, staticResourceName string) error {
 	// Build production WASM bundle
-	buildDir, err := buildProdWASM(appDir)
+	stamp := newBuildStamp(appDir)
+	buildDir, err := buildProdWASM(appDir, stamp)
 	if err != nil {
 		return fmt.Errorf("error building production WASM: %w", err)
 	}
@@ -1251,11 +1755,11 @@ func redeployWASM(appDir, staticResourceName string) error {
 	if err != nil {
 		return err
 	}
-	firstChunkExtras, err := runtimeExtras()
+	firstChunkExtras, err := runtimeExtras(wasmCompiler)
 	if err != nil {
 		return err
 	}
-	zipChunks, err := splitAndZip(wasmData, firstChunkExtras...)
+	zipChunks, err := splitAndZip(wasmData, stamp, firstChunkExtras...)
 	if err != nil {
 		return err
 	}
@@ -1266,15 +1770,7 @@ func redeployWASM(appDir, staticResourceName string) error {
 		addStaticResource(files, resourceNames[i], chunk)
 	}
 
-	// Generate minimal package.xml for just the static resource(s)
-	pkg := `<?xml version="1.0" encoding="UTF-8"?>
-<Package xmlns="http://soap.sforce.com/2006/04/metadata">
-  <types>
-` + staticResourceMembersXML(resourceNames) + `    <name>StaticResource</name>
-  </types>
-  <version>58.0</version>
-</Package>`
-	files["package.xml"] = []byte(pkg)
+	files["package.xml"] = []byte(staticResourcePackageXML(resourceNames))
 
 	// Deploy only the WASM static resource(s)
 	return performDeployment(files, staticResourceName, "", false, nil)
@@ -1283,15 +1779,17 @@ func redeployWASM(appDir, staticResourceName string) error {
 // buildProdWASM compiles the Go app in appDir to WebAssembly for production.
 // It strips debug symbols (-s -w) and trims source paths (-trimpath) to keep
 // the bundle small enough to fit Salesforce's 5MB static-resource limit, and
-// optionally post-processes the output with wasm-opt -Oz when available.
-func buildProdWASM(appDir string) (string, error) {
+// optionally post-processes the output with wasm-opt -Oz when available. With
+// --compiler tinygo the bundle is built by TinyGo, optimizing for size.
+func buildProdWASM(appDir string, stamp bundleManifest) (string, error) {
 	// create temporary build directory
 	buildDir, err := os.MkdirTemp("", "thunder-deploy-*")
 	if err != nil {
 		return "", err
 	}
 	outWasm := filepath.Join(buildDir, "bundle.wasm")
-	cmd := exec.Command("go", "build", "-trimpath", "-ldflags=-s -w", "-o", outWasm)
+	args := wasmBuildCommand(wasmCompiler, false, outWasm, stamp)
+	cmd := exec.Command(args[0], args[1:]...)
 
 	// Set up environment with smart GOWORK handling
 	env := append(os.Environ(), "GOOS=js", "GOARCH=wasm")
@@ -1353,55 +1851,117 @@ func optimizeWASM(wasmPath string) {
 // staticResourceLimit is Salesforce's maximum size for a single static resource (5 MB).
 const staticResourceLimit = 5 * 1024 * 1024
 
-// splitAndZip compresses the WebAssembly binary into one or more zip archives,
-// each holding a contiguous slice of the bundle as bundle.wasm and each small
-// enough to deploy as an individual Salesforce static resource. The first archive
-// always carries a parts.json manifest recording the total chunk count (1 when
-// the bundle fits in a single resource); the runtime loader treats a resource
-// without that manifest as a legacy single-part app.
 // runtimeExtras returns the extra files to pack into the first WASM static
 // resource: the Go runtime (wasm_exec.js) from the SDK that built the bundle.
 // Taking it from the same SDK keeps the runtime in lockstep with the compiler.
 // Visualforce pages load it from the resource as a script; LWC deployments load
-// the runtime through the go LWC and ignore it. It ships unconditionally so
+// the runtime through the go LWC and ignore it, except for TinyGo bundles,
+// whose runtime differs from the standard one. It ships unconditionally so
 // WASM-only redeploys (--app-only, --watch) don't need to know how the app was
 // originally deployed.
-func runtimeExtras() ([]zipEntry, error) {
-	wasmExecPath := filepath.Join(runtime.GOROOT(), "lib", "wasm", "wasm_exec.js")
-	wasmExec, err := os.ReadFile(wasmExecPath)
+func runtimeExtras(compiler string) ([]zipEntry, error) {
+	path, err := wasmExecPath(compiler)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read wasm_exec.js from the Go SDK: %w", err)
+		return nil, err
+	}
+	wasmExec, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wasm_exec.js from the %s SDK: %w", compiler, err)
 	}
 	return []zipEntry{{name: "wasm_exec.js", data: wasmExec}}, nil
 }
 
-func splitAndZip(wasmData []byte, firstChunkExtras ...zipEntry) ([][]byte, error) {
-	return splitAndZipWithLimit(wasmData, staticResourceLimit, firstChunkExtras...)
+// wasmExecPath returns the path of the JavaScript runtime (wasm_exec.js)
+// matching the compiler's WebAssembly output.
+func wasmExecPath(compiler string) (string, error) {
+	if compiler != compilerTinyGo {
+		return filepath.Join(runtime.GOROOT(), "lib", "wasm", "wasm_exec.js"), nil
+	}
+	out, err := exec.Command("tinygo", "env", "TINYGOROOT").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate TinyGo (is tinygo on PATH?): %w", err)
+	}
+	return filepath.Join(strings.TrimSpace(string(out)), "targets", "wasm_exec.js"), nil
+}
+
+// wasmBuildCommand returns the command line compiling the app in the current
+// directory to out. Development builds add the dev build tag; production
+// builds strip debug information and stamp the build. TinyGo builds target
+// browser WebAssembly and optimize for size.
+func wasmBuildCommand(compiler string, dev bool, out string, stamp bundleManifest) []string {
+	if compiler == compilerTinyGo {
+		args := []string{"tinygo", "build", "-target", "wasm"}
+		if dev {
+			args = append(args, "-tags", "dev")
+		} else {
+			args = append(args, "-no-debug", "-opt=z")
+			if ldflags := stampLDFlags(stamp); ldflags != "" {
+				args = append(args, "-ldflags="+ldflags)
+			}
+		}
+		return append(args, "-o", out)
+	}
+	if dev {
+		return []string{"go", "build", "-o", out, "-tags", "dev"}
+	}
+	return []string{"go", "build", "-trimpath", "-ldflags=" + buildLDFlags(stamp), "-o", out}
+}
+
+// checkCompiler validates the --compiler flag, and that TinyGo is installed
+// when selected.
+func checkCompiler(compiler string) error {
+	switch compiler {
+	case compilerGo:
+		return nil
+	case compilerTinyGo:
+		if _, err := exec.LookPath("tinygo"); err != nil {
+			return fmt.Errorf("--compiler tinygo requires TinyGo on PATH (see https://tinygo.org/getting-started/install/)")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown compiler %q; use %q or %q", compiler, compilerGo, compilerTinyGo)
+	}
+}
+
+// splitAndZip compresses the WebAssembly binary into one or more zip archives,
+// each holding a contiguous slice of the bundle as bundle.wasm and each small
+// enough to deploy as an individual Salesforce static resource. The first archive
+// always carries a parts.json manifest recording the total chunk count (1 when
+// the bundle fits in a single resource) and each chunk's content hash; the
+// runtime loader treats a resource without that manifest as a legacy
+// single-part app. Chunk boundaries are content-defined (see cdcBoundaries), so
+// a change in one region of the bundle leaves the other chunks, and their cached
+// copies in the browser, intact.
+func splitAndZip(wasmData []byte, manifest bundleManifest, firstChunkExtras ...zipEntry) ([][]byte, error) {
+	return splitAndZipWithLimit(wasmData, staticResourceLimit, manifest, firstChunkExtras...)
 }
 
 // splitAndZipWithLimit is splitAndZip with an explicit per-resource byte limit
 // (parameterized for testing). firstChunkExtras are additional files packed into
 // the first archive only (e.g. wasm_exec.js for Visualforce deployments).
-func splitAndZipWithLimit(wasmData []byte, limit int, firstChunkExtras ...zipEntry) ([][]byte, error) {
+func splitAndZipWithLimit(wasmData []byte, limit int, manifest bundleManifest, firstChunkExtras ...zipEntry) ([][]byte, error) {
 	// The single-resource case still carries a parts.json manifest (parts=1) so
 	// newly deployed apps are described uniformly.
-	whole, err := zipChunkWithManifest(wasmData, 1, firstChunkExtras...)
+	manifest.Parts = 1
+	manifest.Chunks = chunkInfos(wasmData, []int{len(wasmData)})
+	whole, err := zipChunkWithManifest(wasmData, manifest, firstChunkExtras...)
 	if err != nil {
 		return nil, err
 	}
 	if len(whole) <= limit {
 		return [][]byte{whole}, nil
 	}
-	// Estimate the compression ratio from the whole-bundle archive so raw chunks
-	// are sized to land comfortably under the limit once compressed. Target 90%
-	// of the limit to leave headroom for variation in compressibility.
+	// Estimate the compression ratio from the whole-bundle archive so the
+	// largest raw chunk lands comfortably under the limit once compressed.
+	// Target 90% of the limit to leave headroom for variation in
+	// compressibility.
 	ratio := float64(len(whole)) / float64(len(wasmData))
-	rawChunk := int(float64(limit) * 0.9 / ratio)
-	if rawChunk < 1 {
-		rawChunk = limit
+	maxChunk := int(float64(limit) * 0.9 / ratio)
+	if maxChunk < 1 {
+		maxChunk = limit
 	}
 	for {
-		chunks, ok, err := chunkAndZip(wasmData, rawChunk, limit, firstChunkExtras...)
+		chunks, ok, err := chunkAndZip(wasmData, maxChunk, limit, manifest, firstChunkExtras...)
 		if err != nil {
 			return nil, err
 		}
@@ -1409,29 +1969,29 @@ func splitAndZipWithLimit(wasmData []byte, limit int, firstChunkExtras ...zipEnt
 			return chunks, nil
 		}
 		// A chunk exceeded the limit (an atypically incompressible region);
-		// shrink the raw chunk size and retry.
-		rawChunk = rawChunk * 4 / 5
-		if rawChunk < 1024 {
+		// shrink the maximum chunk size and retry.
+		maxChunk = maxChunk * 4 / 5
+		if maxChunk < 1024 {
 			return nil, fmt.Errorf("unable to split WASM bundle under the %d-byte static resource limit", limit)
 		}
 	}
 }
 
-// chunkAndZip slices wasmData into rawChunk-sized pieces and zips each. The first
-// chunk also carries a parts.json manifest recording the total chunk count so the
-// runtime loader knows how many sibling Part resources to fetch. It reports
-// ok=false (without error) if any resulting archive exceeds limit so the caller
-// can retry with a smaller chunk size.
-func chunkAndZip(wasmData []byte, rawChunk, limit int, firstChunkExtras ...zipEntry) (chunks [][]byte, ok bool, err error) {
-	count := (len(wasmData) + rawChunk - 1) / rawChunk
-	for off, idx := 0, 0; off < len(wasmData); off, idx = off+rawChunk, idx+1 {
-		end := off + rawChunk
-		if end > len(wasmData) {
-			end = len(wasmData)
-		}
+// chunkAndZip cuts wasmData at content-defined boundaries no more than maxChunk
+// bytes apart and zips each piece. The first chunk also carries a parts.json
+// manifest recording the total chunk count so the runtime loader knows how many
+// sibling Part resources to fetch, and which of them it already has cached. It
+// reports ok=false (without error) if any resulting archive exceeds limit so the
+// caller can retry with a smaller maximum.
+func chunkAndZip(wasmData []byte, maxChunk, limit int, manifest bundleManifest, firstChunkExtras ...zipEntry) (chunks [][]byte, ok bool, err error) {
+	ends := cdcBoundaries(wasmData, maxChunk/4, maxChunk)
+	manifest.Parts = len(ends)
+	manifest.Chunks = chunkInfos(wasmData, ends)
+	off := 0
+	for idx, end := range ends {
 		var z []byte
 		if idx == 0 {
-			z, err = zipChunkWithManifest(wasmData[off:end], count, firstChunkExtras...)
+			z, err = zipChunkWithManifest(wasmData[off:end], manifest, firstChunkExtras...)
 		} else {
 			z, err = zipBundle(wasmData[off:end])
 		}
@@ -1442,10 +2002,77 @@ func chunkAndZip(wasmData []byte, rawChunk, limit int, firstChunkExtras ...zipEn
 			return nil, false, nil
 		}
 		chunks = append(chunks, z)
+		off = end
 	}
 	return chunks, true, nil
 }
 
+// gearTable maps each byte to a pseudo-random 64-bit value for the rolling hash
+// in cdcBoundaries. It is generated with splitmix64 from a fixed seed so chunk
+// boundaries are stable across builds and Go versions.
+var gearTable = func() (table [256]uint64) {
+	x := uint64(0x7468756e646572) // "thunder"
+	for i := range table {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// cdcBoundaries splits data with a gear rolling hash and returns the end offset
+// of each chunk. A boundary falls where the hash of the preceding 64 bytes has
+// its top bits clear, so boundaries move with the content rather than with
+// absolute offsets: inserting or changing bytes only disturbs the chunks around
+// the edit. Chunks are at least minSize bytes (except the last) and at most
+// maxSize bytes, averaging roughly minSize plus the expected gap between hash
+// matches.
+func cdcBoundaries(data []byte, minSize, maxSize int) []int {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	minSize = max(0, min(minSize, maxSize))
+	// Expect a match roughly every maxSize/4 bytes past the minimum, so typical
+	// chunks sit well inside the bounds.
+	bits := 0
+	for gap := (maxSize - minSize) / 3; gap > 1; gap >>= 1 {
+		bits++
+	}
+	var ends []int
+	start := 0
+	for start < len(data) {
+		end := min(start+maxSize, len(data))
+		if end-start > minSize && bits > 0 {
+			var h uint64
+			for i := start; i < end; i++ {
+				h = h<<1 + gearTable[data[i]]
+				if i+1-start >= minSize && h>>(64-bits) == 0 {
+					end = i + 1
+					break
+				}
+			}
+		}
+		ends = append(ends, end)
+		start = end
+	}
+	return ends
+}
+
+// chunkInfos returns the content hash and size of each piece of wasmData ending
+// at the given offsets, in load order.
+func chunkInfos(wasmData []byte, ends []int) []chunkInfo {
+	var infos []chunkInfo
+	off := 0
+	for _, end := range ends {
+		sum := sha256.Sum256(wasmData[off:end])
+		infos = append(infos, chunkInfo{SHA256: hex.EncodeToString(sum[:]), Size: end - off})
+		off = end
+	}
+	return infos
+}
+
 // staticResourceNames returns the static resource name for each WASM chunk in
 // load order. Chunk 0 keeps the bare base name so the LWC wrapper's
 // resourceUrl import is unchanged; additional chunks are suffixed Part1, Part2,
@@ -1482,6 +2109,18 @@ func staticResourceMembersXML(names []string) string {
 	return b.String()
 }
 
+// staticResourcePackageXML returns a minimal package.xml for just the static
+// resource(s).
+func staticResourcePackageXML(resourceNames []string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+  <types>
+` + staticResourceMembersXML(resourceNames) + `    <name>StaticResource</name>
+  </types>
+  <version>58.0</version>
+</Package>`
+}
+
 // generateAppJS builds the LWC JavaScript wrapper for a deployed app. It always
 // imports the base static resource and sets this.app; when the bundle is split
 // across several resources Thunder discovers the additional chunks at runtime from
@@ -1548,6 +2187,109 @@ func buildPackageXML(resourceNames []string, appComp, tabName string, thunderDev
 	return b.String()
 }
 
+// appLabelsDir is the directory of an app holding its message bundles, one
+// JSON file per language (e.g. labels/en_US.json), as read by
+// i18n.RegisterFS. deploy ships the default language's bundle as Custom
+// Labels so admins can override and translate the messages in Setup.
+const appLabelsDir = "labels"
+
+// customLabelName matches valid Custom Label names: a letter followed by
+// letters, digits and single underscores, not ending in an underscore.
+var customLabelName = regexp.MustCompile(`^[A-Za-z](?:[A-Za-z0-9]|_[A-Za-z0-9])*$`)
+
+// customLabel is one label of a CustomLabels metadata file.
+type customLabel struct {
+	Name     string
+	Language string
+	Value    string
+}
+
+// readAppLabels reads the bundle of the app's default language from its
+// labels directory: en_US.json, else en.json, else the first bundle by name.
+// It returns no labels when the app has no bundles.
+func readAppLabels(appDir string) ([]customLabel, error) {
+	files, err := filepath.Glob(filepath.Join(appDir, appLabelsDir, "*.json"))
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	sort.Strings(files)
+	file := files[0]
+	for _, lang := range []string{"en", "en_US"} {
+		candidate := filepath.Join(appDir, appLabelsDir, lang+".json")
+		if _, err := os.Stat(candidate); err == nil {
+			file = candidate
+		}
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var bundle map[string]string
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	language := strings.TrimSuffix(filepath.Base(file), ".json")
+	if language == "en" {
+		language = "en_US"
+	}
+	labels := make([]customLabel, 0, len(bundle))
+	for name, value := range bundle {
+		if len(name) > 80 || !customLabelName.MatchString(name) {
+			return nil, fmt.Errorf("%s: %q is not a valid Custom Label name", file, name)
+		}
+		if len(value) > 1000 {
+			return nil, fmt.Errorf("%s: %s is longer than the 1000 characters a Custom Label can hold", file, name)
+		}
+		labels = append(labels, customLabel{Name: name, Language: language, Value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels, nil
+}
+
+// customLabelsXML renders labels as a CustomLabels metadata file.
+func customLabelsXML(labels []customLabel) string {
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString("<CustomLabels xmlns=\"http://soap.sforce.com/2006/04/metadata\">\n")
+	for _, l := range labels {
+		b.WriteString("    <labels>\n")
+		fmt.Fprintf(&b, "        <fullName>%s</fullName>\n", l.Name)
+		fmt.Fprintf(&b, "        <language>%s</language>\n", l.Language)
+		b.WriteString("        <protected>false</protected>\n")
+		fmt.Fprintf(&b, "        <shortDescription>%s</shortDescription>\n", l.Name)
+		fmt.Fprintf(&b, "        <value>%s</value>\n", escapeXML(l.Value))
+		b.WriteString("    </labels>\n")
+	}
+	b.WriteString("</CustomLabels>")
+	return b.String()
+}
+
+// addCustomLabels adds labels to the deployment's files and lists them in its
+// package.xml.
+func addCustomLabels(files forcecli.ForceMetadataFiles, labels []customLabel) {
+	if len(labels) == 0 {
+		return
+	}
+	files["labels/CustomLabels.labels"] = []byte(customLabelsXML(labels))
+	var members strings.Builder
+	members.WriteString("  <types>\n")
+	for _, l := range labels {
+		fmt.Fprintf(&members, "    <members>%s</members>\n", l.Name)
+	}
+	members.WriteString("    <name>CustomLabel</name>\n  </types>\n")
+	pkg := string(files["package.xml"])
+	if i := strings.Index(pkg, "  <version>"); i >= 0 {
+		files["package.xml"] = []byte(pkg[:i] + members.String() + pkg[i:])
+	}
+}
+
+// escapeXML escapes s for use as XML text.
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
 // addVisualforceMetadata registers everything a Visualforce-hosted app needs:
 // the Visualforce page itself and an optional CustomTab pointing at it. When
 // thunderDev is set it also bundles the GoBridge proxy classes and Thunder
@@ -1654,6 +2396,10 @@ func generateVisualforcePage(appName, controllerClass string, resourceNames []st
 		globalThis.recordId = recordIdParam;
 		globalThis.getRecordIdForDiv = function () { return recordId; };
 
+		// Icons reference the SLDS sprites bundled with <apex:slds/>; the
+		// Lightning Experience path does not exist on Visualforce domains.
+		globalThis.thunderIconSpritePath = "{!URLFOR($Asset.SLDS, 'assets/icons')}";
+
 		// REST proxy: reach GoBridge through JavaScript Remoting. The Go runtime
 		// expects a Promise that resolves to the JSON response string, mirroring
 		// the LWC callRest proxy.
@@ -1821,6 +2567,344 @@ func openVisualforceApp(pageName, tabName string, withTab bool) {
 	}
 }
 
+// bundleManifest is the parts.json manifest packed into the base static
+// resource. Parts tells the runtime loader how many chunks to fetch and Chunks
+// identifies their content so unchanged chunks can be served from the
+// browser's cache; the other fields stamp which build the resource holds and
+// are omitted when unknown.
+type bundleManifest struct {
+	Parts   int         `json:"parts"`
+	Chunks  []chunkInfo `json:"chunks,omitempty"`
+	Version string      `json:"version,omitempty"`
+	Commit  string      `json:"commit,omitempty"`
+	BuiltAt string      `json:"builtAt,omitempty"`
+	// Compiler is "tinygo" for TinyGo bundles, which need TinyGo's runtime;
+	// it is omitted for the standard Go toolchain.
+	Compiler string `json:"compiler,omitempty"`
+}
+
+// chunkInfo identifies one chunk of the bundle by the sha256 and size of its
+// bundle.wasm piece.
+type chunkInfo struct {
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// newBuildStamp describes a build of the app in appDir started now. The
+// version is the UTC build time (YYYYMMDDhhmmss), so versions sort
+// chronologically; the commit is the app's short git SHA when it is in a git
+// repository, suffixed -dirty when there are uncommitted changes.
+func newBuildStamp(appDir string) bundleManifest {
+	now := time.Now().UTC()
+	stamp := bundleManifest{
+		Version: now.Format("20060102150405"),
+		Commit:  gitCommit(appDir),
+		BuiltAt: now.Format(time.RFC3339),
+	}
+	if wasmCompiler == compilerTinyGo {
+		stamp.Compiler = compilerTinyGo
+	}
+	return stamp
+}
+
+// withDeployedStamp returns stamp with the version, commit and build time of
+// a deployed bundle's manifest, so rebuilding the same source reproduces the
+// deployed bundle byte for byte. The compiler is kept from stamp, since a
+// change of compiler is a real difference.
+func withDeployedStamp(stamp, deployed bundleManifest) bundleManifest {
+	stamp.Version = deployed.Version
+	stamp.Commit = deployed.Commit
+	stamp.BuiltAt = deployed.BuiltAt
+	return stamp
+}
+
+// gitCommit returns the short HEAD SHA of the git repository containing dir, or
+// "" when dir is not in a repository or git is unavailable.
+func gitCommit(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	commit := strings.TrimSpace(string(out))
+	if status, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output(); err == nil && len(bytes.TrimSpace(status)) > 0 {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+// buildLDFlags returns the linker flags for a production build: strip debug
+// symbols and stamp the build into the api package, where the app reads it
+// with api.BuildInfo().
+func buildLDFlags(stamp bundleManifest) string {
+	return strings.TrimSpace("-s -w " + stampLDFlags(stamp))
+}
+
+// stampLDFlags returns the -X linker flags that stamp the build into the api
+// package. TinyGo accepts only these, so they are kept apart from -s -w.
+func stampLDFlags(stamp bundleManifest) string {
+	var flags []string
+	for _, v := range []struct{ name, value string }{
+		{"buildVersion", stamp.Version},
+		{"buildCommit", stamp.Commit},
+		{"buildTime", stamp.BuiltAt},
+	} {
+		if v.value != "" {
+			flags = append(flags, "-X", "github.com/octoberswimmer/thunder/api."+v.name+"="+v.value)
+		}
+	}
+	return strings.Join(flags, " ")
+}
+
+// versionedResourceName returns the base static resource name under which
+// --keep retains the bundle built as version.
+func versionedResourceName(base, version string) string {
+	return base + "_v" + version
+}
+
+// resourceVersions groups versioned static resource names (as created by
+// versionedResourceName and staticResourceNames) by version. Names that are not
+// versions of base are ignored.
+func resourceVersions(base string, names []string) map[string][]string {
+	re := regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `_v(\d{14})(Part\d+)?$`)
+	versions := make(map[string][]string)
+	for _, name := range names {
+		if m := re.FindStringSubmatch(name); m != nil {
+			versions[m[1]] = append(versions[m[1]], name)
+		}
+	}
+	for _, names := range versions {
+		sort.Strings(names)
+	}
+	return versions
+}
+
+// sortedVersions returns the versions newest first.
+func sortedVersions(versions map[string][]string) []string {
+	list := make([]string, 0, len(versions))
+	for v := range versions {
+		list = append(list, v)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(list)))
+	return list
+}
+
+// expiredVersions returns the retained versions to delete so that, together
+// with the version being deployed, at most keep versions remain.
+func expiredVersions(versions map[string][]string, current string, keep int) []string {
+	var expired []string
+	kept := 1
+	for _, v := range sortedVersions(versions) {
+		if v == current {
+			continue
+		}
+		if kept < keep {
+			kept++
+			continue
+		}
+		expired = append(expired, versions[v]...)
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// previousVersion returns the newest retained version older than current, or
+// the newest retained version when current is unknown.
+func previousVersion(versions []string, current string) string {
+	for _, v := range versions {
+		if current == "" || v < current {
+			return v
+		}
+	}
+	return ""
+}
+
+// listResourceVersions queries the org for the retained versions of the base
+// static resource.
+func listResourceVersions(force *forcecli.Force, base string) (map[string][]string, error) {
+	// _ is a LIKE wildcard in SOQL; escape it so only <base>_v... matches.
+	soql := fmt.Sprintf("SELECT Name FROM StaticResource WHERE Name LIKE '%s\\_v%%'", base)
+	result, err := force.Query(soql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query static resources: %w", err)
+	}
+	var names []string
+	for _, r := range result.Records {
+		if name, ok := r["Name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	return resourceVersions(base, names), nil
+}
+
+// destructiveChangesXML returns a destructiveChanges manifest deleting the
+// named static resources.
+func destructiveChangesXML(resourceNames []string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+  <types>
+` + staticResourceMembersXML(resourceNames) + `    <name>StaticResource</name>
+  </types>
+</Package>`
+}
+
+// addVersionPruning adds a destructiveChangesPost.xml to files deleting the
+// retained bundles beyond the newest keep versions (counting the one being
+// deployed).
+func addVersionPruning(files forcecli.ForceMetadataFiles, base, current string, keep int) error {
+	creds, err := forcecli.ActiveCredentials(false)
+	if err != nil {
+		return fmt.Errorf("failed to load Salesforce credentials: %w", err)
+	}
+	versions, err := listResourceVersions(forcecli.NewForce(&creds), base)
+	if err != nil {
+		return err
+	}
+	expired := expiredVersions(versions, current, keep)
+	if len(expired) == 0 {
+		return nil
+	}
+	fmt.Printf("Removing %d static resource(s) from bundles beyond the newest %d versions\n", len(expired), keep)
+	files["destructiveChangesPost.xml"] = []byte(destructiveChangesXML(expired))
+	return nil
+}
+
+// readBundleManifest returns the parts.json manifest from a base static
+// resource. Bundles deployed before the manifest existed report a single part.
+func readBundleManifest(zipData []byte) (bundleManifest, error) {
+	manifest := bundleManifest{Parts: 1}
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return manifest, err
+	}
+	for _, f := range zr.File {
+		if f.Name != "parts.json" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return manifest, err
+		}
+		defer rc.Close()
+		if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+			return manifest, fmt.Errorf("invalid parts.json: %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+// retrieveStaticResources retrieves the named static resources, keyed by name.
+// Resources missing from the org are omitted.
+func retrieveStaticResources(force *forcecli.Force, names []string) (map[string][]byte, error) {
+	files, _, err := force.Metadata.RetrieveByPackageXmlContents([]byte(staticResourcePackageXML(names)))
+	if err != nil {
+		return nil, fmt.Errorf("retrieve failed: %w", err)
+	}
+	resources := make(map[string][]byte)
+	for _, name := range names {
+		if data, ok := files["staticresources/"+name+".resource"]; ok {
+			resources[name] = data
+		}
+	}
+	return resources, nil
+}
+
+// runRollback re-points the app at a bundle retained by `thunder deploy
+// --keep`, copying the versioned static resources back over the base names the
+// app loads.
+func runRollback(cmd *cobra.Command, args []string) error {
+	appDir := "."
+	if len(args) > 0 {
+		appDir = args[0]
+	}
+	absDir, err := filepath.Abs(appDir)
+	if err != nil {
+		return err
+	}
+	base := sanitizeStaticResourceName(filepath.Base(absDir))
+
+	creds, err := forcecli.ActiveCredentials(false)
+	if err != nil {
+		return fmt.Errorf("failed to load Salesforce credentials: %w", err)
+	}
+	force := forcecli.NewForce(&creds)
+	versions, err := listResourceVersions(force, base)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("no retained versions of %s found; deploy with --keep N to retain bundles", base)
+	}
+	ordered := sortedVersions(versions)
+
+	// The base resource's manifest records which version is live.
+	bases := []string{base}
+	for _, v := range ordered {
+		bases = append(bases, versionedResourceName(base, v))
+	}
+	resources, err := retrieveStaticResources(force, bases)
+	if err != nil {
+		return err
+	}
+	var current bundleManifest
+	if data, ok := resources[base]; ok {
+		current, _ = readBundleManifest(data)
+	}
+
+	if rollbackList {
+		fmt.Printf("Retained versions of %s (newest first):\n", base)
+		for _, v := range ordered {
+			m, _ := readBundleManifest(resources[versionedResourceName(base, v)])
+			marker := " "
+			if v == current.Version {
+				marker = "*"
+			}
+			fmt.Printf("%s %s  commit %-14s built %s  %d part(s)\n", marker, v, valueOr(m.Commit, "-"), valueOr(m.BuiltAt, "-"), m.Parts)
+		}
+		return nil
+	}
+
+	target := rollbackTo
+	if target == "" {
+		target = previousVersion(ordered, current.Version)
+	}
+	if _, ok := versions[target]; !ok || target == "" {
+		return fmt.Errorf("version %q is not retained in the org; run `thunder rollback --list` to see available versions", target)
+	}
+	if target == current.Version {
+		return fmt.Errorf("version %s is already live", target)
+	}
+
+	versioned, err := retrieveStaticResources(force, versions[target])
+	if err != nil {
+		return err
+	}
+	files := make(forcecli.ForceMetadataFiles)
+	var names []string
+	for _, name := range versions[target] {
+		data, ok := versioned[name]
+		if !ok {
+			return fmt.Errorf("static resource %s is missing from the org", name)
+		}
+		live := base + strings.TrimPrefix(name, versionedResourceName(base, target))
+		addStaticResource(files, live, data)
+		names = append(names, live)
+	}
+	if parts := len(names); current.Parts > 0 && parts != current.Parts {
+		fmt.Fprintf(os.Stderr, "Warning: version has %d part(s) but the live bundle has %d; redeploy Visualforce apps, whose page lists the parts, after rolling back\n", parts, current.Parts)
+	}
+	files["package.xml"] = []byte(staticResourcePackageXML(names))
+	fmt.Printf("Rolling %s back to version %s...\n", base, target)
+	return performDeployment(files, base, "", false, nil)
+}
+
+// valueOr returns s, or fallback when s is empty.
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
 // zipEntry is a single file to place in a static resource zip archive.
 type zipEntry struct {
 	name string
@@ -1862,14 +2946,17 @@ func zipBundle(wasmData []byte) ([]byte, error) {
 
 // zipChunkWithManifest compresses the first chunk of a split bundle, adding a
 // parts.json manifest that records how many static resources the full bundle was
-// split across. The runtime loader reads it to fetch and concatenate the
+// split across, along with the build's version stamp. The runtime loader reads it to fetch and concatenate the
 // remaining Part resources before instantiating the WASM module. extras are any
 // additional files to pack alongside (e.g. wasm_exec.js for Visualforce apps).
-func zipChunkWithManifest(wasmData []byte, parts int, extras ...zipEntry) ([]byte, error) {
-	manifest := fmt.Sprintf(`{"parts":%d}`, parts)
+func zipChunkWithManifest(wasmData []byte, manifest bundleManifest, extras ...zipEntry) ([]byte, error) {
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
 	entries := []zipEntry{
 		{name: "bundle.wasm", data: wasmData},
-		{name: "parts.json", data: []byte(manifest)},
+		{name: "parts.json", data: manifestJSON},
 	}
 	entries = append(entries, extras...)
 	return zipFiles(entries)
@@ -3,15 +3,20 @@ package main
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"io"
 	"math/rand"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
-	"strconv"
+	"reflect"
 	"strings"
 	"testing"
 
+	forcecli "github.com/ForceCLI/force/lib"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -33,6 +38,38 @@ func Test_indexHandler_serves_index_html(t *testing.T) {
 	}
 }
 
+func Test_sldsIconsHandler_proxies_sprites(t *testing.T) {
+	var gotPath string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte("<svg/>"))
+	}))
+	defer upstream.Close()
+	orig := sldsAssetsURL
+	sldsAssetsURL = upstream.URL + "/assets"
+	defer func() { sldsAssetsURL = orig }()
+
+	w := httptest.NewRecorder()
+	sldsIconsHandler(w, httptest.NewRequest("GET", "/_slds/icons/utility-sprite/svg/symbols.svg", nil))
+	res := w.Result()
+	if gotPath != "/assets/icons/utility-sprite/svg/symbols.svg" {
+		t.Errorf("upstream path = %q", gotPath)
+	}
+	if got := res.Header.Get("Content-Type"); got != "image/svg+xml" {
+		t.Errorf("Content-Type = %q; want image/svg+xml", got)
+	}
+	if body, _ := io.ReadAll(res.Body); string(body) != "<svg/>" {
+		t.Errorf("body = %q", body)
+	}
+
+	w = httptest.NewRecorder()
+	sldsIconsHandler(w, httptest.NewRequest("GET", "/_slds/icons/../styles/x.css", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status for path traversal = %d; want 404", w.Code)
+	}
+}
+
 func Test_wasmHandler_serves_wasm_file(t *testing.T) {
 	dir, err := os.MkdirTemp("", "test-build-*")
 	if err != nil {
@@ -168,10 +205,10 @@ func unzipChunkFile(t *testing.T, zipData []byte, name string) ([]byte, bool) {
 
 // Test_splitAndZip_single_chunk_includes_manifest verifies that a bundle small
 // enough for one resource is returned as a single chunk carrying a parts.json
-// manifest recording a count of 1.
+// manifest recording a count of 1 and the bundle's hash.
 func Test_splitAndZip_single_chunk_includes_manifest(t *testing.T) {
 	data := bytes.Repeat([]byte("thunder"), 1000)
-	chunks, err := splitAndZipWithLimit(data, staticResourceLimit)
+	chunks, err := splitAndZipWithLimit(data, staticResourceLimit, bundleManifest{})
 	if err != nil {
 		t.Fatalf("splitAndZipWithLimit returned error: %v", err)
 	}
@@ -189,14 +226,17 @@ func Test_splitAndZip_single_chunk_includes_manifest(t *testing.T) {
 	if !ok {
 		t.Fatal("single chunk missing parts.json manifest")
 	}
-	if got := string(manifest); got != `{"parts":1}` {
-		t.Errorf("manifest = %q; want %q", got, `{"parts":1}`)
+	sum := sha256.Sum256(data)
+	want := `{"parts":1,"chunks":[{"sha256":"` + hex.EncodeToString(sum[:]) + `","size":7000}]}`
+	if got := string(manifest); got != want {
+		t.Errorf("manifest = %q; want %q", got, want)
 	}
 }
 
 // Test_splitAndZip_splits_large_bundle verifies that an oversized bundle is split
 // into multiple chunks, each under the limit, that reassemble to the original,
-// with the first chunk's manifest recording the chunk count.
+// with the first chunk's manifest recording the chunk count and each chunk's
+// hash and size.
 func Test_splitAndZip_splits_large_bundle(t *testing.T) {
 	// Incompressible (pseudo-random) data so the compressed whole exceeds the
 	// small limit and forces a split. Deterministic seed keeps the test stable.
@@ -205,7 +245,7 @@ func Test_splitAndZip_splits_large_bundle(t *testing.T) {
 	rng.Read(data)
 	const limit = 64 * 1024
 
-	chunks, err := splitAndZipWithLimit(data, limit)
+	chunks, err := splitAndZipWithLimit(data, limit, bundleManifest{})
 	if err != nil {
 		t.Fatalf("splitAndZipWithLimit returned error: %v", err)
 	}
@@ -218,9 +258,12 @@ func Test_splitAndZip_splits_large_bundle(t *testing.T) {
 	if !ok {
 		t.Fatal("first chunk missing parts.json manifest")
 	}
-	wantManifest := `{"parts":` + strconv.Itoa(len(chunks)) + `}`
-	if got := string(manifest); got != wantManifest {
-		t.Errorf("manifest = %q; want %q", got, wantManifest)
+	var m bundleManifest
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		t.Fatalf("invalid manifest %q: %v", manifest, err)
+	}
+	if m.Parts != len(chunks) || len(m.Chunks) != len(chunks) {
+		t.Errorf("manifest = %s; want %d parts with %d chunk entries", manifest, len(chunks), len(chunks))
 	}
 	if _, ok := unzipChunkFile(t, chunks[len(chunks)-1], "parts.json"); ok {
 		t.Error("trailing chunk should not carry a parts.json manifest")
@@ -236,12 +279,57 @@ func Test_splitAndZip_splits_large_bundle(t *testing.T) {
 			t.Fatalf("chunk %d missing bundle.wasm", i)
 		}
 		reassembled = append(reassembled, wasm...)
+		if i < len(m.Chunks) {
+			sum := sha256.Sum256(wasm)
+			if m.Chunks[i].SHA256 != hex.EncodeToString(sum[:]) || m.Chunks[i].Size != len(wasm) {
+				t.Errorf("chunk %d manifest entry %+v does not match its content", i, m.Chunks[i])
+			}
+		}
 	}
 	if !bytes.Equal(reassembled, data) {
 		t.Error("reassembled bundle does not match original")
 	}
 }
 
+// Test_splitAndZip_keeps_unchanged_chunks verifies that chunk boundaries follow
+// the content: editing the middle of a bundle (here, inserting bytes) leaves the
+// leading and trailing chunks, and so their cached copies, unchanged.
+func Test_splitAndZip_keeps_unchanged_chunks(t *testing.T) {
+	data := make([]byte, 1024*1024)
+	rng := rand.New(rand.NewSource(1))
+	rng.Read(data)
+	const limit = 64 * 1024
+
+	mid := len(data) / 2
+	edited := append(append(append([]byte{}, data[:mid]...), []byte("changed code")...), data[mid:]...)
+
+	manifestOf := func(b []byte) bundleManifest {
+		chunks, err := splitAndZipWithLimit(b, limit, bundleManifest{})
+		if err != nil {
+			t.Fatalf("splitAndZipWithLimit returned error: %v", err)
+		}
+		raw, ok := unzipChunkFile(t, chunks[0], "parts.json")
+		if !ok {
+			t.Fatal("first chunk missing parts.json manifest")
+		}
+		var m bundleManifest
+		if err := json.Unmarshal(raw, &m); err != nil {
+			t.Fatalf("invalid manifest %q: %v", raw, err)
+		}
+		return m
+	}
+	before, after := manifestOf(data), manifestOf(edited)
+	if len(before.Chunks) < 4 {
+		t.Fatalf("expected several chunks, got %d", len(before.Chunks))
+	}
+	if before.Chunks[0] != after.Chunks[0] {
+		t.Errorf("leading chunk changed: %+v -> %+v", before.Chunks[0], after.Chunks[0])
+	}
+	if b, a := before.Chunks[len(before.Chunks)-1], after.Chunks[len(after.Chunks)-1]; b != a {
+		t.Errorf("trailing chunk changed: %+v -> %+v", b, a)
+	}
+}
+
 // Test_splitAndZip_first_chunk_carries_extras verifies that extra files (e.g.
 // wasm_exec.js for Visualforce apps) are packed into the first chunk only.
 func Test_splitAndZip_first_chunk_carries_extras(t *testing.T) {
@@ -253,7 +341,7 @@ func Test_splitAndZip_first_chunk_carries_extras(t *testing.T) {
 	const limit = 64 * 1024
 	exec := []byte("// wasm_exec.js runtime shim")
 
-	chunks, err := splitAndZipWithLimit(data, limit, zipEntry{name: "wasm_exec.js", data: exec})
+	chunks, err := splitAndZipWithLimit(data, limit, bundleManifest{}, zipEntry{name: "wasm_exec.js", data: exec})
 	if err != nil {
 		t.Fatalf("splitAndZipWithLimit returned error: %v", err)
 	}
@@ -280,7 +368,7 @@ func Test_runtimeExtras_ships_wasm_exec_without_visualforce_flag(t *testing.T) {
 	deployVisualforce = false
 	defer func() { deployVisualforce = orig }()
 
-	extras, err := runtimeExtras()
+	extras, err := runtimeExtras(compilerGo)
 	if err != nil {
 		t.Fatalf("runtimeExtras returned error: %v", err)
 	}
@@ -345,6 +433,7 @@ func Test_generateVisualforcePage_wires_runtime_and_remoting(t *testing.T) {
 		`<title>Clinic Scheduler</title>`,
 		// Absent ?id= must surface as undefined so api.RecordId() reports "no record".
 		`var recordId = recordIdParam || undefined;`,
+		`globalThis.thunderIconSpritePath = "{!URLFOR($Asset.SLDS, 'assets/icons')}";`,
 	} {
 		if !strings.Contains(page, want) {
 			t.Errorf("generated page missing %q\n%s", want, page)
@@ -486,3 +575,406 @@ func Test_packageLoadError_surfaces_underlying_package_errors(t *testing.T) {
 		t.Errorf("expected underlying load error to be surfaced, got: %v", err)
 	}
 }
+
+// Test_deployOptions_skips_tests_for_regular_deploys verifies a normal deploy
+// without named tests runs no Apex tests.
+func Test_deployOptions_skips_tests_for_regular_deploys(t *testing.T) {
+	opts := deployOptions(nil, false)
+	if opts.CheckOnly {
+		t.Error("regular deploy should not be check-only")
+	}
+	if !opts.RollbackOnError || !opts.SinglePackage {
+		t.Errorf("expected SinglePackage and RollbackOnError, got %+v", opts)
+	}
+	if opts.TestLevel != "" || opts.RunTests == nil || len(opts.RunTests) != 0 {
+		t.Errorf("expected no tests to run, got TestLevel=%q RunTests=%v", opts.TestLevel, opts.RunTests)
+	}
+}
+
+// Test_deployOptions_check_only_runs_local_tests verifies a validation deploy
+// without named tests falls back to the org's local tests.
+func Test_deployOptions_check_only_runs_local_tests(t *testing.T) {
+	opts := deployOptions(nil, true)
+	if !opts.CheckOnly {
+		t.Error("expected CheckOnly to be set")
+	}
+	if opts.TestLevel != "RunLocalTests" {
+		t.Errorf("TestLevel = %q; want RunLocalTests", opts.TestLevel)
+	}
+}
+
+// Test_deployOptions_check_only_keeps_specified_tests verifies named tests
+// (e.g. GoBridgeTest for --thunder-dev) are run during validation.
+func Test_deployOptions_check_only_keeps_specified_tests(t *testing.T) {
+	opts := deployOptions([]string{"GoBridgeTest"}, true)
+	if opts.TestLevel != "RunSpecifiedTests" {
+		t.Errorf("TestLevel = %q; want RunSpecifiedTests", opts.TestLevel)
+	}
+	if len(opts.RunTests) != 1 || opts.RunTests[0] != "GoBridgeTest" {
+		t.Errorf("RunTests = %v; want [GoBridgeTest]", opts.RunTests)
+	}
+}
+
+// Test_writeMetadataFiles_preserves_paths verifies dry-run output mirrors the
+// metadata layout so it can be deployed by another pipeline.
+func Test_writeMetadataFiles_preserves_paths(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	files := forcecli.ForceMetadataFiles{
+		"package.xml":                             []byte("<Package/>"),
+		"staticresources/MyApp.resource":          []byte("zip"),
+		"lwc/myApp/myApp.js":                      []byte("js"),
+		"tabs/MY_APP.tab-meta.xml":                []byte("<CustomTab/>"),
+		"staticresources/MyApp.resource-meta.xml": []byte("<StaticResource/>"),
+	}
+	if err := writeMetadataFiles(dir, files); err != nil {
+		t.Fatalf("writeMetadataFiles returned error: %v", err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("reading %s: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s = %q; want %q", name, got, want)
+		}
+	}
+}
+
+// Test_clearMetadataOutput_removes_earlier_output verifies a directory holding
+// an earlier dry run is cleared, while other non-empty directories are kept.
+func Test_clearMetadataOutput_removes_earlier_output(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := writeMetadataFiles(dir, forcecli.ForceMetadataFiles{
+		"package.xml":           []byte("<Package/>"),
+		"tabs/OLD.tab-meta.xml": []byte("<CustomTab/>"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := clearMetadataOutput(dir); err != nil {
+		t.Fatalf("clearMetadataOutput returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tabs", "OLD.tab-meta.xml")); !os.IsNotExist(err) {
+		t.Error("expected the stale tab to be removed")
+	}
+
+	other := t.TempDir()
+	if err := os.WriteFile(filepath.Join(other, "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := clearMetadataOutput(other); err == nil {
+		t.Error("expected an error for a directory without earlier metadata")
+	}
+	if _, err := os.Stat(filepath.Join(other, "main.go")); err != nil {
+		t.Errorf("expected main.go to be kept: %v", err)
+	}
+	if err := clearMetadataOutput(filepath.Join(other, "missing")); err != nil {
+		t.Errorf("expected no error for a missing directory, got %v", err)
+	}
+}
+
+// Test_runDeploy_rejects_check_only_with_dry_run verifies conflicting modes
+// fail before anything is built or deployed.
+func Test_runDeploy_rejects_check_only_with_dry_run(t *testing.T) {
+	origCheck, origOutput, origDry := deployCheckOnly, deployOutput, deployDryRun
+	defer func() { deployCheckOnly, deployOutput, deployDryRun = origCheck, origOutput, origDry }()
+	deployCheckOnly = true
+	deployDryRun = false
+	deployOutput = "out"
+
+	err := runDeploy(deployCmd, []string{t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "--check-only") {
+		t.Errorf("expected --check-only conflict error, got: %v", err)
+	}
+}
+
+// Test_diffMetadata_reports_added_removed_and_modified verifies local files
+// missing from the org, org-only files and changed files are all reported,
+// ignoring package.xml.
+func Test_diffMetadata_reports_added_removed_and_modified(t *testing.T) {
+	local := forcecli.ForceMetadataFiles{
+		"package.xml":        []byte("<Package>local</Package>"),
+		"lwc/myApp/myApp.js": []byte("line1\nline2\n"),
+		"lwc/myApp/new.js":   []byte("x"),
+	}
+	remote := forcecli.ForceMetadataFiles{
+		"package.xml":          []byte("<Package>remote</Package>"),
+		"lwc/myApp/myApp.js":   []byte("line1\nhotfix\n"),
+		"lwc/myApp/myApp.html": []byte("<template></template>"),
+	}
+	changes := diffMetadata(local, remote)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d: %v", len(changes), changes)
+	}
+	kinds := map[string]byte{}
+	for _, c := range changes {
+		kinds[c.name] = c.kind
+	}
+	if kinds["lwc/myApp/new.js"] != '+' || kinds["lwc/myApp/myApp.html"] != '-' || kinds["lwc/myApp/myApp.js"] != '~' {
+		t.Errorf("unexpected change kinds: %v", kinds)
+	}
+	for _, c := range changes {
+		if c.name != "lwc/myApp/myApp.js" {
+			continue
+		}
+		want := []string{"- hotfix", "+ line2"}
+		if strings.Join(c.detail, "\n") != strings.Join(want, "\n") {
+			t.Errorf("diff detail = %q; want %q", c.detail, want)
+		}
+	}
+}
+
+// Test_diffMetadata_matches_retrieved_tab_name verifies a deployed
+// tabs/X.tab-meta.xml compares against the retrieved tabs/X.tab.
+func Test_diffMetadata_matches_retrieved_tab_name(t *testing.T) {
+	local := forcecli.ForceMetadataFiles{"tabs/MY_APP.tab-meta.xml": []byte("<label>My App</label>\n")}
+	remote := forcecli.ForceMetadataFiles{"tabs/MY_APP.tab": []byte("<label>My App</label>\r\n")}
+	if changes := diffMetadata(local, remote); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+	remote["tabs/MY_APP.tab"] = []byte("<label>Hotfixed</label>")
+	changes := diffMetadata(local, remote)
+	if len(changes) != 1 || changes[0].name != "tabs/MY_APP.tab" || changes[0].kind != '~' {
+		t.Errorf("expected tab label change, got %v", changes)
+	}
+}
+
+// Test_diffStaticResource_compares_archive_contents verifies static resources
+// are compared by archived files, listing the entries that changed.
+func Test_diffStaticResource_compares_archive_contents(t *testing.T) {
+	a, err := zipFiles([]zipEntry{{name: "bundle.wasm", data: []byte("one")}, {name: "parts.json", data: []byte(`{"parts":1}`)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := zipFiles([]zipEntry{{name: "bundle.wasm", data: []byte("one")}, {name: "parts.json", data: []byte(`{"parts":1}`)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail := diffStaticResource(a, b); detail != nil {
+		t.Errorf("identical archives reported as different: %v", detail)
+	}
+	c, err := zipFiles([]zipEntry{{name: "bundle.wasm", data: []byte("two")}, {name: "parts.json", data: []byte(`{"parts":1}`)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail := diffStaticResource(c, a)
+	if len(detail) != 3 || detail[2] != "bundle.wasm: changed" {
+		t.Errorf("unexpected detail: %v", detail)
+	}
+	if !strings.Contains(detail[0], "org:") || !strings.Contains(detail[1], "local:") {
+		t.Errorf("expected org and local summaries, got %v", detail)
+	}
+}
+
+// Test_zipChunkWithManifest_stamps_build_version verifies the version stamp is
+// recorded in parts.json and read back by readBundleManifest.
+func Test_zipChunkWithManifest_stamps_build_version(t *testing.T) {
+	stamp := bundleManifest{Parts: 2, Version: "20240102030405", Commit: "abc1234", BuiltAt: "2024-01-02T03:04:05Z"}
+	z, err := zipChunkWithManifest([]byte("wasm"), stamp)
+	if err != nil {
+		t.Fatalf("zipChunkWithManifest returned error: %v", err)
+	}
+	got, err := readBundleManifest(z)
+	if err != nil {
+		t.Fatalf("readBundleManifest returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, stamp) {
+		t.Errorf("manifest = %+v; want %+v", got, stamp)
+	}
+}
+
+// Test_readBundleManifest_defaults_legacy_bundles_to_one_part verifies a
+// resource without parts.json is treated as a single-part bundle.
+func Test_readBundleManifest_defaults_legacy_bundles_to_one_part(t *testing.T) {
+	z, err := zipBundle([]byte("wasm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readBundleManifest(z)
+	if err != nil {
+		t.Fatalf("readBundleManifest returned error: %v", err)
+	}
+	if got.Parts != 1 || got.Version != "" {
+		t.Errorf("manifest = %+v; want a single unversioned part", got)
+	}
+}
+
+// Test_buildLDFlags_stamps_api_package verifies the build stamp is passed to
+// the api package's linker variables and empty values are skipped.
+func Test_buildLDFlags_stamps_api_package(t *testing.T) {
+	got := buildLDFlags(bundleManifest{Version: "20240102030405", BuiltAt: "2024-01-02T03:04:05Z"})
+	want := "-s -w -X github.com/octoberswimmer/thunder/api.buildVersion=20240102030405 -X github.com/octoberswimmer/thunder/api.buildTime=2024-01-02T03:04:05Z"
+	if got != want {
+		t.Errorf("buildLDFlags = %q; want %q", got, want)
+	}
+	if got := buildLDFlags(bundleManifest{}); got != "-s -w" {
+		t.Errorf("buildLDFlags(empty) = %q; want %q", got, "-s -w")
+	}
+}
+
+// Test_resourceVersions_groups_parts_by_version verifies versioned resources
+// and their Part siblings are grouped, ignoring unrelated resources.
+func Test_resourceVersions_groups_parts_by_version(t *testing.T) {
+	names := []string{
+		"MyApp_v20240101000000",
+		"MyApp_v20240102000000Part1",
+		"MyApp_v20240102000000",
+		"MyApp",
+		"MyAppOther_v20240103000000",
+		"MyApp_vlatest",
+	}
+	got := resourceVersions("MyApp", names)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 versions, got %v", got)
+	}
+	if parts := got["20240102000000"]; len(parts) != 2 || parts[0] != "MyApp_v20240102000000" || parts[1] != "MyApp_v20240102000000Part1" {
+		t.Errorf("unexpected parts for 20240102000000: %v", parts)
+	}
+	if order := sortedVersions(got); order[0] != "20240102000000" || order[1] != "20240101000000" {
+		t.Errorf("sortedVersions = %v; want newest first", order)
+	}
+}
+
+// Test_expiredVersions_keeps_newest verifies --keep counts the version being
+// deployed and expires every part of older bundles.
+func Test_expiredVersions_keeps_newest(t *testing.T) {
+	versions := map[string][]string{
+		"20240101000000": {"MyApp_v20240101000000", "MyApp_v20240101000000Part1"},
+		"20240102000000": {"MyApp_v20240102000000"},
+		"20240103000000": {"MyApp_v20240103000000"},
+	}
+	got := expiredVersions(versions, "20240104000000", 2)
+	want := []string{"MyApp_v20240101000000", "MyApp_v20240101000000Part1", "MyApp_v20240102000000"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expiredVersions = %v; want %v", got, want)
+	}
+	if got := expiredVersions(versions, "20240104000000", 4); len(got) != 0 {
+		t.Errorf("expected nothing to expire, got %v", got)
+	}
+}
+
+// Test_previousVersion_picks_version_before_live verifies rollback defaults to
+// the newest version older than the live one.
+func Test_previousVersion_picks_version_before_live(t *testing.T) {
+	versions := []string{"20240103000000", "20240102000000", "20240101000000"}
+	if got := previousVersion(versions, "20240103000000"); got != "20240102000000" {
+		t.Errorf("previousVersion = %q; want 20240102000000", got)
+	}
+	if got := previousVersion(versions, ""); got != "20240103000000" {
+		t.Errorf("previousVersion with unknown live version = %q; want newest", got)
+	}
+	if got := previousVersion(versions, "20240101000000"); got != "" {
+		t.Errorf("previousVersion of oldest = %q; want empty", got)
+	}
+}
+
+// Test_wasmBuildCommand_standard_toolchain verifies the go build command lines
+// for development and production builds.
+func Test_wasmBuildCommand_standard_toolchain(t *testing.T) {
+	got := strings.Join(wasmBuildCommand(compilerGo, true, "out.wasm", bundleManifest{}), " ")
+	if want := "go build -o out.wasm -tags dev"; got != want {
+		t.Errorf("dev command = %q; want %q", got, want)
+	}
+	got = strings.Join(wasmBuildCommand(compilerGo, false, "out.wasm", bundleManifest{}), " ")
+	if want := "go build -trimpath -ldflags=-s -w -o out.wasm"; got != want {
+		t.Errorf("prod command = %q; want %q", got, want)
+	}
+}
+
+// Test_wasmBuildCommand_tinygo verifies TinyGo builds target browser wasm,
+// optimize for size in production and pass only -X linker flags.
+func Test_wasmBuildCommand_tinygo(t *testing.T) {
+	got := strings.Join(wasmBuildCommand(compilerTinyGo, true, "out.wasm", bundleManifest{}), " ")
+	if want := "tinygo build -target wasm -tags dev -o out.wasm"; got != want {
+		t.Errorf("dev command = %q; want %q", got, want)
+	}
+	got = strings.Join(wasmBuildCommand(compilerTinyGo, false, "out.wasm", bundleManifest{Version: "20240102030405"}), " ")
+	want := "tinygo build -target wasm -no-debug -opt=z -ldflags=-X github.com/octoberswimmer/thunder/api.buildVersion=20240102030405 -o out.wasm"
+	if got != want {
+		t.Errorf("prod command = %q; want %q", got, want)
+	}
+}
+
+// Test_checkCompiler_rejects_unknown_compiler verifies --compiler is validated.
+func Test_checkCompiler_rejects_unknown_compiler(t *testing.T) {
+	if err := checkCompiler(compilerGo); err != nil {
+		t.Errorf("checkCompiler(go) returned error: %v", err)
+	}
+	if err := checkCompiler("gccgo"); err == nil {
+		t.Error("expected an error for an unknown compiler")
+	}
+	t.Setenv("PATH", t.TempDir())
+	if err := checkCompiler(compilerTinyGo); err == nil || !strings.Contains(err.Error(), "TinyGo") {
+		t.Errorf("expected missing TinyGo error, got: %v", err)
+	}
+}
+
+// Test_readAppLabels_prefers_english_bundle verifies the default language's
+// bundle is shipped as Custom Labels.
+func Test_readAppLabels_prefers_english_bundle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, appLabelsDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{
+		"de.json": `{"Greeting": "Hallo {0}"}`,
+		"en.json": `{"Greeting": "Hello {0}", "Farewell": "Bye & thanks"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, appLabelsDir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	labels, err := readAppLabels(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []customLabel{
+		{Name: "Farewell", Language: "en_US", Value: "Bye & thanks"},
+		{Name: "Greeting", Language: "en_US", Value: "Hello {0}"},
+	}
+	if !reflect.DeepEqual(labels, want) {
+		t.Fatalf("readAppLabels() = %v; want %v", labels, want)
+	}
+
+	files := forcecli.ForceMetadataFiles{"package.xml": []byte(buildPackageXML([]string{"App"}, "app", "APP", false, false))}
+	addCustomLabels(files, labels)
+	xml := string(files["labels/CustomLabels.labels"])
+	if !strings.Contains(xml, "<fullName>Farewell</fullName>") || !strings.Contains(xml, "<value>Bye &amp; thanks</value>") {
+		t.Errorf("unexpected CustomLabels file:\n%s", xml)
+	}
+	pkg := string(files["package.xml"])
+	if !strings.Contains(pkg, "<members>Greeting</members>\n    <name>CustomLabel</name>") || !strings.HasSuffix(pkg, "<version>58.0</version>\n</Package>") {
+		t.Errorf("expected the labels in package.xml, got:\n%s", pkg)
+	}
+}
+
+// Test_readAppLabels_rejects_invalid_names verifies names Salesforce would
+// reject fail before deploying.
+func Test_readAppLabels_rejects_invalid_names(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, appLabelsDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, appLabelsDir, "en_US.json"), []byte(`{"Bad__Name": "x"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readAppLabels(dir); err == nil {
+		t.Error("expected an error for a name with a double underscore")
+	}
+	if labels, err := readAppLabels(t.TempDir()); err != nil || labels != nil {
+		t.Errorf("expected no labels for an app without bundles, got %v, %v", labels, err)
+	}
+}
+
+// Test_withDeployedStamp_reuses_deployed_build verifies diff builds reuse the
+// deployed bundle's version, commit and build time but keep the local
+// compiler.
+func Test_withDeployedStamp_reuses_deployed_build(t *testing.T) {
+	local := bundleManifest{Version: "20250101000000", Commit: "def5678-dirty", BuiltAt: "2025-01-01T00:00:00Z", Compiler: compilerTinyGo}
+	deployed := bundleManifest{Parts: 2, Version: "20240102030405", Commit: "abc1234", BuiltAt: "2024-01-02T03:04:05Z"}
+	got := withDeployedStamp(local, deployed)
+	want := bundleManifest{Version: "20240102030405", Commit: "abc1234", BuiltAt: "2024-01-02T03:04:05Z", Compiler: compilerTinyGo}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withDeployedStamp = %+v; want %+v", got, want)
+	}
+}
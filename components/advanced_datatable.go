@@ -0,0 +1,672 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// SortDirection is the direction a data table column is sorted in.
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// DataTableSort identifies the column a data table is sorted by.
+// The zero value means the table is unsorted.
+type DataTableSort struct {
+	FieldName string
+	Direction SortDirection
+}
+
+// SelectionMode controls row selection in an AdvancedDataTable.
+type SelectionMode int
+
+const (
+	// SelectionNone renders no selection column.
+	SelectionNone SelectionMode = iota
+	// SelectionSingle renders a radio button per row.
+	SelectionSingle
+	// SelectionMulti renders a checkbox per row and a select-all checkbox.
+	SelectionMulti
+)
+
+// AdvancedDataTableProps configures an AdvancedDataTable. The table keeps no
+// state of its own: the app stores the sort, selection and column widths in
+// its model and passes them back in, updating them from the callbacks.
+type AdvancedDataTableProps struct {
+	Columns []DataTableColumn
+	Rows    []map[string]interface{}
+	// KeyField names the field that uniquely identifies each row; defaults to
+	// "Id". Inline editing needs rows keyed by Id, so columns are only
+	// editable when KeyField is empty or "Id".
+	KeyField string
+
+	// Sort is the current sort, shown on the matching column header.
+	Sort DataTableSort
+	// SelectionMode enables single or multi row selection.
+	SelectionMode SelectionMode
+	// SelectedKeys holds the KeyField values of the selected rows.
+	SelectedKeys []string
+	// ColumnWidths holds column widths in pixels by FieldName, overriding
+	// DataTableColumn.Width once the user resizes a column.
+	ColumnWidths map[string]int
+
+	// HasMore shows a "Load More" button (or enables infinite scroll) when
+	// more rows are available, e.g. while an api.QueryResult is not Done.
+	HasMore bool
+	// IsLoadingMore shows a spinner below the rows while the next page loads.
+	IsLoadingMore bool
+	// Height makes the table body scroll within a container of this CSS
+	// height (e.g. "400px").
+	Height string
+	// InfiniteScroll calls OnLoadMore as the user scrolls near the bottom of
+	// the container instead of showing a "Load More" button. Requires Height
+	// or Virtualize.
+	InfiniteScroll bool
+	// Virtualize renders only the rows in view, for tables with thousands of
+	// rows. It replaces Height with Virtualize.ViewportHeight.
+	Virtualize *VirtualScroll
+
+	// OnSort receives the new sort when a Sortable column header is clicked:
+	// ascending for a new column, toggling direction for the sorted column.
+	OnSort func(DataTableSort)
+	// OnRowSelection receives the full set of selected keys after a change.
+	OnRowSelection func(selectedKeys []string)
+	// OnLoadMore is called to fetch the next page of rows.
+	OnLoadMore func()
+	// OnColumnResize receives a column's new width in pixels. Columns are
+	// resizable only when it is set.
+	OnColumnResize func(fieldName string, width int)
+	// OnRowAction receives the action name and row from "action" columns.
+	OnRowAction func(action string, row map[string]interface{})
+
+	// EditingCell is the cell whose inline editor is open.
+	EditingCell DataTableCell
+	// DraftValues holds unsaved edits by row key, then field name. Edited
+	// cells show their draft value and the save bar appears while any exist.
+	DraftValues map[string]map[string]interface{}
+	// CellErrors holds save errors by row key, then field name. An error
+	// under the empty field name applies to the whole row.
+	CellErrors map[string]map[string]string
+	// IsSaving disables editing and shows a spinner on the Save button.
+	IsSaving bool
+	// LookupSearch is the search text typed into an open lookup editor.
+	LookupSearch string
+
+	// OnEditCell receives the cell to open the editor for, or the zero
+	// DataTableCell when the editor is closed. Columns are editable only
+	// when OnCellChange is set.
+	OnEditCell func(DataTableCell)
+	// OnCellChange receives each edit: a string for text, date, picklist and
+	// lookup columns, a float64 (or nil when cleared) for number columns and
+	// a bool for checkbox columns. See SetDraftValue.
+	OnCellChange func(cell DataTableCell, value interface{})
+	// OnLookupSearch receives the text typed into a lookup editor, e.g. to
+	// refresh the column's Options.
+	OnLookupSearch func(text string)
+	// OnSave receives the changed rows, each holding its Id plus its changed
+	// fields (see DraftChanges), for a composite update such as
+	// api.UpdateRecords.
+	OnSave func(changes []map[string]interface{})
+	// OnCancel is called when the user discards the drafts.
+	OnCancel func()
+}
+
+// loadMoreThreshold is how close, in pixels, the user must scroll to the bottom
+// of an infinite-scroll table before the next page is requested.
+const loadMoreThreshold = 100
+
+// AdvancedDataTable renders an SLDS advanced data table with sortable column
+// headers, single or multi row selection, resizable columns and a load-more
+// hook for paging through large result sets (see api.QueryPage and
+// api.QueryMore). All interaction is reported through the typed callbacks in
+// props.
+func AdvancedDataTable(props AdvancedDataTableProps) masc.ComponentOrHTML {
+	if len(props.Columns) == 0 {
+		return nil
+	}
+	keyField := props.KeyField
+	if keyField == "" {
+		keyField = "Id"
+	}
+	selected := make(map[string]bool, len(props.SelectedKeys))
+	for _, k := range props.SelectedKeys {
+		selected[k] = true
+	}
+
+	// Header row
+	var headCells []masc.MarkupOrChild
+	if props.SelectionMode != SelectionNone {
+		headCells = append(headCells, selectionHeaderCell(props, keyField, selected))
+	}
+	for _, col := range props.Columns {
+		headCells = append(headCells, advancedHeaderCell(props, col))
+	}
+	var headerRowArgs []masc.MarkupOrChild
+	headerRowArgs = append(headerRowArgs, masc.Markup(masc.Class("slds-line-height_reset")))
+	headerRowArgs = append(headerRowArgs, headCells...)
+
+	// Body rows
+	colspan := len(props.Columns)
+	if props.SelectionMode != SelectionNone {
+		colspan++
+	}
+	var bodyRows []masc.MarkupOrChild
+	r := virtualRange{End: len(props.Rows)}
+	if props.Virtualize != nil {
+		r = visibleRange(*props.Virtualize, len(props.Rows))
+		bodyRows = append(bodyRows, spacerRow(r.Before, colspan))
+	}
+	for rowIndex := r.Start; rowIndex < r.End; rowIndex++ {
+		row := props.Rows[rowIndex]
+		bodyRows = append(bodyRows, advancedBodyRow(props, row, rowIndex, rowKey(row, keyField), selected))
+	}
+	if props.Virtualize != nil {
+		bodyRows = append(bodyRows, spacerRow(r.After, colspan))
+	}
+	if props.IsLoadingMore {
+		bodyRows = append(bodyRows, elem.TableRow(
+			elem.TableData(
+				masc.Markup(masc.Attribute("colspan", colspan)),
+				CenteredSpinner("small"),
+			),
+		))
+	}
+
+	tableMarkup := []masc.Applyer{
+		masc.Class("slds-table", "slds-table_cell-buffer", "slds-table_bordered", "slds-table_fixed-layout"),
+		masc.Attribute("role", "grid"),
+	}
+	if props.OnColumnResize != nil {
+		tableMarkup = append(tableMarkup, masc.Class("slds-table_resizable-cols"))
+	}
+	if props.SelectionMode == SelectionMulti {
+		tableMarkup = append(tableMarkup, masc.Attribute("aria-multiselectable", "true"))
+	}
+	table := elem.Table(
+		masc.Markup(tableMarkup...),
+		elem.TableHead(elem.TableRow(headerRowArgs...)),
+		elem.TableBody(bodyRows...),
+	)
+
+	// Scroll container
+	scrolls := props.Height != "" || props.Virtualize != nil
+	var loadMoreOnScroll func(*masc.Event)
+	if props.InfiniteScroll && scrolls {
+		loadMoreOnScroll = func(e *masc.Event) {
+			if !props.HasMore || props.IsLoadingMore || props.OnLoadMore == nil {
+				return
+			}
+			t := e.Target
+			if t.Get("scrollTop").Int()+t.Get("clientHeight").Int() >= t.Get("scrollHeight").Int()-loadMoreThreshold {
+				props.OnLoadMore()
+			}
+		}
+	}
+	var container masc.ComponentOrHTML
+	switch {
+	case props.Virtualize != nil:
+		container = virtualScrollContainer(*props.Virtualize, len(props.Rows), r, loadMoreOnScroll, table)
+	case props.Height != "":
+		containerMarkup := []masc.Applyer{
+			masc.Class("slds-scrollable"),
+			masc.Style("height", props.Height),
+		}
+		if loadMoreOnScroll != nil {
+			containerMarkup = append(containerMarkup, event.Scroll(loadMoreOnScroll))
+		}
+		container = elem.Div(masc.Markup(containerMarkup...), table)
+	default:
+		container = elem.Div(masc.Markup(masc.Class("slds-scrollable_x")), table)
+	}
+
+	var footer masc.ComponentOrHTML
+	if hasDrafts(props.DraftValues) {
+		footer = saveBar(props)
+	}
+
+	showButton := props.HasMore && !props.IsLoadingMore && props.OnLoadMore != nil && loadMoreOnScroll == nil
+	return elem.Div(
+		container,
+		masc.If(showButton,
+			elem.Div(
+				masc.Markup(masc.Class("slds-align_absolute-center", "slds-p-vertical_small")),
+				Button(i18n.T("Thunder_Load_More"), VariantNeutral, func(*masc.Event) { props.OnLoadMore() }),
+			),
+		),
+		footer,
+	)
+}
+
+// selectionHeaderCell renders the header of the selection column: a select-all
+// checkbox for multi selection, or assistive text for single selection.
+func selectionHeaderCell(props AdvancedDataTableProps, keyField string, selected map[string]bool) masc.ComponentOrHTML {
+	markup := masc.Markup(
+		masc.Property("scope", "col"),
+		masc.Class("slds-text-align_right"),
+		masc.Style("width", "3.25rem"),
+	)
+	if props.SelectionMode == SelectionSingle {
+		return elem.TableHeader(markup,
+			elem.Span(
+				masc.Markup(masc.Class("slds-assistive-text")),
+				masc.Text(i18n.T("Thunder_Choose_A_Row")),
+			),
+		)
+	}
+	allSelected := len(props.Rows) > 0
+	for _, row := range props.Rows {
+		if !selected[rowKey(row, keyField)] {
+			allSelected = false
+			break
+		}
+	}
+	return elem.TableHeader(markup,
+		selectionCheckbox(i18n.T("Thunder_Select_All_Rows"), allSelected, func(*masc.Event) {
+			if props.OnRowSelection == nil {
+				return
+			}
+			if allSelected {
+				props.OnRowSelection([]string{})
+				return
+			}
+			keys := make([]string, 0, len(props.Rows))
+			for _, row := range props.Rows {
+				keys = append(keys, rowKey(row, keyField))
+			}
+			props.OnRowSelection(keys)
+		}),
+	)
+}
+
+// advancedHeaderCell renders a column header with its sort control and resize
+// handle.
+func advancedHeaderCell(props AdvancedDataTableProps, col DataTableColumn) masc.ComponentOrHTML {
+	if col.Type == "action" {
+		return elem.TableHeader(
+			masc.Markup(
+				masc.Property("scope", "col"),
+				masc.Class("slds-text-align_right"),
+				masc.Style("width", "3.25rem"),
+			),
+			elem.Div(
+				masc.Markup(masc.Class("slds-truncate")),
+				elem.Span(
+					masc.Markup(masc.Class("slds-assistive-text")),
+					masc.Text(i18n.T("Thunder_Actions")),
+				),
+			),
+		)
+	}
+
+	sortable := col.Sortable && props.OnSort != nil
+	sorted := sortable && props.Sort.FieldName == col.FieldName && props.Sort.Direction != ""
+	markup := []masc.Applyer{
+		masc.Property("scope", "col"),
+		masc.Attribute("aria-label", col.Label),
+	}
+	if width := columnWidth(props, col); width != "" {
+		markup = append(markup, masc.Style("width", width))
+	}
+	if props.OnColumnResize != nil {
+		markup = append(markup, masc.Class("slds-is-resizable"))
+	}
+	if sortable {
+		ariaSort := "none"
+		markup = append(markup, masc.Class("slds-is-sortable"))
+		if sorted {
+			ariaSort = "ascending"
+			markup = append(markup, masc.Class("slds-is-sorted", "slds-is-sorted_asc"))
+			if props.Sort.Direction == SortDescending {
+				ariaSort = "descending"
+				markup = append(markup, masc.Class("slds-is-sorted_desc"))
+			}
+		}
+		markup = append(markup, masc.Attribute("aria-sort", ariaSort))
+	}
+
+	label := elem.Span(
+		masc.Markup(
+			masc.Class("slds-truncate"),
+			masc.Property("title", col.Label),
+		),
+		masc.Text(col.Label),
+	)
+	var content masc.ComponentOrHTML
+	if sortable {
+		fieldName := col.FieldName
+		content = elem.Anchor(
+			masc.Markup(
+				masc.Class("slds-th__action", "slds-text-link_reset"),
+				masc.Attribute("href", "javascript:void(0);"),
+				masc.Attribute("role", "button"),
+				masc.Attribute("tabindex", "0"),
+				event.Click(func(*masc.Event) {
+					props.OnSort(nextSort(props.Sort, fieldName))
+				}).PreventDefault(),
+			),
+			elem.Span(
+				masc.Markup(masc.Class("slds-assistive-text")),
+				masc.Text(i18n.T("Thunder_Sort_By")+" "),
+			),
+			elem.Div(
+				masc.Markup(masc.Class("slds-grid", "slds-grid_vertical-align-center", "slds-has-flexi-truncate")),
+				label,
+				sortIcon(sorted && props.Sort.Direction == SortDescending),
+			),
+		)
+	} else {
+		content = elem.Div(
+			masc.Markup(masc.Class("slds-th__action")),
+			label,
+		)
+	}
+
+	return elem.TableHeader(
+		masc.Markup(markup...),
+		content,
+		masc.If(props.OnColumnResize != nil, columnResizer(props, col)),
+	)
+}
+
+// sortIcon renders the sort direction arrow shown on sortable column headers.
+func sortIcon(descending bool) masc.ComponentOrHTML {
+	name := "arrowup"
+	if descending {
+		name = "arrowdown"
+	}
+	return elem.Span(
+		masc.Markup(masc.Class("slds-is-sortable__icon")),
+		Icon(UtilityIcon, name, IconXSmall),
+	)
+}
+
+// columnResizer renders the SLDS resize handle for a column. The handle can be
+// dragged with the pointer; the visually hidden range input resizes the
+// column from the keyboard.
+func columnResizer(props AdvancedDataTableProps, col DataTableColumn) masc.ComponentOrHTML {
+	fieldName := col.FieldName
+	width := props.ColumnWidths[fieldName]
+	if width == 0 {
+		width = 200
+	}
+	return elem.Div(
+		masc.Markup(masc.Class("slds-resizable")),
+		elem.Input(
+			masc.Markup(
+				masc.Class("slds-resizable__input", "slds-assistive-text"),
+				masc.Property("type", "range"),
+				masc.Property("min", "20"),
+				masc.Property("max", "1000"),
+				masc.Property("value", strconv.Itoa(width)),
+				masc.Attribute("aria-label", i18n.T("Thunder_Column_Width", col.Label)),
+				event.Input(func(e *masc.Event) {
+					if w, err := strconv.Atoi(e.Target.Get("value").String()); err == nil {
+						props.OnColumnResize(fieldName, w)
+					}
+				}),
+			),
+		),
+		elem.Span(
+			masc.Markup(
+				masc.Class("slds-resizable__handle"),
+				event.MouseDown(func(e *masc.Event) {
+					beginColumnResize(e, func(w int) {
+						props.OnColumnResize(fieldName, w)
+					})
+				}).PreventDefault(),
+			),
+			elem.Span(masc.Markup(masc.Class("slds-resizable__divider"))),
+		),
+	)
+}
+
+// columnWidth returns the CSS width for a column: the user-resized width when
+// there is one, otherwise the column's configured Width.
+func columnWidth(props AdvancedDataTableProps, col DataTableColumn) string {
+	if w, ok := props.ColumnWidths[col.FieldName]; ok && w > 0 {
+		return fmt.Sprintf("%dpx", w)
+	}
+	return col.Width
+}
+
+// advancedBodyRow renders one data row with its selection control.
+func advancedBodyRow(props AdvancedDataTableProps, row map[string]interface{}, rowIndex int, key string, selected map[string]bool) masc.ComponentOrHTML {
+	isSelected := selected[key]
+	rowMarkup := []masc.Applyer{
+		masc.Class("slds-hint-parent"),
+		masc.Attribute("aria-selected", strconv.FormatBool(isSelected)),
+		virtualIndex(rowIndex),
+	}
+	if isSelected {
+		rowMarkup = append(rowMarkup, masc.Class("slds-is-selected"))
+	}
+	if rowErr := props.CellErrors[key][""]; rowErr != "" {
+		rowMarkup = append(rowMarkup, masc.Class("slds-has-error"), masc.Property("title", rowErr))
+	}
+	var cells []masc.MarkupOrChild
+	cells = append(cells, masc.Markup(rowMarkup...))
+
+	switch props.SelectionMode {
+	case SelectionMulti:
+		cells = append(cells, elem.TableData(
+			masc.Markup(masc.Class("slds-text-align_right"), masc.Attribute("role", "gridcell")),
+			selectionCheckbox(i18n.T("Thunder_Select_Item", rowIndex+1), isSelected, func(*masc.Event) {
+				if props.OnRowSelection != nil {
+					props.OnRowSelection(toggleKey(props.SelectedKeys, key))
+				}
+			}),
+		))
+	case SelectionSingle:
+		cells = append(cells, elem.TableData(
+			masc.Markup(masc.Class("slds-text-align_right"), masc.Attribute("role", "gridcell")),
+			elem.Label(
+				masc.Markup(masc.Class("slds-radio")),
+				elem.Input(
+					masc.Markup(
+						masc.Property("type", "radio"),
+						masc.Property("checked", isSelected),
+						event.Change(func(*masc.Event) {
+							if props.OnRowSelection != nil {
+								props.OnRowSelection([]string{key})
+							}
+						}),
+					),
+				),
+				elem.Span(masc.Markup(masc.Class("slds-radio_faux"))),
+				elem.Span(
+					masc.Markup(masc.Class("slds-form-element__label", "slds-assistive-text")),
+					masc.Text(i18n.T("Thunder_Choose_Item", rowIndex+1)),
+				),
+			),
+		))
+	}
+
+	for colIndex, col := range props.Columns {
+		if col.Type == "action" {
+			cells = append(cells, renderMenuCell(col, row, props.OnRowAction, rowIndex))
+			continue
+		}
+		if col.Editable && inlineEditable(props) {
+			cells = append(cells, editableCell(props, col, row, key, colIndex == 0))
+			continue
+		}
+		text := displayValue(col, row[col.FieldName])
+		content := elem.Div(
+			masc.Markup(
+				masc.Class("slds-truncate"),
+				masc.Property("title", text),
+			),
+			masc.Text(text),
+		)
+		if colIndex == 0 {
+			cells = append(cells, elem.TableHeader(
+				masc.Markup(masc.Property("scope", "row"), masc.Data("label", col.Label)),
+				content,
+			))
+		} else {
+			cells = append(cells, elem.TableData(
+				masc.Markup(masc.Data("label", col.Label), masc.Attribute("role", "gridcell")),
+				content,
+			))
+		}
+	}
+	return elem.TableRow(cells...)
+}
+
+// selectionCheckbox renders a row selection checkbox with an assistive label.
+func selectionCheckbox(label string, checked bool, onChange func(*masc.Event)) masc.ComponentOrHTML {
+	return elem.Label(
+		masc.Markup(masc.Class("slds-checkbox")),
+		elem.Input(
+			masc.Markup(
+				masc.Property("type", "checkbox"),
+				masc.Property("checked", checked),
+				event.Change(onChange),
+			),
+		),
+		elem.Span(masc.Markup(masc.Class("slds-checkbox_faux"))),
+		elem.Span(
+			masc.Markup(masc.Class("slds-form-element__label", "slds-assistive-text")),
+			masc.Text(label),
+		),
+	)
+}
+
+// formatCellValue renders a field value as cell text.
+func formatCellValue(col DataTableColumn, v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		if col.Type == "boolean" {
+			if val {
+				return i18n.T("Thunder_Yes")
+			}
+			return i18n.T("Thunder_No")
+		}
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// rowKey returns the value identifying a row.
+func rowKey(row map[string]interface{}, keyField string) string {
+	if v, ok := row[keyField]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// toggleKey returns keys with key added, or removed if it was present.
+func toggleKey(keys []string, key string) []string {
+	out := make([]string, 0, len(keys)+1)
+	found := false
+	for _, k := range keys {
+		if k == key {
+			found = true
+			continue
+		}
+		out = append(out, k)
+	}
+	if !found {
+		out = append(out, key)
+	}
+	return out
+}
+
+// nextSort returns the sort after clicking fieldName's header: ascending for a
+// newly sorted column, otherwise the opposite of the current direction.
+func nextSort(current DataTableSort, fieldName string) DataTableSort {
+	if current.FieldName == fieldName && current.Direction == SortAscending {
+		return DataTableSort{FieldName: fieldName, Direction: SortDescending}
+	}
+	return DataTableSort{FieldName: fieldName, Direction: SortAscending}
+}
+
+// SortRows returns a copy of rows sorted by the given sort, for tables sorted
+// on the client rather than with a SOQL ORDER BY. Numbers compare
+// numerically, strings case-insensitively and false before true; empty values
+// sort last in either direction. Equal rows keep their order.
+func SortRows(rows []map[string]interface{}, s DataTableSort) []map[string]interface{} {
+	sorted := make([]map[string]interface{}, len(rows))
+	copy(sorted, rows)
+	if s.FieldName == "" {
+		return sorted
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i][s.FieldName], sorted[j][s.FieldName]
+		if isEmptyValue(a) || isEmptyValue(b) {
+			return !isEmptyValue(a) && isEmptyValue(b)
+		}
+		c := compareValues(a, b)
+		if s.Direction == SortDescending {
+			return c > 0
+		}
+		return c < 0
+	})
+	return sorted
+}
+
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && s == ""
+}
+
+// compareValues orders two non-empty field values, returning -1, 0 or 1.
+func compareValues(a, b interface{}) int {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(strings.ToLower(fmt.Sprint(a)), strings.ToLower(fmt.Sprint(b)))
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
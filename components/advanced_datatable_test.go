@@ -0,0 +1,200 @@
+package components
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+)
+
+var advancedColumns = []DataTableColumn{
+	{Label: "Name", FieldName: "Name", Sortable: true},
+	{Label: "Amount", FieldName: "Amount", Sortable: true},
+	{Label: "Stage", FieldName: "StageName"},
+}
+
+var advancedRows = []map[string]interface{}{
+	{"Id": "001", "Name": "beta", "Amount": 20.0, "StageName": "Closed"},
+	{"Id": "002", "Name": "Alpha", "Amount": 5.0, "StageName": "Open"},
+	{"Id": "003", "Name": "gamma", "Amount": nil, "StageName": "Open"},
+}
+
+// TestAdvancedDataTableMarksSortedColumn verifies the sorted column header
+// carries the SLDS sort classes and aria-sort, and unsortable columns do not.
+func TestAdvancedDataTableMarksSortedColumn(t *testing.T) {
+	win := renderComponent(t, AdvancedDataTable(AdvancedDataTableProps{
+		Columns: advancedColumns,
+		Rows:    advancedRows,
+		Sort:    DataTableSort{FieldName: "Amount", Direction: SortDescending},
+		OnSort:  func(DataTableSort) {},
+	}))
+
+	sorted, err := win.Document().QuerySelector("th.slds-is-sorted_desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sorted == nil {
+		t.Fatal("expected a descending sorted header, got none")
+	}
+	if got, _ := sorted.GetAttribute("aria-label"); got != "Amount" {
+		t.Errorf("expected Amount to be sorted, got %q", got)
+	}
+	if got, _ := sorted.GetAttribute("aria-sort"); got != "descending" {
+		t.Errorf("expected aria-sort descending, got %q", got)
+	}
+	icon, err := sorted.QuerySelector(".slds-is-sortable__icon .slds-icon-utility-arrowdown svg.slds-icon_x-small")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if icon == nil || !strings.Contains(icon.OuterHTML(), `href="#arrowdown"`) {
+		t.Error("expected the arrowdown sort icon from the utility sprite")
+	}
+
+	sortable, err := win.Document().QuerySelectorAll("th.slds-is-sortable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sortable.Length(); got != 2 {
+		t.Errorf("expected 2 sortable headers, got %d", got)
+	}
+}
+
+// TestAdvancedDataTableSortClick verifies clicking a sortable header reports
+// the toggled sort.
+func TestAdvancedDataTableSortClick(t *testing.T) {
+	var got DataTableSort
+	win := renderComponent(t, AdvancedDataTable(AdvancedDataTableProps{
+		Columns: advancedColumns,
+		Rows:    advancedRows,
+		Sort:    DataTableSort{FieldName: "Name", Direction: SortAscending},
+		OnSort:  func(s DataTableSort) { got = s },
+	}))
+
+	link, err := win.Document().QuerySelector("th[aria-label=Name] a.slds-th__action")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link == nil {
+		t.Fatal("expected a sort link in the Name header, got none")
+	}
+	link.(html.HTMLElement).Click()
+
+	want := DataTableSort{FieldName: "Name", Direction: SortDescending}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+// TestAdvancedDataTableMultiSelection verifies selected rows are marked and a
+// select-all checkbox is rendered in the header.
+func TestAdvancedDataTableMultiSelection(t *testing.T) {
+	win := renderComponent(t, AdvancedDataTable(AdvancedDataTableProps{
+		Columns:        advancedColumns,
+		Rows:           advancedRows,
+		SelectionMode:  SelectionMulti,
+		SelectedKeys:   []string{"002"},
+		OnRowSelection: func([]string) {},
+	}))
+
+	selected, err := win.Document().QuerySelectorAll("tr.slds-is-selected")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := selected.Length(); got != 1 {
+		t.Errorf("expected 1 selected row, got %d", got)
+	}
+	selectAll, err := win.Document().QuerySelector("thead input[type=checkbox]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selectAll == nil {
+		t.Error("expected a select-all checkbox in the header, got none")
+	}
+}
+
+// TestAdvancedDataTableLoadMore verifies the Load More button is shown only
+// when more rows are available and not already loading.
+func TestAdvancedDataTableLoadMore(t *testing.T) {
+	props := AdvancedDataTableProps{
+		Columns:    advancedColumns,
+		Rows:       advancedRows,
+		HasMore:    true,
+		OnLoadMore: func() {},
+	}
+	win := renderComponent(t, AdvancedDataTable(props))
+	btn, err := win.Document().QuerySelector("button.slds-button_neutral")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if btn == nil {
+		t.Fatal("expected a Load More button, got none")
+	}
+
+	props.IsLoadingMore = true
+	win = renderComponent(t, AdvancedDataTable(props))
+	btn, err = win.Document().QuerySelector("button.slds-button_neutral")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if btn != nil {
+		t.Error("expected no Load More button while loading")
+	}
+}
+
+// TestNextSortToggles verifies header clicks sort ascending first and then
+// toggle direction.
+func TestNextSortToggles(t *testing.T) {
+	s := nextSort(DataTableSort{}, "Name")
+	if s != (DataTableSort{FieldName: "Name", Direction: SortAscending}) {
+		t.Errorf("expected ascending on first click, got %+v", s)
+	}
+	s = nextSort(s, "Name")
+	if s.Direction != SortDescending {
+		t.Errorf("expected descending on second click, got %+v", s)
+	}
+	s = nextSort(s, "Amount")
+	if s != (DataTableSort{FieldName: "Amount", Direction: SortAscending}) {
+		t.Errorf("expected ascending on a new column, got %+v", s)
+	}
+}
+
+// TestToggleKey verifies selection keys are added and removed.
+func TestToggleKey(t *testing.T) {
+	keys := toggleKey([]string{"a", "b"}, "c")
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Errorf("unexpected keys after add: %v", keys)
+	}
+	keys = toggleKey(keys, "a")
+	if !reflect.DeepEqual(keys, []string{"b", "c"}) {
+		t.Errorf("unexpected keys after remove: %v", keys)
+	}
+}
+
+// TestSortRows verifies client-side sorting of strings and numbers with empty
+// values last, without modifying the input.
+func TestSortRows(t *testing.T) {
+	ids := func(rows []map[string]interface{}) []string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, r["Id"].(string))
+		}
+		return out
+	}
+
+	got := ids(SortRows(advancedRows, DataTableSort{FieldName: "Name", Direction: SortAscending}))
+	if want := []string{"002", "001", "003"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("name asc: expected %v, got %v", want, got)
+	}
+	got = ids(SortRows(advancedRows, DataTableSort{FieldName: "Amount", Direction: SortDescending}))
+	if want := []string{"001", "002", "003"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("amount desc: expected %v, got %v", want, got)
+	}
+	got = ids(SortRows(advancedRows, DataTableSort{FieldName: "Amount", Direction: SortAscending}))
+	if want := []string{"002", "001", "003"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("amount asc: expected %v, got %v", want, got)
+	}
+	if advancedRows[0]["Id"] != "001" {
+		t.Error("SortRows modified its input")
+	}
+}
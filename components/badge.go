@@ -17,9 +17,12 @@
 package components
 
 import (
+	"html"
+
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // Badge renders an SLDS badge with the given label.
@@ -43,13 +46,13 @@ func Pill(label string, onRemove func(*masc.Event)) masc.ComponentOrHTML {
 		// Inline SVG for close icon plus assistive text (embedded path to avoid external sprite)
 		svgHTML := `<svg class="slds-button__icon slds-button__icon-small" aria-hidden="true" viewBox="0 0 24 24" fill="currentColor">` +
 			`<path d="M19 6.41L17.59 5 12 10.59 6.41 5 5 6.41 10.59 12 5 17.59 6.41 19 12 13.41 17.59 19 19 17.59 13.41 12z"/>` +
-			`</svg><span class="slds-assistive-text">Remove</span>`
+			`</svg><span class="slds-assistive-text">` + html.EscapeString(i18n.T("Thunder_Remove")) + `</span>`
 		args = append(args,
 			elem.Button(
 				masc.Markup(
 					masc.Class("slds-button", "slds-button_icon", "slds-button_icon-small", "slds-pill__remove"),
 					event.Click(onRemove),
-					masc.Property("title", "Remove"),
+					masc.Property("title", i18n.T("Thunder_Remove")),
 				),
 				masc.Markup(masc.UnsafeHTML(svgHTML)),
 			),
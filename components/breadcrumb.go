@@ -4,6 +4,7 @@ import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // BreadcrumbOption represents one breadcrumb item.
@@ -74,7 +75,7 @@ func Breadcrumb(opts []BreadcrumbOption) masc.ComponentOrHTML {
 		masc.Markup(
 			// SLDS nav wrapper for breadcrumbs
 			masc.Class("slds-breadcrumbs", "slds-m-bottom_medium"),
-			masc.Property("aria-label", "Breadcrumbs"),
+			masc.Property("aria-label", i18n.T("Thunder_Breadcrumbs")),
 		),
 		ol,
 	)
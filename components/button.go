@@ -4,6 +4,7 @@ import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // ButtonVariant defines the SLDS button style variant.
@@ -82,7 +83,7 @@ func LoadingButton(label string, variant ButtonVariant) masc.ComponentOrHTML {
 			masc.Markup(masc.Attribute("role", "status")),
 			elem.Span(
 				masc.Markup(masc.Class("slds-assistive-text")),
-				masc.Text("Loading"),
+				masc.Text(i18n.T("Thunder_Loading")),
 			),
 			elem.Div(masc.Markup(masc.Class("slds-spinner__dot-a"))),
 			elem.Div(masc.Markup(masc.Class("slds-spinner__dot-b"))),
@@ -150,14 +151,14 @@ func NavigationButtons(
 	showPrevious bool,
 ) masc.ComponentOrHTML {
 	buttons := []masc.ComponentOrHTML{
-		Button("Cancel", VariantNeutral, onCancel),
+		Button(i18n.T("Thunder_Cancel"), VariantNeutral, onCancel),
 	}
 
 	if showPrevious {
-		buttons = append(buttons, Button("Previous", VariantNeutral, onPrevious))
+		buttons = append(buttons, Button(i18n.T("Thunder_Previous"), VariantNeutral, onPrevious))
 	}
 
-	buttons = append(buttons, Button("Next", VariantBrand, onNext))
+	buttons = append(buttons, Button(i18n.T("Thunder_Next"), VariantBrand, onNext))
 
 	return ActionButtons(buttons...)
 }
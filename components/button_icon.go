@@ -0,0 +1,127 @@
+package components
+
+import (
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+)
+
+// ButtonIconVariant defines the SLDS style of a ButtonIcon.
+type ButtonIconVariant string
+
+const (
+	// ButtonIconBare is an icon without a border, the default.
+	ButtonIconBare ButtonIconVariant = ""
+	// ButtonIconContainer is a bare icon with a square, clickable area.
+	ButtonIconContainer ButtonIconVariant = "slds-button_icon-container"
+	// ButtonIconBorder is an icon with a border.
+	ButtonIconBorder ButtonIconVariant = "slds-button_icon-border"
+	// ButtonIconBorderFilled is an icon with a border and a white background.
+	ButtonIconBorderFilled ButtonIconVariant = "slds-button_icon-border-filled"
+	// ButtonIconBrand is an icon on the brand color.
+	ButtonIconBrand ButtonIconVariant = "slds-button_icon-brand"
+	// ButtonIconInverse is an icon for dark backgrounds.
+	ButtonIconInverse ButtonIconVariant = "slds-button_icon-inverse"
+)
+
+// ButtonIconProps configures a ButtonIcon.
+type ButtonIconProps struct {
+	// IconName is the icon in Lightning's "category:name" form, e.g.
+	// "utility:settings". A name without a category is a utility icon.
+	IconName string
+	Variant  ButtonIconVariant
+	// Size is the size of the button: IconXXSmall to IconLarge, with
+	// IconMedium the default. Bordered variants support IconXXSmall,
+	// IconXSmall and IconSmall; bare ones IconXSmall, IconSmall and IconLarge.
+	Size IconSize
+	// AssistiveText describes the button to screen readers and is shown as
+	// its title when there is no Tooltip. It defaults to Tooltip.
+	AssistiveText string
+	// Tooltip, if set, is shown above the button while it is hovered or
+	// focused.
+	Tooltip  string
+	Disabled bool
+	OnClick  func()
+}
+
+// ButtonIcon renders an SLDS icon button with an optional tooltip.
+func ButtonIcon(props ButtonIconProps) masc.ComponentOrHTML {
+	category, name, ok := ParseIconName(props.IconName)
+	if !ok {
+		category, name = UtilityIcon, props.IconName
+	}
+
+	buttonClasses := []string{"slds-button", "slds-button_icon"}
+	if props.Variant != ButtonIconBare {
+		buttonClasses = append(buttonClasses, string(props.Variant))
+	}
+	svgClasses := []string{"slds-button__icon"}
+	if props.Size != "" && props.Size != IconMedium {
+		if props.Variant == ButtonIconBare || props.Variant == ButtonIconContainer {
+			svgClasses = append(svgClasses, "slds-button__icon_"+string(props.Size))
+		} else {
+			buttonClasses = append(buttonClasses, "slds-button_icon-"+string(props.Size))
+		}
+	}
+	if props.Variant == ButtonIconInverse {
+		svgClasses = append(svgClasses, "slds-button__icon_inverse")
+	}
+
+	assistive := props.AssistiveText
+	if assistive == "" {
+		assistive = props.Tooltip
+	}
+	markup := []masc.Applyer{
+		masc.Class(buttonClasses...),
+		masc.Attribute("type", "button"),
+	}
+	if props.Tooltip == "" && assistive != "" {
+		markup = append(markup, masc.Property("title", assistive))
+	}
+	if props.Disabled {
+		markup = append(markup, masc.Attribute("disabled", "true"))
+	} else if props.OnClick != nil {
+		onClick := props.OnClick
+		markup = append(markup, event.Click(func(*masc.Event) { onClick() }))
+	}
+
+	button := elem.Button(
+		masc.Markup(markup...),
+		elem.Span(masc.Markup(masc.UnsafeHTML(iconSVG(svgClasses, category, name)))),
+		elem.Span(
+			masc.Markup(masc.Class("slds-assistive-text")),
+			masc.Text(assistive),
+		),
+	)
+	if props.Tooltip == "" {
+		return button
+	}
+
+	return elem.Div(
+		masc.Markup(
+			masc.Class("slds-is-relative", "slds-show_inline-block"),
+			masc.Data("tooltip", props.Tooltip),
+			event.MouseEnter(func(e *masc.Event) { toggleTooltip(e, true) }),
+			event.MouseLeave(func(e *masc.Event) { toggleTooltip(e, false) }),
+			event.FocusIn(func(e *masc.Event) { toggleTooltip(e, true) }),
+			event.FocusOut(func(e *masc.Event) { toggleTooltip(e, false) }),
+		),
+		button,
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-popover", "slds-popover_tooltip", "slds-nubbin_bottom", "slds-fall-into-ground"),
+				masc.Attribute("role", "tooltip"),
+				masc.Style("position", "absolute"),
+				masc.Style("bottom", "calc(100% + 0.75rem)"),
+				masc.Style("left", "50%"),
+				masc.Style("transform", "translateX(-50%)"),
+				masc.Style("width", "max-content"),
+				masc.Style("max-width", "20rem"),
+			),
+			elem.Div(
+				masc.Markup(masc.Class("slds-popover__body")),
+				masc.Text(props.Tooltip),
+			),
+		),
+	)
+}
@@ -0,0 +1,28 @@
+//go:build js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+// toggleTooltip shows or hides the tooltip of the ButtonIcon an event came
+// from. It is switched in the DOM so hovering needs no round trip through
+// the app.
+func toggleTooltip(e *masc.Event, show bool) {
+	if e == nil || !e.Target.Truthy() {
+		return
+	}
+	trigger := e.Target.Call("closest", "[data-tooltip]")
+	if !trigger.Truthy() {
+		return
+	}
+	tooltip := trigger.Call("querySelector", "[role=tooltip]")
+	if !tooltip.Truthy() {
+		return
+	}
+	classList := tooltip.Get("classList")
+	if show {
+		classList.Call("replace", "slds-fall-into-ground", "slds-rise-from-ground")
+	} else {
+		classList.Call("replace", "slds-rise-from-ground", "slds-fall-into-ground")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+// toggleTooltip is a no-op outside the browser, where there is nothing to
+// hover.
+func toggleTooltip(e *masc.Event, show bool) {}
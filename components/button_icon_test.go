@@ -0,0 +1,65 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+)
+
+// TestButtonIconClick verifies the button renders its icon and assistive
+// text and calls OnClick.
+func TestButtonIconClick(t *testing.T) {
+	clicked := false
+	win := renderComponent(t, ButtonIcon(ButtonIconProps{
+		IconName:      "utility:settings",
+		Variant:       ButtonIconBorderFilled,
+		Size:          IconXSmall,
+		AssistiveText: "Settings",
+		OnClick:       func() { clicked = true },
+	}))
+	button := querySelector(t, win, "button.slds-button_icon.slds-button_icon-border-filled.slds-button_icon-x-small")
+	if button == nil {
+		t.Fatal("expected a bordered icon button")
+	}
+	if title, _ := button.GetAttribute("title"); title != "Settings" {
+		t.Errorf("expected title Settings, got %q", title)
+	}
+	svg := querySelector(t, win, "svg.slds-button__icon")
+	if svg == nil || !strings.Contains(svg.OuterHTML(), `href="#settings"`) {
+		t.Error("expected the settings icon from the utility sprite")
+	}
+	if text := querySelector(t, win, ".slds-assistive-text"); text == nil || text.TextContent() != "Settings" {
+		t.Error("expected assistive text")
+	}
+	button.(html.HTMLElement).Click()
+	if !clicked {
+		t.Error("expected OnClick to be called")
+	}
+}
+
+// TestButtonIconTooltip verifies a tooltip is rendered hidden next to the
+// button and describes it to screen readers.
+func TestButtonIconTooltip(t *testing.T) {
+	win := renderComponent(t, ButtonIcon(ButtonIconProps{
+		IconName: "close",
+		Tooltip:  "Close this window",
+		Disabled: true,
+		OnClick:  func() { t.Error("disabled button should not be clickable") },
+	}))
+	tooltip := querySelector(t, win, "[data-tooltip] [role=tooltip].slds-popover_tooltip.slds-fall-into-ground")
+	if tooltip == nil || tooltip.TextContent() != "Close this window" {
+		t.Fatal("expected a hidden tooltip")
+	}
+	button := querySelector(t, win, "[data-tooltip] button")
+	if _, ok := button.GetAttribute("disabled"); !ok {
+		t.Error("expected the button to be disabled")
+	}
+	if _, ok := button.GetAttribute("title"); ok {
+		t.Error("expected no title alongside the tooltip")
+	}
+	if text := querySelector(t, win, "button .slds-assistive-text"); text.TextContent() != "Close this window" {
+		t.Errorf("expected the tooltip as assistive text, got %q", text.TextContent())
+	}
+	button.(html.HTMLElement).Click()
+}
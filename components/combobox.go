@@ -0,0 +1,384 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// ComboboxOption is one option of a Combobox.
+type ComboboxOption struct {
+	Label string
+	Value string
+	// Meta is secondary text shown beneath the label.
+	Meta string
+	// Icon is an SLDS icon shown beside the label, as "category:name"
+	// (e.g. "standard:account" or "utility:user").
+	Icon string
+	// Group places the option under a group heading. Options of a group
+	// should be adjacent.
+	Group string
+	// Disabled options are shown but cannot be chosen.
+	Disabled bool
+}
+
+// ComboboxProps configures a Combobox.
+type ComboboxProps struct {
+	// ID prefixes the ids of the listbox and its options, which keyboard
+	// navigation refers to. Defaults to one unique to the combobox.
+	ID          string
+	Label       string
+	Options     []ComboboxOption
+	Placeholder string
+	// ReadOnly renders a select-only combobox: the input shows the selected
+	// option and clicking it lists every option. Otherwise the combobox
+	// autocompletes: typing filters the options by label.
+	ReadOnly bool
+	// Value is the selected option's value.
+	Value string
+	// SearchText is the text typed into an autocomplete combobox.
+	SearchText string
+	// AllowCreate offers to add the typed text as a new value when it
+	// matches no option.
+	AllowCreate bool
+	// Validation holds the required flag and error state.
+	Validation ValidationState
+
+	// OnInput receives the text typed into an autocomplete combobox.
+	OnInput func(text string)
+	// OnSelect receives the value of the chosen option.
+	OnSelect func(value string)
+	// OnCreate receives the typed text when the user adds it as a new value.
+	OnCreate func(text string)
+	// OnEscape is called when Escape closes the list, e.g. to restore the
+	// search text to the selected option's label.
+	OnEscape func()
+}
+
+// Combobox renders an SLDS combobox, in read-only or autocomplete form, with
+// option groups, icons and meta text. The list is navigated with the arrow,
+// Home and End keys, following the ARIA active-descendant pattern; Enter
+// chooses the active option (or adds the typed text when AllowCreate is
+// set) and Escape closes the list.
+func Combobox(props ComboboxProps) masc.ComponentOrHTML {
+	return &comboboxComponent{Props: props}
+}
+
+// comboboxIDs numbers comboboxes without an ID so each gets unique element
+// ids.
+var comboboxIDs atomic.Int64
+
+// comboboxComponent is the implementation behind Combobox. It is a component
+// so its generated id, and whether the list of a read-only combobox is open,
+// survive re-renders.
+type comboboxComponent struct {
+	masc.Core
+
+	Props ComboboxProps `masc:"prop"`
+
+	id       string
+	listOpen bool
+}
+
+func (c *comboboxComponent) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	props := c.Props
+	if c.id == "" {
+		c.id = fmt.Sprintf("combobox-%d", comboboxIDs.Add(1))
+	}
+	id := props.ID
+	if id == "" {
+		id = c.id
+	}
+	listboxID := id + "-listbox"
+
+	var selected *ComboboxOption
+	for i := range props.Options {
+		if props.Options[i].Value == props.Value {
+			selected = &props.Options[i]
+			break
+		}
+	}
+
+	// Options to list, and whether to offer creating a new value
+	options := props.Options
+	text := strings.TrimSpace(props.SearchText)
+	offerCreate := false
+	if !props.ReadOnly {
+		options = filterComboboxOptions(props.Options, text)
+		offerCreate = props.AllowCreate && text != "" && !hasComboboxLabel(props.Options, text)
+	}
+	open := !props.ReadOnly && text != "" && (len(options) > 0 || offerCreate)
+	if props.ReadOnly {
+		open = c.listOpen
+	}
+	setOpen := func(open bool) { c.listOpen = open }
+
+	choose := func(value string) func(*masc.Event) {
+		return func(e *masc.Event) {
+			c.listOpen = false
+			closeComboboxList(e)
+			if props.OnSelect != nil {
+				props.OnSelect(value)
+			}
+		}
+	}
+	create := func() {
+		if offerCreate && props.OnCreate != nil {
+			props.OnCreate(text)
+		}
+	}
+
+	// Build listbox items, with a heading before each group
+	items := []masc.MarkupOrChild{
+		masc.Markup(
+			masc.Class("slds-listbox", "slds-listbox_vertical"),
+			masc.Attribute("role", "presentation"),
+		),
+	}
+	group := ""
+	for i, opt := range options {
+		if opt.Group != "" && opt.Group != group {
+			items = append(items, comboboxGroupHeading(opt.Group))
+		}
+		group = opt.Group
+		items = append(items, comboboxOption(fmt.Sprintf("%s-option-%d", id, i), opt, opt.Value == props.Value, choose(opt.Value)))
+	}
+	if offerCreate {
+		items = append(items, comboboxOption(id+"-option-new", ComboboxOption{
+			Label: i18n.T("Thunder_Add_Value", text),
+			Icon:  "utility:add",
+		}, false, func(e *masc.Event) {
+			c.listOpen = false
+			closeComboboxList(e)
+			create()
+		}))
+	}
+
+	// Build the input
+	inputValue := props.SearchText
+	autocomplete := "list"
+	if props.ReadOnly {
+		inputValue = ""
+		if selected != nil {
+			inputValue = selected.Label
+		}
+		autocomplete = "none"
+	}
+	placeholder := props.Placeholder
+	if placeholder == "" {
+		placeholder = props.Validation.Placeholder
+	}
+	if placeholder == "" && props.ReadOnly {
+		placeholder = i18n.T("Thunder_Select_An_Option")
+	}
+	inputMarkup := []masc.Applyer{
+		masc.Class("slds-input", "slds-combobox__input"),
+		masc.Property("type", "text"),
+		masc.Property("value", inputValue),
+		masc.Property("placeholder", placeholder),
+		masc.Property("required", props.Validation.Required),
+		masc.Attribute("role", "combobox"),
+		masc.Attribute("autocomplete", "off"),
+		masc.Attribute("aria-autocomplete", autocomplete),
+		masc.Attribute("aria-controls", listboxID),
+		masc.Attribute("aria-expanded", strconv.FormatBool(open)),
+		event.KeyDown(func(e *masc.Event) {
+			comboboxKeyDown(e, setOpen, create, props.OnEscape)
+		}),
+	}
+	if props.ReadOnly {
+		inputMarkup = append(inputMarkup,
+			masc.Property("readOnly", true),
+			event.Click(func(e *masc.Event) {
+				c.listOpen = !c.listOpen
+				setComboboxListOpen(e, c.listOpen)
+			}),
+		)
+	} else {
+		inputMarkup = append(inputMarkup, event.Input(func(e *masc.Event) {
+			if props.OnInput != nil {
+				props.OnInput(e.Target.Get("value").String())
+			}
+		}))
+	}
+	icon := Icon(UtilityIcon, "search", IconSmall)
+	if props.ReadOnly {
+		icon = Icon(UtilityIcon, "down", IconSmall)
+	}
+
+	comboClasses := []string{"slds-combobox", "slds-dropdown-trigger", "slds-dropdown-trigger_click"}
+	if open {
+		comboClasses = append(comboClasses, "slds-is-open")
+	}
+	control := elem.Div(
+		masc.Markup(masc.Class("slds-combobox_container")),
+		elem.Div(
+			masc.Markup(masc.Class(comboClasses...)),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-combobox__form-element", "slds-input-has-icon", "slds-input-has-icon_right"),
+					masc.Attribute("role", "none"),
+				),
+				elem.Input(masc.Markup(inputMarkup...)),
+				elem.Span(
+					masc.Markup(masc.Class("slds-icon_container", "slds-input__icon", "slds-input__icon_right")),
+					icon,
+				),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-dropdown", "slds-dropdown_length-with-icon-7", "slds-dropdown_fluid"),
+					masc.Property("id", listboxID),
+					masc.Attribute("role", "listbox"),
+					masc.Attribute("aria-label", props.Label),
+				),
+				elem.UnorderedList(items...),
+			),
+		),
+	)
+	return validatedFormElement(props.Label, props.Validation, control)
+}
+
+// comboboxOption renders one option of a Combobox.
+func comboboxOption(id string, opt ComboboxOption, selected bool, onClick func(*masc.Event)) masc.ComponentOrHTML {
+	classes := []string{"slds-media", "slds-listbox__option", "slds-media_center"}
+	if opt.Meta != "" {
+		classes = append(classes, "slds-listbox__option_entity", "slds-listbox__option_has-meta")
+	} else {
+		classes = append(classes, "slds-listbox__option_plain", "slds-media_small")
+	}
+	if selected {
+		classes = append(classes, "slds-is-selected")
+	}
+	markup := []masc.Applyer{
+		masc.Class(classes...),
+		masc.Property("id", id),
+		masc.Attribute("role", "option"),
+		masc.Attribute("aria-selected", strconv.FormatBool(selected)),
+		masc.Data("value", opt.Value),
+	}
+	if opt.Disabled {
+		markup = append(markup, masc.Attribute("aria-disabled", "true"))
+	} else {
+		markup = append(markup, event.Click(onClick))
+	}
+
+	var figure masc.MarkupOrChild
+	switch {
+	case opt.Icon != "":
+		category, name, _ := strings.Cut(opt.Icon, ":")
+		figure = Icon(IconCategory(category), name, IconSmall)
+	case selected:
+		figure = Icon(UtilityIcon, "check", IconXSmall)
+	}
+	body := []masc.MarkupOrChild{
+		masc.Markup(masc.Class("slds-media__body")),
+		elem.Span(
+			masc.Markup(
+				masc.Class("slds-truncate", "slds-listbox__option-text", "slds-listbox__option-text_entity"),
+				masc.Property("title", opt.Label),
+			),
+			masc.Text(opt.Label),
+		),
+	}
+	if opt.Meta != "" {
+		body = append(body, elem.Span(
+			masc.Markup(masc.Class("slds-listbox__option-meta", "slds-listbox__option-meta_entity")),
+			masc.Text(opt.Meta),
+		))
+	}
+	return elem.ListItem(
+		masc.Markup(
+			masc.Class("slds-listbox__item"),
+			masc.Attribute("role", "presentation"),
+		),
+		elem.Div(
+			masc.Markup(markup...),
+			elem.Span(
+				masc.Markup(masc.Class("slds-media__figure", "slds-listbox__option-icon")),
+				figure,
+			),
+			elem.Span(body...),
+		),
+	)
+}
+
+// comboboxGroupHeading renders the heading of an option group.
+func comboboxGroupHeading(group string) masc.ComponentOrHTML {
+	return elem.ListItem(
+		masc.Markup(
+			masc.Class("slds-listbox__item"),
+			masc.Attribute("role", "presentation"),
+		),
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-media", "slds-listbox__option", "slds-listbox__option_plain", "slds-media_small"),
+				masc.Attribute("role", "presentation"),
+			),
+			elem.Heading3(
+				masc.Markup(
+					masc.Class("slds-listbox__option-header"),
+					masc.Attribute("role", "presentation"),
+				),
+				masc.Text(group),
+			),
+		),
+	)
+}
+
+// nextActiveIndex returns the option made active by key when current of
+// count options is active (-1 for none). Arrow keys wrap around.
+func nextActiveIndex(current, count int, key string) int {
+	if count == 0 {
+		return -1
+	}
+	switch key {
+	case "ArrowDown":
+		return (current + 1) % count
+	case "ArrowUp":
+		if current <= 0 {
+			return count - 1
+		}
+		return current - 1
+	case "Home":
+		return 0
+	case "End":
+		return count - 1
+	}
+	return current
+}
+
+// filterComboboxOptions returns the options whose label contains text,
+// ignoring case. An option whose label equals text is left out, so the list
+// closes once an option has been chosen.
+func filterComboboxOptions(options []ComboboxOption, text string) []ComboboxOption {
+	q := strings.ToLower(strings.TrimSpace(text))
+	if q == "" {
+		return nil
+	}
+	var filtered []ComboboxOption
+	for _, opt := range options {
+		lower := strings.ToLower(opt.Label)
+		if lower != q && strings.Contains(lower, q) {
+			filtered = append(filtered, opt)
+		}
+	}
+	return filtered
+}
+
+// hasComboboxLabel reports whether an option's label equals text, ignoring
+// case.
+func hasComboboxLabel(options []ComboboxOption, text string) bool {
+	for _, opt := range options {
+		if strings.EqualFold(opt.Label, text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+//go:build js
+
+package components
+
+import (
+	"strconv"
+
+	"github.com/octoberswimmer/masc"
+)
+
+// comboboxKeyDown handles keyboard navigation of a Combobox. The active
+// option is tracked in the DOM, with the slds-has-focus class and the input's
+// aria-activedescendant, so moving it needs no round trip through the app.
+// setOpen records when the keys open or close the list. Enter clicks the
+// active option or, with none active, calls onCreate; Escape closes the list
+// and calls onEscape.
+func comboboxKeyDown(e *masc.Event, setOpen func(bool), onCreate func(), onEscape func()) {
+	if e == nil || !e.Target.Truthy() {
+		return
+	}
+	combobox := e.Target.Call("closest", ".slds-combobox")
+	if !combobox.Truthy() {
+		return
+	}
+	classList := combobox.Get("classList")
+	options := combobox.Call("querySelectorAll", `[role=option]:not([aria-disabled="true"])`)
+	count := options.Get("length").Int()
+	active := -1
+	for i := 0; i < count; i++ {
+		if options.Call("item", i).Get("classList").Call("contains", "slds-has-focus").Bool() {
+			active = i
+		}
+	}
+
+	switch key := e.Value.Get("key").String(); key {
+	case "ArrowDown", "ArrowUp", "Home", "End":
+		e.Value.Call("preventDefault")
+		for i := 0; i < count; i++ {
+			options.Call("item", i).Get("classList").Call("remove", "slds-has-focus")
+		}
+		next := nextActiveIndex(active, count, key)
+		if next < 0 {
+			e.Target.Call("removeAttribute", "aria-activedescendant")
+			return
+		}
+		classList.Call("add", "slds-is-open")
+		e.Target.Call("setAttribute", "aria-expanded", "true")
+		setOpen(true)
+		option := options.Call("item", next)
+		option.Get("classList").Call("add", "slds-has-focus")
+		option.Call("scrollIntoView", map[string]interface{}{"block": "nearest"})
+		e.Target.Call("setAttribute", "aria-activedescendant", option.Get("id").String())
+	case "Enter":
+		e.Value.Call("preventDefault")
+		if active >= 0 && classList.Call("contains", "slds-is-open").Bool() {
+			options.Call("item", active).Call("click")
+		} else if onCreate != nil {
+			onCreate()
+		}
+	case "Escape":
+		setOpen(false)
+		closeComboboxList(e)
+		if onEscape != nil {
+			onEscape()
+		}
+	}
+}
+
+// closeComboboxList closes the list of the combobox containing the event's
+// target and clears its active option.
+func closeComboboxList(e *masc.Event) {
+	if e == nil || !e.Target.Truthy() {
+		return
+	}
+	combobox := e.Target.Call("closest", ".slds-combobox")
+	if !combobox.Truthy() {
+		return
+	}
+	combobox.Get("classList").Call("remove", "slds-is-open")
+	input := combobox.Call("querySelector", "input[role=combobox]")
+	if input.Truthy() {
+		input.Call("removeAttribute", "aria-activedescendant")
+		input.Call("setAttribute", "aria-expanded", "false")
+	}
+	focused := combobox.Call("querySelectorAll", ".slds-has-focus")
+	for i := 0; i < focused.Get("length").Int(); i++ {
+		focused.Call("item", i).Get("classList").Call("remove", "slds-has-focus")
+	}
+}
+
+// setComboboxListOpen opens or closes the list of a read-only combobox.
+func setComboboxListOpen(e *masc.Event, open bool) {
+	if e == nil || !e.Target.Truthy() {
+		return
+	}
+	combobox := e.Target.Call("closest", ".slds-combobox")
+	if !combobox.Truthy() {
+		return
+	}
+	combobox.Get("classList").Call("toggle", "slds-is-open", open)
+	e.Target.Call("setAttribute", "aria-expanded", strconv.FormatBool(open))
+}
@@ -0,0 +1,14 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+// The combobox list is opened, closed and navigated in the browser's DOM;
+// outside the browser these are no-ops.
+
+func comboboxKeyDown(e *masc.Event, setOpen func(bool), onCreate func(), onEscape func()) {}
+
+func closeComboboxList(e *masc.Event) {}
+
+func setComboboxListOpen(e *masc.Event, open bool) {}
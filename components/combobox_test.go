@@ -0,0 +1,182 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/masc/elem"
+)
+
+var comboboxTestOptions = []ComboboxOption{
+	{Label: "Acme", Value: "001A", Meta: "San Francisco", Icon: "standard:account", Group: "Accounts"},
+	{Label: "Acme Labs", Value: "001B", Group: "Accounts"},
+	{Label: "Ada Lovelace", Value: "003A", Icon: "standard:contact", Group: "Contacts"},
+	{Label: "Archived", Value: "003B", Group: "Contacts", Disabled: true},
+}
+
+// TestComboboxGroupsOptions verifies autocomplete options are filtered and
+// listed under their group headings.
+func TestComboboxGroupsOptions(t *testing.T) {
+	win := renderComponent(t, Combobox(ComboboxProps{
+		Label:      "Related To",
+		Options:    comboboxTestOptions,
+		SearchText: "a",
+	}))
+
+	if querySelector(t, win, ".slds-combobox.slds-is-open") == nil {
+		t.Fatal("expected the list to be open")
+	}
+	headings, err := win.Document().QuerySelectorAll(".slds-listbox__option-header")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headings.Length() != 2 {
+		t.Fatalf("expected 2 group headings, got %d", headings.Length())
+	}
+	if got := headings.Item(1).TextContent(); got != "Contacts" {
+		t.Errorf("expected Contacts heading, got %q", got)
+	}
+	meta := querySelector(t, win, ".slds-listbox__option-meta")
+	if meta == nil || meta.TextContent() != "San Francisco" {
+		t.Error("expected meta text beneath the option label")
+	}
+	if querySelector(t, win, `[role=option][aria-disabled="true"]`) == nil {
+		t.Error("expected the disabled option to be marked aria-disabled")
+	}
+
+	input := querySelector(t, win, "input[role=combobox]")
+	controls, _ := input.GetAttribute("aria-controls")
+	if controls == "" || querySelector(t, win, "#"+controls+"[role=listbox]") == nil {
+		t.Errorf("expected aria-controls to reference the listbox, got %q", controls)
+	}
+	id := strings.TrimSuffix(controls, "-listbox")
+	if querySelector(t, win, "#"+id+"-option-0") == nil {
+		t.Error("expected options to have ids for aria-activedescendant")
+	}
+}
+
+// TestComboboxUniqueIDs verifies comboboxes with the same label get distinct
+// element ids.
+func TestComboboxUniqueIDs(t *testing.T) {
+	props := ComboboxProps{Label: "Owner", Options: comboboxTestOptions, SearchText: "a"}
+	win := renderComponent(t, elem.Div(Combobox(props), Combobox(props)))
+
+	listboxes, err := win.Document().QuerySelectorAll("[role=listbox]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listboxes.Length() != 2 {
+		t.Fatalf("expected 2 listboxes, got %d", listboxes.Length())
+	}
+	first, _ := listboxes.Item(0).(html.HTMLElement).GetAttribute("id")
+	second, _ := listboxes.Item(1).(html.HTMLElement).GetAttribute("id")
+	if first == "" || first == second {
+		t.Errorf("expected distinct listbox ids, got %q and %q", first, second)
+	}
+}
+
+// TestComboboxKeepsReadOnlyListOpen verifies an opened read-only list stays
+// open when the combobox renders again.
+func TestComboboxKeepsReadOnlyListOpen(t *testing.T) {
+	comp := Combobox(ComboboxProps{
+		Label:    "Stage",
+		ReadOnly: true,
+		Options:  []ComboboxOption{{Label: "Open", Value: "open"}},
+	})
+	win := renderComponent(t, comp)
+	querySelector(t, win, "input[role=combobox]").(html.HTMLElement).Click()
+
+	win = renderComponent(t, comp)
+	if querySelector(t, win, ".slds-combobox.slds-is-open") == nil {
+		t.Error("expected the list to stay open after a re-render")
+	}
+	if expanded, _ := querySelector(t, win, "input[role=combobox]").GetAttribute("aria-expanded"); expanded != "true" {
+		t.Errorf("expected aria-expanded true, got %q", expanded)
+	}
+}
+
+// TestComboboxSelectsOption verifies clicking an option reports its value.
+func TestComboboxSelectsOption(t *testing.T) {
+	var selected string
+	win := renderComponent(t, Combobox(ComboboxProps{
+		Label:    "Stage",
+		ReadOnly: true,
+		Value:    "open",
+		Options: []ComboboxOption{
+			{Label: "Open", Value: "open"},
+			{Label: "Closed", Value: "closed"},
+		},
+		OnSelect: func(value string) { selected = value },
+	}))
+
+	input := querySelector(t, win, "input[role=combobox]")
+	if value, _ := input.GetAttribute("aria-autocomplete"); value != "none" {
+		t.Errorf("expected read-only combobox, got aria-autocomplete %q", value)
+	}
+	if querySelector(t, win, `[aria-selected="true"][data-value="open"]`) == nil {
+		t.Error("expected the current value to be selected")
+	}
+	querySelector(t, win, `[data-value="closed"]`).(html.HTMLElement).Click()
+	if selected != "closed" {
+		t.Errorf("expected closed to be selected, got %q", selected)
+	}
+}
+
+// TestComboboxCreatesValue verifies typed text that matches no option can be
+// added.
+func TestComboboxCreatesValue(t *testing.T) {
+	var created string
+	win := renderComponent(t, Combobox(ComboboxProps{
+		Label:       "Tags",
+		Options:     comboboxTestOptions,
+		SearchText:  "Zebra",
+		AllowCreate: true,
+		OnCreate:    func(text string) { created = text },
+	}))
+
+	option := querySelector(t, win, "[id$=-option-new]")
+	if option == nil {
+		t.Fatal("expected an option to add the typed text")
+	}
+	option.(html.HTMLElement).Click()
+	if created != "Zebra" {
+		t.Errorf("expected Zebra to be created, got %q", created)
+	}
+}
+
+// TestNextActiveIndex verifies arrow, Home and End keys move the active
+// option, wrapping at either end.
+func TestNextActiveIndex(t *testing.T) {
+	tests := []struct {
+		current, count int
+		key            string
+		want           int
+	}{
+		{-1, 3, "ArrowDown", 0},
+		{2, 3, "ArrowDown", 0},
+		{-1, 3, "ArrowUp", 2},
+		{0, 3, "ArrowUp", 2},
+		{1, 3, "ArrowUp", 0},
+		{1, 3, "Home", 0},
+		{0, 3, "End", 2},
+		{-1, 0, "ArrowDown", -1},
+	}
+	for _, tt := range tests {
+		if got := nextActiveIndex(tt.current, tt.count, tt.key); got != tt.want {
+			t.Errorf("nextActiveIndex(%d, %d, %q) = %d, want %d", tt.current, tt.count, tt.key, got, tt.want)
+		}
+	}
+}
+
+// TestFilterComboboxOptions verifies filtering ignores case and leaves out
+// an exact match.
+func TestFilterComboboxOptions(t *testing.T) {
+	got := filterComboboxOptions(comboboxTestOptions, "ACME")
+	if len(got) != 1 || got[0].Value != "001B" {
+		t.Errorf("expected only Acme Labs, got %v", got)
+	}
+	if got := filterComboboxOptions(comboboxTestOptions, " "); got != nil {
+		t.Errorf("expected no options for blank text, got %v", got)
+	}
+}
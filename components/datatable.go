@@ -3,6 +3,7 @@ package components
 import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // DataTable renders an SLDS data table.
@@ -198,7 +199,7 @@ func TableWithActions(headers []string, rows []TableRow) masc.ComponentOrHTML {
 				actionArgs = append(actionArgs, action)
 			}
 			actionCell := elem.TableData(
-				masc.Markup(masc.Data("label", "Actions")),
+				masc.Markup(masc.Data("label", i18n.T("Thunder_Actions"))),
 				elem.Div(actionArgs...),
 			)
 			cells = append(cells, actionCell)
@@ -225,10 +226,65 @@ func TableWithComponents(headers []string, rows []ComponentTableRow) masc.Compon
 		return nil
 	}
 
-	// Table classes (striped for alternating row colors)
-	tableClass := masc.Class("slds-table", "slds-table_cell-buffer", "slds-table_bordered", "slds-table_striped")
+	// Build body rows
+	var bodyRows []masc.MarkupOrChild
+	for _, row := range rows {
+		bodyRows = append(bodyRows, componentTableRow(headers, row))
+	}
 
-	// Build header row
+	// Assemble table in a section container
+	return Section(SpaceLarge,
+		elem.Table(
+			masc.Markup(componentTableClass()),
+			elem.TableHead(componentTableHead(headers)),
+			elem.TableBody(bodyRows...),
+		),
+	)
+}
+
+// VirtualTableWithComponents renders a TableWithComponents inside a
+// fixed-height scroll container, rendering only the rows in view. Use it for
+// tables with thousands of rows.
+func VirtualTableWithComponents(headers []string, rows []ComponentTableRow, vs VirtualScroll) masc.ComponentOrHTML {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	r := visibleRange(vs, len(rows))
+	colspan := len(headers)
+	for _, row := range rows {
+		if row.Actions != nil {
+			colspan++
+			break
+		}
+	}
+
+	// Build body rows, with spacers for the rows out of view
+	bodyRows := []masc.MarkupOrChild{spacerRow(r.Before, colspan)}
+	for i := r.Start; i < r.End; i++ {
+		bodyRows = append(bodyRows, componentTableRow(headers, rows[i], virtualIndex(i)))
+	}
+	bodyRows = append(bodyRows, spacerRow(r.After, colspan))
+
+	return Section(SpaceLarge,
+		virtualScrollContainer(vs, len(rows), r, nil,
+			elem.Table(
+				masc.Markup(componentTableClass()),
+				elem.TableHead(componentTableHead(headers)),
+				elem.TableBody(bodyRows...),
+			),
+		),
+	)
+}
+
+// componentTableClass returns the table classes (striped for alternating row
+// colors) used by TableWithComponents.
+func componentTableClass() masc.Applyer {
+	return masc.Class("slds-table", "slds-table_cell-buffer", "slds-table_bordered", "slds-table_striped")
+}
+
+// componentTableHead renders the header row of a TableWithComponents.
+func componentTableHead(headers []string) masc.ComponentOrHTML {
 	var headCells []masc.MarkupOrChild
 	for _, h := range headers {
 		headCells = append(headCells,
@@ -251,73 +307,64 @@ func TableWithComponents(headers []string, rows []ComponentTableRow) masc.Compon
 	var headerRowArgs []masc.MarkupOrChild
 	headerRowArgs = append(headerRowArgs, masc.Markup(masc.Class("slds-line-height_reset")))
 	headerRowArgs = append(headerRowArgs, headCells...)
-	headRow := elem.TableRow(headerRowArgs...)
+	return elem.TableRow(headerRowArgs...)
+}
 
-	// Build body rows
-	var bodyRows []masc.MarkupOrChild
-	for _, row := range rows {
-		var cells []masc.MarkupOrChild
+// componentTableRow renders one body row of a TableWithComponents. Extra
+// markup is applied to the row element.
+func componentTableRow(headers []string, row ComponentTableRow, markup ...masc.Applyer) masc.ComponentOrHTML {
+	var cells []masc.MarkupOrChild
 
-		// Add data cells
-		for i, cell := range row.Cells {
-			if i >= len(headers) {
-				break // Don't exceed header count
-			}
+	// Add data cells
+	for i, cell := range row.Cells {
+		if i >= len(headers) {
+			break // Don't exceed header count
+		}
 
-			content := elem.Div(
-				masc.Markup(
-					masc.Class("slds-truncate"),
-					masc.Property("title", cell.Title),
-				),
-				cell.Content,
-			)
+		content := elem.Div(
+			masc.Markup(
+				masc.Class("slds-truncate"),
+				masc.Property("title", cell.Title),
+			),
+			cell.Content,
+		)
 
-			if i == 0 {
-				// First cell is a row header
-				cells = append(cells,
-					elem.TableHeader(
-						masc.Markup(
-							masc.Property("scope", "row"),
-							masc.Data("label", headers[i]),
-						),
-						content,
-					),
-				)
-			} else {
-				// Regular data cell
-				cells = append(cells,
-					elem.TableData(
-						masc.Markup(masc.Data("label", headers[i])),
-						content,
+		if i == 0 {
+			// First cell is a row header
+			cells = append(cells,
+				elem.TableHeader(
+					masc.Markup(
+						masc.Property("scope", "row"),
+						masc.Data("label", headers[i]),
 					),
-				)
-			}
-		}
-
-		// Add action cell if actions exist
-		if row.Actions != nil {
-			actionCell := elem.TableData(
-				masc.Markup(masc.Data("label", "Actions")),
-				row.Actions,
+					content,
+				),
+			)
+		} else {
+			// Regular data cell
+			cells = append(cells,
+				elem.TableData(
+					masc.Markup(masc.Data("label", headers[i])),
+					content,
+				),
 			)
-			cells = append(cells, actionCell)
 		}
+	}
 
-		// Combine row markup and cells into arguments
-		var rowArgs []masc.MarkupOrChild
-		rowArgs = append(rowArgs, masc.Markup(masc.Class("slds-hint-parent")))
-		rowArgs = append(rowArgs, cells...)
-		bodyRows = append(bodyRows, elem.TableRow(rowArgs...))
+	// Add action cell if actions exist
+	if row.Actions != nil {
+		actionCell := elem.TableData(
+			masc.Markup(masc.Data("label", i18n.T("Thunder_Actions"))),
+			row.Actions,
+		)
+		cells = append(cells, actionCell)
 	}
 
-	// Assemble table in a section container
-	return Section(SpaceLarge,
-		elem.Table(
-			masc.Markup(tableClass),
-			elem.TableHead(headRow),
-			elem.TableBody(bodyRows...),
-		),
-	)
+	// Combine row markup and cells into arguments
+	var rowArgs []masc.MarkupOrChild
+	rowArgs = append(rowArgs, masc.Markup(append([]masc.Applyer{masc.Class("slds-hint-parent")}, markup...)...))
+	rowArgs = append(rowArgs, cells...)
+	return elem.TableRow(rowArgs...)
 }
 
 // EmptyTable renders a centered message when no data is available.
@@ -0,0 +1,356 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// DataTableCell identifies one cell of an AdvancedDataTable by its row key and
+// column field name. The zero value means no cell.
+type DataTableCell struct {
+	RowKey    string
+	FieldName string
+}
+
+// SetDraftValue records value as the draft for cell and returns the updated
+// drafts, allocating the map when drafts is nil. It is a convenience for
+// handling AdvancedDataTableProps.OnCellChange in the app's Update.
+func SetDraftValue(drafts map[string]map[string]interface{}, cell DataTableCell, value interface{}) map[string]map[string]interface{} {
+	if drafts == nil {
+		drafts = make(map[string]map[string]interface{})
+	}
+	if drafts[cell.RowKey] == nil {
+		drafts[cell.RowKey] = make(map[string]interface{})
+	}
+	drafts[cell.RowKey][cell.FieldName] = value
+	return drafts
+}
+
+// DraftChanges converts draft values into one record per changed row, each
+// holding its row key as "Id" plus the changed fields, ordered by row key.
+// The result is ready to pass to api.UpdateRecords, so the drafts must be
+// keyed by record Id.
+func DraftChanges(drafts map[string]map[string]interface{}) []map[string]interface{} {
+	keys := make([]string, 0, len(drafts))
+	for k, fields := range drafts {
+		if len(fields) > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	changes := make([]map[string]interface{}, 0, len(keys))
+	for _, k := range keys {
+		change := map[string]interface{}{"Id": k}
+		for field, v := range drafts[k] {
+			change[field] = v
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// inlineEditable reports whether the table's editable columns can be edited:
+// OnCellChange must be set and rows keyed by Id, which DraftChanges sends as
+// the record Id.
+func inlineEditable(props AdvancedDataTableProps) bool {
+	return props.OnCellChange != nil && (props.KeyField == "" || props.KeyField == "Id")
+}
+
+// hasDrafts reports whether any row has a draft value.
+func hasDrafts(drafts map[string]map[string]interface{}) bool {
+	for _, fields := range drafts {
+		if len(fields) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// editableCell renders an inline-editable cell: the current (or draft) value
+// with an edit button, the SLDS edit popover while the cell is being edited,
+// and the error from the last save, if any.
+func editableCell(props AdvancedDataTableProps, col DataTableColumn, row map[string]interface{}, key string, first bool) masc.ComponentOrHTML {
+	cell := DataTableCell{RowKey: key, FieldName: col.FieldName}
+	value, edited := row[col.FieldName], false
+	if draft, ok := props.DraftValues[key][col.FieldName]; ok {
+		value, edited = draft, true
+	}
+	errMsg := props.CellErrors[key][col.FieldName]
+	editing := props.EditingCell == cell && !props.IsSaving
+
+	classes := []string{"slds-cell-edit"}
+	if edited {
+		classes = append(classes, "slds-is-edited")
+	}
+	if errMsg != "" {
+		classes = append(classes, "slds-has-error")
+	}
+	text := displayValue(col, value)
+
+	var errorIcon masc.ComponentOrHTML
+	if errMsg != "" {
+		errorIcon = elem.Span(
+			masc.Markup(
+				masc.Class("slds-m-right_x-small", "slds-text-color_error"),
+				masc.Property("title", errMsg),
+			),
+			Icon(UtilityIcon, "error", IconSmall),
+			elem.Span(
+				masc.Markup(masc.Class("slds-assistive-text")),
+				masc.Text(errMsg),
+			),
+		)
+	}
+
+	content := elem.Span(
+		masc.Markup(masc.Class("slds-grid", "slds-grid_align-spread")),
+		errorIcon,
+		elem.Span(
+			masc.Markup(
+				masc.Class("slds-truncate"),
+				masc.Property("title", text),
+			),
+			masc.Text(text),
+		),
+		elem.Button(
+			masc.Markup(
+				masc.Class("slds-button", "slds-button_icon", "slds-cell-edit__button", "slds-m-left_x-small"),
+				masc.Property("title", i18n.T("Thunder_Edit", col.Label)),
+				masc.Property("disabled", props.IsSaving),
+				event.Click(func(*masc.Event) {
+					if props.OnEditCell != nil {
+						props.OnEditCell(cell)
+					}
+				}),
+			),
+			Icon(UtilityIcon, "edit", IconSmall),
+			elem.Span(
+				masc.Markup(masc.Class("slds-assistive-text")),
+				masc.Text(i18n.T("Thunder_Edit", col.Label)),
+			),
+		),
+	)
+
+	markup := masc.Markup(
+		masc.Class(classes...),
+		masc.Data("label", col.Label),
+		masc.Attribute("role", "gridcell"),
+		masc.Style("position", "relative"),
+	)
+	var popover masc.ComponentOrHTML
+	if editing {
+		popover = cellEditPopover(props, col, cell, value)
+	}
+	if first {
+		return elem.TableHeader(markup, content, popover)
+	}
+	return elem.TableData(markup, content, popover)
+}
+
+// cellEditPopover renders the SLDS inline edit popover holding the editor for
+// the column's Type. Enter or Escape closes the popover; edits are reported as
+// they are made, so closing keeps the draft.
+func cellEditPopover(props AdvancedDataTableProps, col DataTableColumn, cell DataTableCell, value interface{}) masc.ComponentOrHTML {
+	done := func() {
+		if props.OnEditCell != nil {
+			props.OnEditCell(DataTableCell{})
+		}
+	}
+	change := func(v interface{}) {
+		props.OnCellChange(cell, v)
+	}
+	return elem.Section(
+		masc.Markup(
+			masc.Class("slds-popover", "slds-popover_edit"),
+			masc.Attribute("role", "dialog"),
+			masc.Style("position", "absolute"),
+			masc.Style("top", "0"),
+			masc.Style("left", "0"),
+			masc.Style("z-index", "1"),
+			event.KeyDown(func(e *masc.Event) {
+				switch e.Value.Get("key").String() {
+				case "Enter", "Escape":
+					done()
+				}
+			}),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-popover__body")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-form-element", "slds-grid", "slds-wrap")),
+				elem.Label(
+					masc.Markup(masc.Class("slds-form-element__label", "slds-form-element__label_edit", "slds-assistive-text")),
+					masc.Text(col.Label),
+				),
+				elem.Div(
+					masc.Markup(masc.Class("slds-form-element__control", "slds-grow")),
+					cellEditor(props, col, value, change, done),
+				),
+			),
+		),
+	)
+}
+
+// cellEditor renders the editor for a column type: text, number, date,
+// picklist, checkbox or lookup.
+func cellEditor(props AdvancedDataTableProps, col DataTableColumn, value interface{}, change func(interface{}), done func()) masc.ComponentOrHTML {
+	switch col.Type {
+	case "number", "currency", "percent":
+		return elem.Input(
+			masc.Markup(
+				masc.Class("slds-input"),
+				masc.Property("type", "number"),
+				masc.Property("value", displayValue(DataTableColumn{}, value)),
+				masc.Property("autofocus", true),
+				event.Input(func(e *masc.Event) {
+					s := strings.TrimSpace(e.Target.Get("value").String())
+					if s == "" {
+						change(nil)
+						return
+					}
+					if n, err := strconv.ParseFloat(s, 64); err == nil {
+						change(n)
+					}
+				}),
+			),
+		)
+	case "date":
+		return elem.Input(
+			masc.Markup(
+				masc.Class("slds-input"),
+				masc.Property("type", "date"),
+				masc.Property("value", displayValue(DataTableColumn{}, value)),
+				masc.Property("autofocus", true),
+				event.Change(func(e *masc.Event) {
+					if s := e.Target.Get("value").String(); s != "" {
+						change(s)
+					} else {
+						change(nil)
+					}
+				}),
+			),
+		)
+	case "picklist":
+		current := displayValue(DataTableColumn{}, value)
+		options := []masc.MarkupOrChild{
+			masc.Markup(
+				masc.Class("slds-select"),
+				masc.Property("autofocus", true),
+				event.Change(func(e *masc.Event) {
+					change(e.Target.Get("value").String())
+					done()
+				}),
+			),
+			elem.Option(masc.Markup(masc.Property("value", "")), masc.Text(i18n.T("Thunder_None"))),
+		}
+		for _, opt := range col.Options {
+			options = append(options, elem.Option(
+				masc.Markup(
+					masc.Property("value", opt.Value),
+					masc.Property("selected", opt.Value == current),
+				),
+				masc.Text(opt.Label),
+			))
+		}
+		return elem.Div(
+			masc.Markup(masc.Class("slds-select_container")),
+			elem.Select(options...),
+		)
+	case "checkbox", "boolean":
+		checked, _ := value.(bool)
+		return elem.Label(
+			masc.Markup(masc.Class("slds-checkbox")),
+			elem.Input(
+				masc.Markup(
+					masc.Property("type", "checkbox"),
+					masc.Property("checked", checked),
+					masc.Property("autofocus", true),
+					event.Change(func(e *masc.Event) {
+						change(e.Target.Get("checked").Bool())
+					}),
+				),
+			),
+			elem.Span(masc.Markup(masc.Class("slds-checkbox_faux"))),
+			elem.Span(
+				masc.Markup(masc.Class("slds-form-element__label", "slds-assistive-text")),
+				masc.Text(col.Label),
+			),
+		)
+	case "lookup":
+		var suggestions []LookupOption
+		for _, opt := range col.Options {
+			suggestions = append(suggestions, LookupOption{Label: opt.Label, Value: opt.Value})
+		}
+		return Lookup(col.Label, suggestions, props.LookupSearch,
+			func(text string) {
+				if props.OnLookupSearch != nil {
+					props.OnLookupSearch(text)
+				}
+			},
+			func(id string) {
+				change(id)
+				done()
+			},
+		)
+	default:
+		return elem.Input(
+			masc.Markup(
+				masc.Class("slds-input"),
+				masc.Property("type", "text"),
+				masc.Property("value", displayValue(DataTableColumn{}, value)),
+				masc.Property("autofocus", true),
+				event.Input(func(e *masc.Event) {
+					change(e.Target.Get("value").String())
+				}),
+			),
+		)
+	}
+}
+
+// displayValue renders a cell value as text, showing the option label for
+// picklist and lookup columns.
+func displayValue(col DataTableColumn, v interface{}) string {
+	if col.Type == "picklist" || col.Type == "lookup" {
+		s := fmt.Sprint(v)
+		for _, opt := range col.Options {
+			if opt.Value == s {
+				return opt.Label
+			}
+		}
+	}
+	return formatCellValue(col, v)
+}
+
+// saveBar renders the footer shown while the table has unsaved drafts.
+func saveBar(props AdvancedDataTableProps) masc.ComponentOrHTML {
+	save := Button(i18n.T("Thunder_Save"), VariantBrand, func(*masc.Event) {
+		if props.OnSave != nil {
+			props.OnSave(DraftChanges(props.DraftValues))
+		}
+	})
+	if props.IsSaving {
+		save = LoadingButton(i18n.T("Thunder_Saving"), VariantBrand)
+	}
+	cancel := Button(i18n.T("Thunder_Cancel"), VariantNeutral, func(*masc.Event) {
+		if props.OnCancel != nil {
+			props.OnCancel()
+		}
+	})
+	if props.IsSaving {
+		cancel = DisabledButton(i18n.T("Thunder_Cancel"), VariantNeutral)
+	}
+	return elem.Div(
+		masc.Markup(
+			masc.Class("slds-grid", "slds-grid_align-center", "slds-p-around_small", "slds-theme_shade", "slds-border_top"),
+			masc.Attribute("role", "status"),
+		),
+		ButtonGroupSpaced(cancel, save),
+	)
+}
@@ -0,0 +1,142 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+)
+
+var editableColumns = []DataTableColumn{
+	{Label: "Name", FieldName: "Name", Editable: true},
+	{Label: "Stage", FieldName: "StageName", Type: "picklist", Editable: true, Options: []SelectOption{
+		{Label: "Open", Value: "open"},
+		{Label: "Closed", Value: "closed"},
+	}},
+}
+
+var editableRows = []map[string]interface{}{
+	{"Id": "001", "Name": "Acme", "StageName": "open"},
+	{"Id": "002", "Name": "Globex", "StageName": "closed"},
+}
+
+// TestAdvancedDataTableShowsDraftsAndErrors verifies edited cells show their
+// draft value, failed cells are flagged and the save bar appears.
+func TestAdvancedDataTableShowsDraftsAndErrors(t *testing.T) {
+	win := renderComponent(t, AdvancedDataTable(AdvancedDataTableProps{
+		Columns:      editableColumns,
+		Rows:         editableRows,
+		DraftValues:  map[string]map[string]interface{}{"002": {"StageName": "open"}},
+		CellErrors:   map[string]map[string]string{"001": {"Name": "Name is required"}},
+		OnCellChange: func(DataTableCell, interface{}) {},
+	}))
+
+	edited, err := win.Document().QuerySelector("td.slds-is-edited .slds-truncate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited == nil {
+		t.Fatal("expected an edited cell, got none")
+	}
+	if got := edited.TextContent(); got != "Open" {
+		t.Errorf("expected the draft picklist label, got %q", got)
+	}
+
+	failed, err := win.Document().QuerySelector(".slds-has-error [title]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed == nil {
+		t.Fatal("expected an error on the failed cell, got none")
+	}
+	if got, _ := failed.GetAttribute("title"); got != "Name is required" {
+		t.Errorf("expected the save error message, got %q", got)
+	}
+
+	save, err := win.Document().QuerySelector("button.slds-button_brand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if save == nil {
+		t.Fatal("expected a Save button while drafts exist, got none")
+	}
+}
+
+// TestAdvancedDataTableEditAndSave verifies the edit button opens the cell
+// editor and Save hands the drafts to the app as changed rows.
+func TestAdvancedDataTableEditAndSave(t *testing.T) {
+	var editing DataTableCell
+	var saved []map[string]interface{}
+	win := renderComponent(t, AdvancedDataTable(AdvancedDataTableProps{
+		Columns:      editableColumns,
+		Rows:         editableRows,
+		EditingCell:  DataTableCell{RowKey: "001", FieldName: "StageName"},
+		DraftValues:  map[string]map[string]interface{}{"001": {"Name": "Acme Corp"}},
+		OnEditCell:   func(c DataTableCell) { editing = c },
+		OnCellChange: func(DataTableCell, interface{}) {},
+		OnSave:       func(changes []map[string]interface{}) { saved = changes },
+	}))
+
+	popover, err := win.Document().QuerySelector(".slds-popover_edit select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if popover == nil {
+		t.Fatal("expected a picklist editor in the edit popover, got none")
+	}
+
+	btn, err := win.Document().QuerySelector("button[title='Edit Name']")
+	if err != nil {
+		t.Fatal(err)
+	}
+	btn.(html.HTMLElement).Click()
+	if want := (DataTableCell{RowKey: "001", FieldName: "Name"}); editing != want {
+		t.Errorf("expected edit of %+v, got %+v", want, editing)
+	}
+
+	save, err := win.Document().QuerySelector("button.slds-button_brand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	save.(html.HTMLElement).Click()
+	want := []map[string]interface{}{{"Id": "001", "Name": "Acme Corp"}}
+	if !reflect.DeepEqual(saved, want) {
+		t.Errorf("expected %v, got %v", want, saved)
+	}
+}
+
+// TestDraftChanges verifies drafts become one record per row, ordered by key.
+func TestDraftChanges(t *testing.T) {
+	drafts := SetDraftValue(nil, DataTableCell{RowKey: "b", FieldName: "Amount"}, 5.0)
+	drafts = SetDraftValue(drafts, DataTableCell{RowKey: "a", FieldName: "Name"}, "x")
+	drafts = SetDraftValue(drafts, DataTableCell{RowKey: "a", FieldName: "IsActive"}, true)
+
+	got := DraftChanges(drafts)
+	want := []map[string]interface{}{
+		{"Id": "a", "Name": "x", "IsActive": true},
+		{"Id": "b", "Amount": 5.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestAdvancedDataTableEditingNeedsIdKeys verifies columns are only editable
+// when rows are keyed by Id, since DraftChanges sends the key as the Id.
+func TestAdvancedDataTableEditingNeedsIdKeys(t *testing.T) {
+	props := AdvancedDataTableProps{
+		Columns:      editableColumns,
+		Rows:         editableRows,
+		OnCellChange: func(DataTableCell, interface{}) {},
+	}
+	win := renderComponent(t, AdvancedDataTable(props))
+	if button := querySelector(t, win, `button[title="Edit Name"]`); button == nil {
+		t.Error("expected an edit button for rows keyed by Id")
+	}
+
+	props.KeyField = "Name"
+	win = renderComponent(t, AdvancedDataTable(props))
+	if button := querySelector(t, win, ".slds-cell-edit__button"); button != nil {
+		t.Error("expected no edit buttons for rows keyed by Name")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // ActionColumn represents a column configuration for actions
@@ -22,8 +23,11 @@ type DataTableColumn struct {
 	Label     string
 	FieldName string
 	Type      string
-	Width     string        // CSS width value (e.g. "150px", "10%")
-	Actions   *ActionColumn // Only set for action columns
+	Width     string         // CSS width value (e.g. "150px", "10%")
+	Actions   *ActionColumn  // Only set for action columns
+	Sortable  bool           // Header is clickable in an AdvancedDataTable
+	Editable  bool           // Cells are inline-editable in an AdvancedDataTable
+	Options   []SelectOption // Picklist values or lookup candidates for editing
 }
 
 // DataTableWithMenu renders an SLDS data table with a dropdown menu for actions (more Lightning-like)
@@ -32,9 +36,48 @@ func DataTableWithMenu(columns []DataTableColumn, rows []map[string]interface{},
 		return nil
 	}
 
-	// Table classes (striped for alternating row colors)
-	tableClass := masc.Class("slds-table", "slds-table_cell-buffer", "slds-table_bordered", "slds-table_striped")
+	// Build body rows
+	var bodyRows []masc.MarkupOrChild
+	for rowIndex, row := range rows {
+		bodyRows = append(bodyRows, menuTableRow(columns, row, onRowAction, rowIndex))
+	}
+
+	// Assemble table
+	return elem.Table(
+		masc.Markup(componentTableClass()),
+		elem.TableHead(menuTableHead(columns)),
+		elem.TableBody(bodyRows...),
+	)
+}
+
+// VirtualDataTableWithMenu renders a DataTableWithMenu inside a fixed-height
+// scroll container, rendering only the rows in view. Use it for tables with
+// thousands of rows.
+func VirtualDataTableWithMenu(columns []DataTableColumn, rows []map[string]interface{}, onRowAction func(string, map[string]interface{}), vs VirtualScroll) masc.ComponentOrHTML {
+	if len(columns) == 0 {
+		return nil
+	}
 
+	r := visibleRange(vs, len(rows))
+
+	// Build body rows, with spacers for the rows out of view
+	bodyRows := []masc.MarkupOrChild{spacerRow(r.Before, len(columns))}
+	for i := r.Start; i < r.End; i++ {
+		bodyRows = append(bodyRows, menuTableRow(columns, rows[i], onRowAction, i, virtualIndex(i)))
+	}
+	bodyRows = append(bodyRows, spacerRow(r.After, len(columns)))
+
+	return virtualScrollContainer(vs, len(rows), r, nil,
+		elem.Table(
+			masc.Markup(componentTableClass()),
+			elem.TableHead(menuTableHead(columns)),
+			elem.TableBody(bodyRows...),
+		),
+	)
+}
+
+// menuTableHead renders the header row of a DataTableWithMenu.
+func menuTableHead(columns []DataTableColumn) masc.ComponentOrHTML {
 	// Build header row
 	var headCells []masc.MarkupOrChild
 	for _, col := range columns {
@@ -52,7 +95,7 @@ func DataTableWithMenu(columns []DataTableColumn, rows []map[string]interface{},
 						),
 						elem.Span(
 							masc.Markup(masc.Class("slds-assistive-text")),
-							masc.Text("Actions"),
+							masc.Text(i18n.T("Thunder_Actions")),
 						),
 					),
 				),
@@ -83,85 +126,79 @@ func DataTableWithMenu(columns []DataTableColumn, rows []map[string]interface{},
 	var headerRowArgs []masc.MarkupOrChild
 	headerRowArgs = append(headerRowArgs, masc.Markup(masc.Class("slds-line-height_reset")))
 	headerRowArgs = append(headerRowArgs, headCells...)
-	headRow := elem.TableRow(headerRowArgs...)
+	return elem.TableRow(headerRowArgs...)
 
-	// Build body rows
-	var bodyRows []masc.MarkupOrChild
-	for rowIndex, row := range rows {
-		var cells []masc.MarkupOrChild
+}
 
-		for colIndex, col := range columns {
-			if col.Type == "action" {
-				// Action column with menu button
-				actionCell := renderMenuCell(col, row, onRowAction, rowIndex)
-				cells = append(cells, actionCell)
-			} else {
-				// Regular data cell
-				cellValue := ""
-				if val, ok := row[col.FieldName]; ok {
-					if strVal, isString := val.(string); isString {
-						cellValue = strVal
-					} else if boolVal, isBool := val.(bool); isBool && col.Type == "boolean" {
-						if boolVal {
-							cellValue = "Yes"
-						} else {
-							cellValue = "No"
-						}
+// menuTableRow renders one body row of a DataTableWithMenu. Extra markup is
+// applied to the row element.
+func menuTableRow(columns []DataTableColumn, row map[string]interface{}, onRowAction func(string, map[string]interface{}), rowIndex int, markup ...masc.Applyer) masc.ComponentOrHTML {
+	var cells []masc.MarkupOrChild
+
+	for colIndex, col := range columns {
+		if col.Type == "action" {
+			// Action column with menu button
+			actionCell := renderMenuCell(col, row, onRowAction, rowIndex)
+			cells = append(cells, actionCell)
+		} else {
+			// Regular data cell
+			cellValue := ""
+			if val, ok := row[col.FieldName]; ok {
+				if strVal, isString := val.(string); isString {
+					cellValue = strVal
+				} else if boolVal, isBool := val.(bool); isBool && col.Type == "boolean" {
+					if boolVal {
+						cellValue = i18n.T("Thunder_Yes")
+					} else {
+						cellValue = i18n.T("Thunder_No")
 					}
 				}
+			}
 
-				content := elem.Div(
-					masc.Markup(
-						masc.Class("slds-truncate"),
-						masc.Property("title", cellValue),
-					),
-					masc.Text(cellValue),
-				)
-
-				if colIndex == 0 {
-					var cellMarkup []masc.Applyer
-					cellMarkup = append(cellMarkup, masc.Property("scope", "row"))
-					cellMarkup = append(cellMarkup, masc.Data("label", col.Label))
-					if col.Width != "" {
-						cellMarkup = append(cellMarkup, masc.Style("width", col.Width))
-					}
+			content := elem.Div(
+				masc.Markup(
+					masc.Class("slds-truncate"),
+					masc.Property("title", cellValue),
+				),
+				masc.Text(cellValue),
+			)
 
-					cells = append(cells,
-						elem.TableHeader(
-							masc.Markup(cellMarkup...),
-							content,
-						),
-					)
-				} else {
-					var cellMarkup []masc.Applyer
-					cellMarkup = append(cellMarkup, masc.Data("label", col.Label))
-					if col.Width != "" {
-						cellMarkup = append(cellMarkup, masc.Style("width", col.Width))
-					}
+			if colIndex == 0 {
+				var cellMarkup []masc.Applyer
+				cellMarkup = append(cellMarkup, masc.Property("scope", "row"))
+				cellMarkup = append(cellMarkup, masc.Data("label", col.Label))
+				if col.Width != "" {
+					cellMarkup = append(cellMarkup, masc.Style("width", col.Width))
+				}
 
-					cells = append(cells,
-						elem.TableData(
-							masc.Markup(cellMarkup...),
-							content,
-						),
-					)
+				cells = append(cells,
+					elem.TableHeader(
+						masc.Markup(cellMarkup...),
+						content,
+					),
+				)
+			} else {
+				var cellMarkup []masc.Applyer
+				cellMarkup = append(cellMarkup, masc.Data("label", col.Label))
+				if col.Width != "" {
+					cellMarkup = append(cellMarkup, masc.Style("width", col.Width))
 				}
+
+				cells = append(cells,
+					elem.TableData(
+						masc.Markup(cellMarkup...),
+						content,
+					),
+				)
 			}
 		}
-
-		// Combine row markup and cells into arguments
-		var rowArgs []masc.MarkupOrChild
-		rowArgs = append(rowArgs, masc.Markup(masc.Class("slds-hint-parent")))
-		rowArgs = append(rowArgs, cells...)
-		bodyRows = append(bodyRows, elem.TableRow(rowArgs...))
 	}
 
-	// Assemble table
-	return elem.Table(
-		masc.Markup(tableClass),
-		elem.TableHead(headRow),
-		elem.TableBody(bodyRows...),
-	)
+	// Combine row markup and cells into arguments
+	var rowArgs []masc.MarkupOrChild
+	rowArgs = append(rowArgs, masc.Markup(append([]masc.Applyer{masc.Class("slds-hint-parent")}, markup...)...))
+	rowArgs = append(rowArgs, cells...)
+	return elem.TableRow(rowArgs...)
 }
 
 // renderMenuCell renders a table cell with a proper dropdown menu for actions
@@ -265,7 +302,7 @@ func renderMenuCell(col DataTableColumn, row map[string]interface{}, onRowAction
 					masc.Attribute("type", "button"),
 					masc.Attribute("aria-haspopup", "true"),
 					masc.Attribute("aria-expanded", "false"),
-					masc.Attribute("title", "Show Actions"),
+					masc.Attribute("title", i18n.T("Thunder_Show_Actions")),
 					// Simple dropdown toggle
 					event.Click(func(e *masc.Event) {
 						button := e.Target
@@ -285,7 +322,7 @@ func renderMenuCell(col DataTableColumn, row map[string]interface{}, onRowAction
 				masc.Text("⋯"),
 				elem.Span(
 					masc.Markup(masc.Class("slds-assistive-text")),
-					masc.Text("Show Actions"),
+					masc.Text(i18n.T("Thunder_Show_Actions")),
 				),
 			),
 			// Dropdown menu
@@ -0,0 +1,48 @@
+//go:build js
+
+package components
+
+import (
+	"strconv"
+	"syscall/js"
+
+	"github.com/octoberswimmer/masc"
+)
+
+// beginColumnResize tracks a pointer drag that started on a column resize
+// handle. The header cell is resized directly while dragging so the table
+// follows the pointer without re-rendering; onDone receives the final width
+// when the pointer is released.
+func beginColumnResize(e *masc.Event, onDone func(width int)) {
+	if e == nil || onDone == nil || !e.Target.Truthy() {
+		return
+	}
+	th := e.Target.Call("closest", "th")
+	if !th.Truthy() {
+		return
+	}
+	doc := js.Global().Get("document")
+	startX := e.Value.Get("clientX").Int()
+	startWidth := th.Get("offsetWidth").Int()
+	width := startWidth
+
+	var move, up js.Func
+	move = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		width = startWidth + args[0].Get("clientX").Int() - startX
+		if width < 20 {
+			width = 20
+		}
+		th.Get("style").Set("width", strconv.Itoa(width)+"px")
+		return nil
+	})
+	up = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		doc.Call("removeEventListener", "mousemove", move)
+		doc.Call("removeEventListener", "mouseup", up)
+		move.Release()
+		up.Release()
+		onDone(width)
+		return nil
+	})
+	doc.Call("addEventListener", "mousemove", move)
+	doc.Call("addEventListener", "mouseup", up)
+}
@@ -0,0 +1,7 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+func beginColumnResize(e *masc.Event, onDone func(width int)) {}
@@ -0,0 +1,133 @@
+package components
+
+import (
+	"time"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// DateTimePickerProps configures a DateTimePicker.
+type DateTimePickerProps struct {
+	Label string
+	// Value is the selected instant; the zero time leaves the picker empty.
+	Value time.Time
+	// Location is the time zone the date and time are shown and entered in.
+	// Defaults to the user's (see i18n.Location).
+	Location *time.Location
+	// OnChange receives the new instant in UTC, or the zero time when the
+	// date is cleared.
+	OnChange func(time.Time)
+}
+
+// DateTimePicker renders side-by-side date and time inputs for a single
+// instant, shown in props.Location with the zone's abbreviation. Changing the
+// date keeps the time of day (midnight when none is set) and setting a time
+// without a date uses today's date, so apps work with a time.Time rather than
+// converting between local strings and Salesforce's UTC format themselves.
+func DateTimePicker(props DateTimePickerProps) masc.ComponentOrHTML {
+	return ValidatedDateTimePicker(props, ValidationState{})
+}
+
+// ValidatedDateTimePicker renders a DateTimePicker with validation state.
+func ValidatedDateTimePicker(props DateTimePickerProps, validation ValidationState) masc.ComponentOrHTML {
+	loc := dateTimeLocation(props.Location)
+	var dateStr, timeStr, zone string
+	if props.Value.IsZero() {
+		zone = time.Now().In(loc).Format("MST")
+	} else {
+		local := props.Value.In(loc)
+		dateStr = local.Format("2006-01-02")
+		timeStr = local.Format("15:04")
+		zone = local.Format("MST")
+	}
+
+	send := func(t time.Time) {
+		if props.OnChange != nil {
+			props.OnChange(t)
+		}
+	}
+	onDate := func(e *masc.Event) {
+		send(withDate(props.Value, e.Target.Get("value").String(), loc))
+	}
+	onTime := func(e *masc.Event) {
+		if t, ok := withClock(props.Value, e.Target.Get("value").String(), loc, time.Now()); ok {
+			send(t)
+		}
+	}
+
+	control := elem.Div(
+		masc.Markup(masc.Class("slds-grid", "slds-grid_vertical-align-center", "slds-gutters_xx-small")),
+		elem.Div(
+			masc.Markup(masc.Class("slds-col")),
+			elem.Input(
+				masc.Markup(
+					masc.Class("slds-input"),
+					masc.Property("type", "date"),
+					masc.Property("value", dateStr),
+					masc.Property("required", validation.Required),
+					masc.Attribute("aria-label", i18n.T("Thunder_Date_Of", props.Label)),
+					event.Change(onDate),
+				),
+			),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-col")),
+			elem.Input(
+				masc.Markup(
+					masc.Class("slds-input"),
+					masc.Property("type", "time"),
+					masc.Property("value", timeStr),
+					masc.Property("required", validation.Required),
+					masc.Attribute("aria-label", i18n.T("Thunder_Time_Of", props.Label)),
+					event.Change(onTime),
+				),
+			),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-col", "slds-no-flex", "slds-text-color_weak")),
+			masc.Text(zone),
+		),
+	)
+	return validatedFormElement(props.Label, validation, control)
+}
+
+// dateTimeLocation returns loc, or the user's time zone when it is nil.
+func dateTimeLocation(loc *time.Location) *time.Location {
+	if loc == nil {
+		return i18n.Location()
+	}
+	return loc
+}
+
+// withDate returns value moved to the date in s ("2006-01-02"), keeping its
+// time of day in loc, or midnight when value is zero. A blank or invalid date
+// gives the zero time. The result is in UTC.
+func withDate(value time.Time, s string, loc *time.Location) time.Time {
+	date, err := time.ParseInLocation("2006-01-02", s, loc)
+	if err != nil {
+		return time.Time{}
+	}
+	if !value.IsZero() {
+		local := value.In(loc)
+		date = time.Date(date.Year(), date.Month(), date.Day(), local.Hour(), local.Minute(), 0, 0, loc)
+	}
+	return date.UTC()
+}
+
+// withClock returns value moved to the time of day in s ("15:04") in loc,
+// using now's date when value is zero. It reports false for a blank or
+// invalid time. The result is in UTC.
+func withClock(value time.Time, s string, loc *time.Location, now time.Time) (time.Time, bool) {
+	clock, err := time.Parse("15:04", s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	day := now.In(loc)
+	if !value.IsZero() {
+		day = value.In(loc)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc).UTC(), true
+}
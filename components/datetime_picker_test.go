@@ -0,0 +1,83 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// TestDateTimePickerShowsValueInLocation verifies the date, time and zone are
+// shown in the given location.
+func TestDateTimePickerShowsValueInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	win := renderComponent(t, DateTimePicker(DateTimePickerProps{
+		Label:    "Start",
+		Value:    time.Date(2025, 1, 15, 2, 30, 0, 0, time.UTC),
+		Location: loc,
+	}))
+
+	if date, _ := querySelector(t, win, `input[type="date"]`).GetAttribute("value"); date != "2025-01-14" {
+		t.Errorf("expected local date 2025-01-14, got %q", date)
+	}
+	if clock, _ := querySelector(t, win, `input[type="time"]`).GetAttribute("value"); clock != "21:30" {
+		t.Errorf("expected local time 21:30, got %q", clock)
+	}
+	if zone := querySelector(t, win, ".slds-no-flex"); zone == nil || zone.TextContent() != "EST" {
+		t.Error("expected the EST zone abbreviation")
+	}
+}
+
+// TestDateTimePickerTranslatesLabels verifies the date and time inputs are
+// labelled in the user's language.
+func TestDateTimePickerTranslatesLabels(t *testing.T) {
+	i18n.Register("de", i18n.Bundle{"Thunder_Date_Of": "{0} (Datum)", "Thunder_Time_Of": "{0} (Uhrzeit)"})
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage("en_US") })
+
+	win := renderComponent(t, DateTimePicker(DateTimePickerProps{Label: "Beginn", Location: time.UTC}))
+	if got, _ := querySelector(t, win, `input[type="date"]`).GetAttribute("aria-label"); got != "Beginn (Datum)" {
+		t.Errorf("expected a translated date label, got %q", got)
+	}
+	if got, _ := querySelector(t, win, `input[type="time"]`).GetAttribute("aria-label"); got != "Beginn (Uhrzeit)" {
+		t.Errorf("expected a translated time label, got %q", got)
+	}
+}
+
+// TestWithDateKeepsTimeOfDay verifies changing the date keeps the local time
+// of day and returns UTC.
+func TestWithDateKeepsTimeOfDay(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	value := time.Date(2025, 1, 15, 2, 30, 0, 0, time.UTC) // 21:30 EST on the 14th
+
+	if got, want := withDate(value, "2025-07-04", loc), time.Date(2025, 7, 5, 1, 30, 0, 0, time.UTC); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := withDate(time.Time{}, "2025-07-04", loc), time.Date(2025, 7, 4, 4, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected local midnight %v, got %v", want, got)
+	}
+	if got := withDate(value, "", loc); !got.IsZero() {
+		t.Errorf("expected clearing the date to give the zero time, got %v", got)
+	}
+}
+
+// TestWithClockUsesValueOrToday verifies setting the time keeps the date, or
+// uses today's date when there is none.
+func TestWithClockUsesValueOrToday(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	value := time.Date(2025, 1, 15, 2, 30, 0, 0, time.UTC)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if got, ok := withClock(value, "08:15", loc, now); !ok || !got.Equal(time.Date(2025, 1, 14, 13, 15, 0, 0, time.UTC)) {
+		t.Errorf("unexpected time %v", got)
+	}
+	if got, ok := withClock(time.Time{}, "08:15", loc, now); !ok || !got.Equal(time.Date(2025, 3, 1, 13, 15, 0, 0, time.UTC)) {
+		t.Errorf("expected today's date, got %v", got)
+	}
+	if _, ok := withClock(value, "", loc, now); ok {
+		t.Error("expected a blank time to be ignored")
+	}
+}
@@ -0,0 +1,75 @@
+package components
+
+import (
+	"strconv"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// DependentPicklistLevel is one field in a chain of dependent picklists.
+type DependentPicklistLevel struct {
+	Label string
+	// Picklist holds the field's values (see
+	// api.GetPicklistValuesByRecordType). Unused when Checkbox is set.
+	Picklist api.PicklistFieldValue
+	// Value is the current selection. Checkbox levels use "true"/"false".
+	Value string
+	// Checkbox renders the level as a checkbox controlling the next level.
+	Checkbox bool
+	// Validation holds the level's required flag and error state.
+	Validation ValidationState
+}
+
+// DependentSelect renders a chain of dependent picklists, such as a
+// controlling field and its dependent, or Region → Country → City. The first
+// level offers all its values; each later level only offers the values valid
+// for the level before it. The first level may be a checkbox.
+//
+// onChange receives every level's value after a change, with any selection no
+// longer valid for its controlling value cleared, so the levels always stay
+// consistent.
+func DependentSelect(levels []DependentPicklistLevel, onChange func(values []string)) masc.ComponentOrHTML {
+	var fields []masc.MarkupOrChild
+	for i, level := range levels {
+		i := i
+		set := func(value string) {
+			if onChange != nil {
+				onChange(reconcileLevels(levels, i, value))
+			}
+		}
+		if level.Checkbox {
+			fields = append(fields, Checkbox(level.Label, level.Value == "true", func(e *masc.Event) {
+				set(strconv.FormatBool(e.Target.Get("checked").Bool()))
+			}))
+			continue
+		}
+		values := level.Picklist.Values
+		if i > 0 {
+			values = level.Picklist.ValidValues(levels[i-1].Value)
+		}
+		options := append([]SelectOption{{Label: i18n.T("Thunder_None"), Value: ""}}, picklistOptions(values)...)
+		fields = append(fields, ValidatedSelect(level.Label, options, level.Value, level.Validation, func(e *masc.Event) {
+			set(e.Target.Get("value").String())
+		}))
+	}
+	return Container(fields...)
+}
+
+// reconcileLevels returns the level values after setting level changed to
+// value, clearing each later level whose value is not valid for the level
+// before it.
+func reconcileLevels(levels []DependentPicklistLevel, changed int, value string) []string {
+	values := make([]string, len(levels))
+	for i, level := range levels {
+		values[i] = level.Value
+	}
+	values[changed] = value
+	for i := changed + 1; i < len(levels); i++ {
+		if values[i] != "" && !levels[i].Picklist.IsValidFor(values[i], values[i-1]) {
+			values[i] = ""
+		}
+	}
+	return values
+}
@@ -0,0 +1,90 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/octoberswimmer/thunder/api"
+)
+
+var locationLevels = []DependentPicklistLevel{
+	{Label: "Region", Picklist: api.PicklistFieldValue{Values: []api.PicklistValue{
+		{Label: "Europe", Value: "EU"}, {Label: "Americas", Value: "AM"},
+	}}, Value: "EU"},
+	{Label: "Country", Picklist: api.PicklistFieldValue{
+		ControllerValues: map[string]int{"EU": 0, "AM": 1},
+		Values: []api.PicklistValue{
+			{Label: "France", Value: "FR", ValidFor: []int{0}},
+			{Label: "Canada", Value: "CA", ValidFor: []int{1}},
+		},
+	}, Value: "FR"},
+	{Label: "City", Picklist: api.PicklistFieldValue{
+		ControllerValues: map[string]int{"FR": 0, "CA": 1},
+		Values: []api.PicklistValue{
+			{Label: "Paris", Value: "Paris", ValidFor: []int{0}},
+			{Label: "Toronto", Value: "Toronto", ValidFor: []int{1}},
+		},
+	}, Value: "Paris"},
+}
+
+// TestDependentSelectFiltersLevels verifies each level only offers values
+// valid for the level before it.
+func TestDependentSelectFiltersLevels(t *testing.T) {
+	win := renderComponent(t, DependentSelect(locationLevels, nil))
+
+	selects, err := win.Document().QuerySelectorAll("select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := selects.Length(); got != 3 {
+		t.Fatalf("expected 3 selects, got %d", got)
+	}
+	options, err := win.Document().QuerySelectorAll("select option")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// --None-- plus 2 regions, 1 European country and 1 French city.
+	if got := options.Length(); got != 7 {
+		t.Errorf("expected 7 options, got %d", got)
+	}
+}
+
+// TestReconcileLevelsClearsInvalidSelections verifies changing a controlling
+// level clears dependent selections through every level.
+func TestReconcileLevelsClearsInvalidSelections(t *testing.T) {
+	got := reconcileLevels(locationLevels, 0, "AM")
+	if want := []string{"AM", "", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	got = reconcileLevels(locationLevels, 2, "")
+	if want := []string{"EU", "FR", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestDependentSelectCheckboxController verifies a checkbox can control the
+// next level.
+func TestDependentSelectCheckboxController(t *testing.T) {
+	levels := []DependentPicklistLevel{
+		{Label: "Partner", Checkbox: true, Value: "true"},
+		{Label: "Discount", Picklist: api.PicklistFieldValue{
+			ControllerValues: map[string]int{"false": 0, "true": 1},
+			Values: []api.PicklistValue{
+				{Label: "None", Value: "None", ValidFor: []int{0}},
+				{Label: "Partner", Value: "Partner", ValidFor: []int{1}},
+			},
+		}, Value: "Partner"},
+	}
+	if got := reconcileLevels(levels, 0, "false"); !reflect.DeepEqual(got, []string{"false", ""}) {
+		t.Errorf("expected the discount to be cleared, got %v", got)
+	}
+
+	win := renderComponent(t, DependentSelect(levels, nil))
+	checkbox, err := win.Document().QuerySelector("input[type=checkbox]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkbox == nil {
+		t.Error("expected a checkbox for the controlling level, got none")
+	}
+}
@@ -0,0 +1,173 @@
+package components
+
+import (
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// ConfirmDialogProps configures a ConfirmDialog.
+type ConfirmDialogProps struct {
+	// ID is returned in the result so an app can tell its dialogs apart.
+	ID      string
+	Title   string
+	Message string
+	// ConfirmLabel and CancelLabel default to "Confirm" and "Cancel".
+	ConfirmLabel string
+	CancelLabel  string
+	// Destructive styles the confirm button for actions such as deleting
+	// records.
+	Destructive bool
+}
+
+// ConfirmResultMsg reports the choice made in a ConfirmDialog.
+type ConfirmResultMsg struct {
+	ID        string
+	Confirmed bool
+}
+
+// ConfirmDialog renders a modal asking the user to confirm an action. The
+// choice is sent as a ConfirmResultMsg; closing the dialog, clicking the
+// backdrop or pressing Escape cancels. Hide the dialog when the result
+// arrives:
+//
+//	if m.confirmDelete {
+//		children = append(children, components.ConfirmDialog(components.ConfirmDialogProps{
+//			ID:           "delete",
+//			Title:        "Delete Account?",
+//			Message:      "This can't be undone.",
+//			ConfirmLabel: "Delete",
+//			Destructive:  true,
+//		}, send))
+//	}
+func ConfirmDialog(props ConfirmDialogProps, send func(masc.Msg)) masc.ComponentOrHTML {
+	confirmLabel := props.ConfirmLabel
+	if confirmLabel == "" {
+		confirmLabel = i18n.T("Thunder_Confirm")
+	}
+	cancelLabel := props.CancelLabel
+	if cancelLabel == "" {
+		cancelLabel = i18n.T("Thunder_Cancel")
+	}
+	confirmVariant := VariantBrand
+	if props.Destructive {
+		confirmVariant = VariantDestructive
+	}
+	result := func(confirmed bool) func(*masc.Event) {
+		return func(*masc.Event) {
+			send(ConfirmResultMsg{ID: props.ID, Confirmed: confirmed})
+		}
+	}
+	return ModalWithClose(props.Title, result(false),
+		elem.Paragraph(masc.Text(props.Message)),
+		Button(cancelLabel, VariantNeutral, result(false)),
+		Button(confirmLabel, confirmVariant, result(true)),
+	)
+}
+
+// PromptDialogProps configures a PromptDialog.
+type PromptDialogProps struct {
+	// ID is returned in the result so an app can tell its dialogs apart.
+	ID      string
+	Title   string
+	Message string
+	// Label is the text input's label.
+	Label string
+	// DefaultValue is the input's initial value.
+	DefaultValue string
+	Placeholder  string
+	// ConfirmLabel and CancelLabel default to "OK" and "Cancel".
+	ConfirmLabel string
+	CancelLabel  string
+}
+
+// PromptResultMsg reports the value entered in a PromptDialog. Value is
+// empty when the prompt was cancelled.
+type PromptResultMsg struct {
+	ID        string
+	Value     string
+	Confirmed bool
+}
+
+// PromptDialog renders a modal asking the user for a single text value. The
+// entered value is sent as a PromptResultMsg when the user clicks the
+// confirm button or presses Enter; closing the dialog, clicking the backdrop
+// or pressing Escape cancels.
+func PromptDialog(props PromptDialogProps, send func(masc.Msg)) masc.ComponentOrHTML {
+	return &promptDialog{Props: props, Send: send}
+}
+
+// promptDialog keeps the text typed into a PromptDialog until it is
+// submitted, so the app's model only receives the result.
+type promptDialog struct {
+	masc.Core
+
+	Props PromptDialogProps `masc:"prop"`
+	Send  func(masc.Msg)    `masc:"prop"`
+
+	value   string
+	started bool
+}
+
+func (p *promptDialog) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if !p.started {
+		p.started = true
+		p.value = p.Props.DefaultValue
+	}
+	confirmLabel := p.Props.ConfirmLabel
+	if confirmLabel == "" {
+		confirmLabel = i18n.T("Thunder_OK")
+	}
+	cancelLabel := p.Props.CancelLabel
+	if cancelLabel == "" {
+		cancelLabel = i18n.T("Thunder_Cancel")
+	}
+	submit := func(*masc.Event) {
+		p.Send(PromptResultMsg{ID: p.Props.ID, Value: p.value, Confirmed: true})
+	}
+	cancel := func(*masc.Event) {
+		p.Send(PromptResultMsg{ID: p.Props.ID})
+	}
+
+	var body []masc.MarkupOrChild
+	if p.Props.Message != "" {
+		body = append(body, elem.Paragraph(
+			masc.Markup(masc.Class("slds-m-bottom_small")),
+			masc.Text(p.Props.Message),
+		))
+	}
+	body = append(body, elem.Div(
+		masc.Markup(masc.Class("slds-form-element")),
+		elem.Label(
+			masc.Markup(masc.Class("slds-form-element__label")),
+			masc.Text(p.Props.Label),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-form-element__control")),
+			elem.Input(masc.Markup(
+				masc.Class("slds-input"),
+				masc.Property("type", "text"),
+				masc.Property("value", p.value),
+				masc.Property("placeholder", p.Props.Placeholder),
+				event.Input(func(e *masc.Event) {
+					p.value = e.Target.Get("value").String()
+				}),
+				event.KeyDown(func(e *masc.Event) {
+					if e.Value.Get("key").String() == "Enter" {
+						p.value = e.Target.Get("value").String()
+						submit(e)
+					}
+				}),
+			)),
+		),
+	))
+
+	return elem.Div(
+		ModalWithClose(p.Props.Title, cancel,
+			elem.Div(body...),
+			Button(cancelLabel, VariantNeutral, cancel),
+			Button(confirmLabel, VariantBrand, submit),
+		),
+	)
+}
@@ -0,0 +1,103 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// TestConfirmDialogSendsChoice verifies the confirm and cancel buttons send
+// the user's choice.
+func TestConfirmDialogSendsChoice(t *testing.T) {
+	var sent []masc.Msg
+	win := renderComponent(t, ConfirmDialog(ConfirmDialogProps{
+		ID:           "delete",
+		Title:        "Delete Account?",
+		Message:      "This can't be undone.",
+		ConfirmLabel: "Delete",
+		Destructive:  true,
+	}, func(msg masc.Msg) { sent = append(sent, msg) }))
+
+	confirm := querySelector(t, win, ".slds-modal__footer .slds-button_destructive")
+	if confirm == nil || confirm.TextContent() != "Delete" {
+		t.Fatal("expected a destructive Delete button")
+	}
+	confirm.(html.HTMLElement).Click()
+	querySelector(t, win, ".slds-modal__footer .slds-button_neutral").(html.HTMLElement).Click()
+	querySelector(t, win, ".slds-backdrop").(html.HTMLElement).Click()
+
+	want := []masc.Msg{
+		ConfirmResultMsg{ID: "delete", Confirmed: true},
+		ConfirmResultMsg{ID: "delete"},
+		ConfirmResultMsg{ID: "delete"},
+	}
+	if len(sent) != len(want) {
+		t.Fatalf("expected %v, got %v", want, sent)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Errorf("message %d: expected %v, got %v", i, want[i], sent[i])
+		}
+	}
+}
+
+// TestConfirmDialogLabelsHeading verifies the dialog is labelled by its
+// heading.
+func TestConfirmDialogLabelsHeading(t *testing.T) {
+	win := renderComponent(t, ConfirmDialog(ConfirmDialogProps{Title: "Discard changes?"}, func(masc.Msg) {}))
+	dialog := querySelector(t, win, "[role=dialog]")
+	id, _ := dialog.GetAttribute("aria-labelledby")
+	heading := querySelector(t, win, "h2#"+id)
+	if heading == nil || heading.TextContent() != "Discard changes?" {
+		t.Errorf("expected aria-labelledby to reference the heading, got %q", id)
+	}
+	if label := querySelector(t, win, ".slds-button_brand"); label == nil || label.TextContent() != "Confirm" {
+		t.Error("expected default Confirm button")
+	}
+}
+
+// TestPromptDialogSendsValue verifies the prompt returns its value when
+// confirmed and nothing when cancelled.
+func TestPromptDialogSendsValue(t *testing.T) {
+	var sent []masc.Msg
+	win := renderComponent(t, PromptDialog(PromptDialogProps{
+		ID:           "rename",
+		Title:        "Rename",
+		Label:        "Name",
+		DefaultValue: "Acme",
+	}, func(msg masc.Msg) { sent = append(sent, msg) }))
+
+	if input := querySelector(t, win, "input.slds-input"); input == nil {
+		t.Fatal("expected a text input")
+	}
+	querySelector(t, win, ".slds-button_brand").(html.HTMLElement).Click()
+	querySelector(t, win, ".slds-modal__close").(html.HTMLElement).Click()
+
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 messages, got %v", sent)
+	}
+	if want := (PromptResultMsg{ID: "rename", Value: "Acme", Confirmed: true}); sent[0] != want {
+		t.Errorf("expected %v, got %v", want, sent[0])
+	}
+	if want := (PromptResultMsg{ID: "rename"}); sent[1] != want {
+		t.Errorf("expected %v, got %v", want, sent[1])
+	}
+}
+
+// TestConfirmDialogTranslatesDefaultLabels verifies the default button labels
+// follow the user's language.
+func TestConfirmDialogTranslatesDefaultLabels(t *testing.T) {
+	i18n.Register("de", i18n.Bundle{"Thunder_Confirm": "Bestätigen", "Thunder_Cancel": "Abbrechen"})
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage("en_US") })
+
+	win := renderComponent(t, ConfirmDialog(ConfirmDialogProps{Title: "Discard changes?"}, func(masc.Msg) {}))
+	if confirm := querySelector(t, win, ".slds-button_brand"); confirm == nil || confirm.TextContent() != "Bestätigen" {
+		t.Error("expected a translated Confirm button")
+	}
+	if cancel := querySelector(t, win, ".slds-modal__footer .slds-button_neutral"); cancel == nil || cancel.TextContent() != "Abbrechen" {
+		t.Error("expected a translated Cancel button")
+	}
+}
@@ -0,0 +1,189 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// DualListboxProps configures a DualListbox.
+type DualListboxProps struct {
+	Label string
+	// SourceLabel and SelectedLabel head the two lists. They default to
+	// "Available" and "Selected".
+	SourceLabel   string
+	SelectedLabel string
+	// Options are all the values that can be chosen.
+	Options []SelectOption
+	// Selected holds the chosen values; see api.SplitMultiSelect.
+	Selected []string
+	// Highlighted holds the values clicked in either list, which the move
+	// buttons act on.
+	Highlighted []string
+	// Size is the number of options visible in each list before it scrolls.
+	// Defaults to 6.
+	Size int
+
+	// OnHighlight receives the highlighted values after an option is clicked.
+	OnHighlight func(highlighted []string)
+	// OnChange receives the selected values, in option order, after options
+	// are moved; see api.JoinMultiSelect.
+	OnChange func(selected []string)
+}
+
+// DualListbox renders an SLDS dual listbox (dueling picklist) for choosing
+// several values, such as a multi-select picklist field. Clicking an option
+// highlights it; the buttons between the lists move the highlighted options
+// across, and double-clicking moves a single option.
+func DualListbox(props DualListboxProps) masc.ComponentOrHTML {
+	return ValidatedDualListbox(props, ValidationState{})
+}
+
+// ValidatedDualListbox renders a DualListbox with validation support.
+func ValidatedDualListbox(props DualListboxProps, validation ValidationState) masc.ComponentOrHTML {
+	sourceLabel := props.SourceLabel
+	if sourceLabel == "" {
+		sourceLabel = "Available"
+	}
+	selectedLabel := props.SelectedLabel
+	if selectedLabel == "" {
+		selectedLabel = "Selected"
+	}
+
+	var available, chosen []SelectOption
+	for _, opt := range props.Options {
+		if slices.Contains(props.Selected, opt.Value) {
+			chosen = append(chosen, opt)
+		} else {
+			available = append(available, opt)
+		}
+	}
+
+	// move selects or deselects the given values, keeping option order.
+	move := func(values []string, selecting bool) {
+		if props.OnChange == nil || len(values) == 0 {
+			return
+		}
+		var next []string
+		for _, opt := range props.Options {
+			isSelected := slices.Contains(props.Selected, opt.Value)
+			moving := slices.Contains(values, opt.Value)
+			if selecting && (isSelected || moving) || !selecting && isSelected && !moving {
+				next = append(next, opt.Value)
+			}
+		}
+		props.OnChange(next)
+	}
+	highlightedIn := func(options []SelectOption) []string {
+		var values []string
+		for _, opt := range options {
+			if slices.Contains(props.Highlighted, opt.Value) {
+				values = append(values, opt.Value)
+			}
+		}
+		return values
+	}
+
+	control := elem.Div(
+		masc.Markup(masc.Class("slds-dueling-list")),
+		dualListboxColumn(props, sourceLabel, available, func(value string) { move([]string{value}, true) }),
+		elem.Div(
+			masc.Markup(masc.Class("slds-dueling-list__column")),
+			dualListboxButton(i18n.T("Thunder_Move_Selection_To", selectedLabel), "→", func() { move(highlightedIn(available), true) }),
+			dualListboxButton(i18n.T("Thunder_Move_Selection_To", sourceLabel), "←", func() { move(highlightedIn(chosen), false) }),
+		),
+		dualListboxColumn(props, selectedLabel, chosen, func(value string) { move([]string{value}, false) }),
+	)
+
+	return validatedFormElement(props.Label, validation, control)
+}
+
+// dualListboxColumn renders one of the two lists of a DualListbox.
+func dualListboxColumn(props DualListboxProps, heading string, options []SelectOption, onMove func(string)) masc.ComponentOrHTML {
+	size := props.Size
+	if size <= 0 {
+		size = 6
+	}
+	items := []masc.MarkupOrChild{
+		masc.Markup(
+			masc.Class("slds-listbox", "slds-listbox_vertical"),
+			masc.Attribute("role", "listbox"),
+			masc.Attribute("aria-label", heading),
+			masc.Attribute("aria-multiselectable", "true"),
+		),
+	}
+	for _, opt := range options {
+		highlighted := slices.Contains(props.Highlighted, opt.Value)
+		optionClasses := []string{"slds-listbox__option", "slds-listbox__option_plain", "slds-media", "slds-media_small", "slds-media_inline"}
+		if highlighted {
+			optionClasses = append(optionClasses, "slds-is-selected")
+		}
+		items = append(items, elem.ListItem(
+			masc.Markup(
+				masc.Class("slds-listbox__item"),
+				masc.Attribute("role", "presentation"),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class(optionClasses...),
+					masc.Attribute("role", "option"),
+					masc.Attribute("aria-selected", fmt.Sprint(highlighted)),
+					masc.Attribute("tabindex", "0"),
+					masc.Data("value", opt.Value),
+					event.Click(func(e *masc.Event) {
+						if props.OnHighlight != nil {
+							props.OnHighlight(toggleKey(props.Highlighted, opt.Value))
+						}
+					}),
+					event.DoubleClick(func(e *masc.Event) {
+						onMove(opt.Value)
+					}),
+				),
+				elem.Span(
+					masc.Markup(masc.Class("slds-media__body")),
+					elem.Span(
+						masc.Markup(masc.Class("slds-truncate"), masc.Property("title", opt.Label)),
+						masc.Text(opt.Label),
+					),
+				),
+			),
+		))
+	}
+	return elem.Div(
+		masc.Markup(masc.Class("slds-dueling-list__column")),
+		elem.Span(
+			masc.Markup(masc.Class("slds-form-element__label")),
+			masc.Text(heading),
+		),
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-dueling-list__options"),
+				masc.Style("height", fmt.Sprintf("%.2frem", float64(size)*2.25)),
+				masc.Style("overflow-y", "auto"),
+			),
+			elem.UnorderedList(items...),
+		),
+	)
+}
+
+// dualListboxButton renders a move button between the lists of a
+// DualListbox.
+func dualListboxButton(title, symbol string, onClick func()) masc.ComponentOrHTML {
+	return elem.Button(
+		masc.Markup(
+			masc.Class("slds-button", "slds-button_icon", "slds-button_icon-container"),
+			masc.Attribute("type", "button"),
+			masc.Property("title", title),
+			event.Click(func(e *masc.Event) { onClick() }),
+		),
+		masc.Text(symbol),
+		elem.Span(
+			masc.Markup(masc.Class("slds-assistive-text")),
+			masc.Text(title),
+		),
+	)
+}
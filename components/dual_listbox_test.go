@@ -0,0 +1,84 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+)
+
+var colorOptions = []SelectOption{
+	{Label: "Red", Value: "Red"},
+	{Label: "Green", Value: "Green"},
+	{Label: "Blue", Value: "Blue"},
+}
+
+// TestDualListboxSplitsOptions verifies selected options are listed in the
+// Selected column and the rest in Available.
+func TestDualListboxSplitsOptions(t *testing.T) {
+	win := renderComponent(t, DualListbox(DualListboxProps{
+		Label:    "Colors",
+		Options:  colorOptions,
+		Selected: []string{"Blue"},
+	}))
+
+	available, err := win.Document().QuerySelectorAll("ul[aria-label=Available] [role=option]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := available.Length(); got != 2 {
+		t.Errorf("expected 2 available options, got %d", got)
+	}
+	selected, err := win.Document().QuerySelectorAll("ul[aria-label=Selected] [role=option]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := selected.Length(); got != 1 {
+		t.Errorf("expected 1 selected option, got %d", got)
+	}
+}
+
+// TestDualListboxHighlightAndMove verifies clicking an option highlights it
+// and the move button selects the highlighted options in option order.
+func TestDualListboxHighlightAndMove(t *testing.T) {
+	var highlighted, changed []string
+	win := renderComponent(t, DualListbox(DualListboxProps{
+		Label:       "Colors",
+		Options:     colorOptions,
+		Selected:    []string{"Blue"},
+		Highlighted: []string{"Green"},
+		OnHighlight: func(v []string) { highlighted = v },
+		OnChange:    func(v []string) { changed = v },
+	}))
+
+	querySelector(t, win, "[data-value=Red]").(html.HTMLElement).Click()
+	if want := []string{"Green", "Red"}; !reflect.DeepEqual(highlighted, want) {
+		t.Errorf("expected highlight %v, got %v", want, highlighted)
+	}
+
+	querySelector(t, win, "button[title='Move selection to Selected']").(html.HTMLElement).Click()
+	if want := []string{"Green", "Blue"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected selection %v, got %v", want, changed)
+	}
+}
+
+// TestDualListboxRemove verifies highlighted selected options move back to
+// Available.
+func TestDualListboxRemove(t *testing.T) {
+	var changed []string
+	win := renderComponent(t, ValidatedDualListbox(DualListboxProps{
+		Label:       "Colors",
+		Options:     colorOptions,
+		Selected:    []string{"Red", "Blue"},
+		Highlighted: []string{"Red"},
+		OnChange:    func(v []string) { changed = v },
+	}, ValidationState{Required: true, HasError: true, ErrorMessage: "Select a color"}))
+
+	querySelector(t, win, "button[title='Move selection to Available']").(html.HTMLElement).Click()
+	if want := []string{"Blue"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected selection %v, got %v", want, changed)
+	}
+	if el := querySelector(t, win, ".slds-form-element__help"); el == nil || el.TextContent() != "Select a color" {
+		t.Error("expected error message")
+	}
+}
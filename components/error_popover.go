@@ -0,0 +1,200 @@
+package components
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// PageError is a problem listed in an ErrorPopover.
+type PageError struct {
+	// Field is the API name of the field the error applies to. It links the
+	// error to the form element marked with that field (see
+	// ValidationState.Field); leave it empty for errors about the whole
+	// record.
+	Field string
+	// Label is the field's label, shown as the link.
+	Label   string
+	Message string
+}
+
+// PageErrors converts save errors (see api.SaveErrors) into PageErrors,
+// labelling each field with labels[field] and falling back to its API name.
+// An error naming several fields is listed once per field.
+func PageErrors(errs []api.SaveError, labels map[string]string) []PageError {
+	var page []PageError
+	for _, se := range errs {
+		if len(se.Fields) == 0 {
+			page = append(page, PageError{Message: se.Message})
+			continue
+		}
+		for _, f := range se.Fields {
+			label := labels[f]
+			if label == "" {
+				label = f
+			}
+			page = append(page, PageError{Field: f, Label: label, Message: se.Message})
+		}
+	}
+	return page
+}
+
+// fieldTarget marks a form element as the input for field, for the links of
+// an ErrorPopover.
+func fieldTarget(field string) masc.Applyer {
+	return masc.MarkupIf(field != "", masc.Data("field", field))
+}
+
+// FieldLabels returns the label of each of an object's fields, by API name,
+// for PageErrors.
+func FieldLabels(info api.ObjectInfo) map[string]string {
+	labels := make(map[string]string, len(info.Fields))
+	for name, field := range info.Fields {
+		labels[name] = field.Label
+	}
+	return labels
+}
+
+// WithError returns state showing message as an error, e.g. a field's error
+// from api.FieldErrors after a failed save. An empty message leaves state
+// unchanged.
+func WithError(state ValidationState, message string) ValidationState {
+	if message != "" {
+		state.HasError = true
+		state.ErrorMessage = message
+	}
+	return state
+}
+
+// ErrorPopover renders an SLDS error popover listing every problem with a
+// form, typically next to its Save button after a failed save. Each field
+// error links to its field: clicking the link scrolls to the form element
+// marked with the error's Field, in the nearest part of the page containing
+// one, and focuses its input. onClose, if set, adds a close button.
+func ErrorPopover(errors []PageError, onClose func()) masc.ComponentOrHTML {
+	if len(errors) == 0 {
+		return nil
+	}
+	return &errorPopoverComponent{Errors: errors, OnClose: onClose}
+}
+
+// errorPopoverIDs numbers error popovers so each can reference its heading
+// and body.
+var errorPopoverIDs atomic.Int64
+
+// errorPopoverComponent is the implementation behind ErrorPopover. It is a
+// component so its generated ids survive re-renders.
+type errorPopoverComponent struct {
+	masc.Core
+
+	Errors  []PageError `masc:"prop"`
+	OnClose func()      `masc:"prop"`
+
+	id string
+}
+
+func (c *errorPopoverComponent) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if c.id == "" {
+		c.id = fmt.Sprintf("error-popover-%d", errorPopoverIDs.Add(1))
+	}
+	errors, onClose := c.Errors, c.OnClose
+	headingID, bodyID := c.id+"-heading", c.id+"-body"
+	heading := i18n.T("Thunder_Resolve_Error")
+	if len(errors) > 1 {
+		heading = i18n.T("Thunder_Resolve_Errors", len(errors))
+	}
+
+	items := []masc.MarkupOrChild{masc.Markup(masc.Class("slds-list_vertical-space"))}
+	for _, pe := range errors {
+		var content []masc.MarkupOrChild
+		content = append(content, masc.Markup(masc.Class("slds-item")))
+		switch {
+		case pe.Field != "":
+			field := pe.Field
+			label := pe.Label
+			if label == "" {
+				label = field
+			}
+			content = append(content, elem.Anchor(
+				masc.Markup(
+					masc.Class("slds-text-title_bold"),
+					masc.Attribute("href", "javascript:void(0);"),
+					event.Click(func(e *masc.Event) {
+						focusFormField(e, field)
+					}),
+				),
+				masc.Text(label),
+			))
+		case pe.Label != "":
+			content = append(content, elem.Paragraph(
+				masc.Markup(masc.Class("slds-text-title_bold")),
+				masc.Text(pe.Label),
+			))
+		}
+		content = append(content, elem.Paragraph(masc.Text(pe.Message)))
+		items = append(items, elem.ListItem(content...))
+	}
+
+	var closeButton masc.ComponentOrHTML
+	if onClose != nil {
+		closeButton = elem.Button(
+			masc.Markup(
+				masc.Class("slds-button", "slds-button_icon", "slds-button_icon-small", "slds-float_right", "slds-popover__close", "slds-button_icon-inverse"),
+				masc.Attribute("title", i18n.T("Thunder_Close_Dialog")),
+				masc.Attribute("type", "button"),
+				event.Click(func(e *masc.Event) { onClose() }),
+			),
+			masc.Text("✕"),
+			elem.Span(
+				masc.Markup(masc.Class("slds-assistive-text")),
+				masc.Text(i18n.T("Thunder_Close_Dialog")),
+			),
+		)
+	}
+
+	return elem.Section(
+		masc.Markup(
+			masc.Class("slds-popover", "slds-popover_error", "slds-nubbin_bottom-left"),
+			masc.Attribute("role", "dialog"),
+			masc.Attribute("aria-labelledby", headingID),
+			masc.Attribute("aria-describedby", bodyID),
+		),
+		closeButton,
+		elem.Header(
+			masc.Markup(masc.Class("slds-popover__header")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-media", "slds-media_center", "slds-has-flexi-truncate")),
+				elem.Div(
+					masc.Markup(masc.Class("slds-media__figure")),
+					elem.Span(
+						masc.Markup(masc.Class("slds-icon_container", "slds-icon-utility-error")),
+						Icon(UtilityIcon, "error", IconSmall),
+					),
+				),
+				elem.Div(
+					masc.Markup(masc.Class("slds-media__body")),
+					elem.Heading2(
+						masc.Markup(
+							masc.Class("slds-truncate", "slds-text-heading_medium"),
+							masc.Property("id", headingID),
+							masc.Property("title", heading),
+						),
+						masc.Text(heading),
+					),
+				),
+			),
+		),
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-popover__body"),
+				masc.Property("id", bodyID),
+			),
+			elem.UnorderedList(items...),
+		),
+	)
+}
@@ -0,0 +1,30 @@
+//go:build js
+
+package components
+
+import (
+	"github.com/octoberswimmer/masc"
+)
+
+// focusFormField scrolls to the form element marked with field and focuses
+// its first control. The element is looked up from the clicked link outwards,
+// so a popover links to the fields of its own form when a page has several.
+func focusFormField(e *masc.Event, field string) {
+	if e == nil || !e.Target.Truthy() {
+		return
+	}
+	selector := `[data-field="` + field + `"]`
+	for scope := e.Target; scope.Truthy(); scope = scope.Get("parentElement") {
+		el := scope.Call("querySelector", selector)
+		if !el.Truthy() {
+			continue
+		}
+		control := el.Call("querySelector", "input, select, textarea, button, [contenteditable=true]")
+		if !control.Truthy() {
+			control = el
+		}
+		control.Call("scrollIntoView", map[string]interface{}{"block": "center"})
+		control.Call("focus")
+		return
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+// focusFormField is a no-op outside the browser, where there is no focus to
+// move.
+func focusFormField(e *masc.Event, field string) {}
@@ -0,0 +1,99 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// TestPageErrorsLabelsFields verifies save errors are listed once per field
+// under the field's label.
+func TestPageErrorsLabelsFields(t *testing.T) {
+	errs := []api.SaveError{
+		{Message: "Required fields are missing: [Name, CloseDate]", Fields: []string{"Name", "CloseDate"}},
+		{Message: "Record is locked"},
+	}
+	got := PageErrors(errs, map[string]string{"Name": "Opportunity Name"})
+	want := []PageError{
+		{Field: "Name", Label: "Opportunity Name", Message: "Required fields are missing: [Name, CloseDate]"},
+		{Field: "CloseDate", Label: "CloseDate", Message: "Required fields are missing: [Name, CloseDate]"},
+		{Message: "Record is locked"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("error %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+// TestErrorPopoverListsErrors verifies the popover links field errors and
+// closes.
+func TestErrorPopoverListsErrors(t *testing.T) {
+	closed := false
+	win := renderComponent(t, ErrorPopover([]PageError{
+		{Field: "Name", Label: "Name", Message: "Name is required"},
+		{Message: "Record is locked"},
+	}, func() { closed = true }))
+
+	headingID, _ := querySelector(t, win, ".slds-popover").GetAttribute("aria-labelledby")
+	heading := querySelector(t, win, "#"+headingID)
+	if heading == nil || heading.TextContent() != "Resolve 2 errors" {
+		t.Error("expected a heading counting the errors")
+	}
+	link := querySelector(t, win, ".slds-popover__body a")
+	if link == nil || link.TextContent() != "Name" {
+		t.Fatal("expected a link to the Name field")
+	}
+	querySelector(t, win, ".slds-popover__close").(html.HTMLElement).Click()
+	if !closed {
+		t.Error("expected onClose to be called")
+	}
+}
+
+// TestErrorPopoverUniqueIDs verifies popovers on the same page reference their
+// own heading and body.
+func TestErrorPopoverUniqueIDs(t *testing.T) {
+	errs := []PageError{{Message: "Record is locked"}}
+	win := renderComponent(t, elem.Div(ErrorPopover(errs, nil), ErrorPopover(errs, nil)))
+
+	popovers, err := win.Document().QuerySelectorAll(".slds-popover")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if popovers.Length() != 2 {
+		t.Fatalf("expected 2 popovers, got %d", popovers.Length())
+	}
+	first, _ := popovers.Item(0).(html.HTMLElement).GetAttribute("aria-labelledby")
+	second, _ := popovers.Item(1).(html.HTMLElement).GetAttribute("aria-labelledby")
+	if first == "" || first == second {
+		t.Errorf("expected distinct heading ids, got %q and %q", first, second)
+	}
+	if body, _ := popovers.Item(1).(html.HTMLElement).GetAttribute("aria-describedby"); querySelector(t, win, "#"+body+".slds-popover__body") == nil {
+		t.Errorf("expected aria-describedby to reference the popover body, got %q", body)
+	}
+}
+
+// TestValidationStateMarksField verifies form elements carry the field API
+// name that ErrorPopover links target.
+func TestValidationStateMarksField(t *testing.T) {
+	win := renderComponent(t, ValidatedTextInput("Opportunity Name", "", ValidationState{Field: "Name"}, nil))
+	if querySelector(t, win, `.slds-form-element[data-field="Name"] input`) == nil {
+		t.Error("expected the form element to be marked with its field")
+	}
+}
+
+// TestWithError verifies a save error is shown on a ValidationState.
+func TestWithError(t *testing.T) {
+	state := WithError(Required(), "Name already exists")
+	if !state.HasError || !state.Required || state.ErrorMessage != "Name already exists" {
+		t.Errorf("unexpected state %+v", state)
+	}
+	if WithError(Required(), "") != Required() {
+		t.Error("expected an empty message to leave the state unchanged")
+	}
+}
@@ -0,0 +1,192 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// FileSelectorProps configures a FileSelector.
+type FileSelectorProps struct {
+	Label string
+	// Accept lists the file types that can be selected, as in a file input's
+	// accept attribute: MIME types such as "image/*" and extensions such as
+	// ".pdf", separated by commas. Empty accepts any file.
+	Accept   string
+	Multiple bool
+	Disabled bool
+	// MaxSize is the largest file, in bytes, that can be selected. Larger
+	// files are rejected without being read. Zero allows any size.
+	MaxSize int64
+	// OnFiles receives the chosen or dropped files with their contents,
+	// ready for api.UploadFiles. Files that don't match Accept are left out.
+	OnFiles func(files []api.File)
+	// OnError receives the files that were larger than MaxSize or could not
+	// be read, each with a message to show the user.
+	OnError func(errs []FileError)
+}
+
+// FileError reports a file a FileSelector could not accept.
+type FileError struct {
+	Name    string
+	Message string
+}
+
+// FileSelector renders an SLDS file selector: a button opening the browser's
+// file picker and a dropzone that files can be dragged onto.
+func FileSelector(props FileSelectorProps) masc.ComponentOrHTML {
+	return ValidatedFileSelector(props, ValidationState{})
+}
+
+// ValidatedFileSelector renders a FileSelector with validation state.
+func ValidatedFileSelector(props FileSelectorProps, validation ValidationState) masc.ComponentOrHTML {
+	return &fileSelector{Props: props, Validation: validation}
+}
+
+// fileSelectorIDs numbers file selectors so their label can point at their
+// input.
+var fileSelectorIDs atomic.Int64
+
+type fileSelector struct {
+	masc.Core
+
+	Props      FileSelectorProps `masc:"prop"`
+	Validation ValidationState   `masc:"prop"`
+
+	id string
+}
+
+func (f *fileSelector) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if f.id == "" {
+		f.id = fmt.Sprintf("file-selector-%d", fileSelectorIDs.Add(1))
+	}
+
+	inputMarkup := []masc.Applyer{
+		masc.Class("slds-file-selector__input", "slds-assistive-text"),
+		masc.Property("type", "file"),
+		masc.Property("id", f.id),
+		masc.Attribute("aria-labelledby", f.id+"-body"),
+	}
+	if f.Props.Accept != "" {
+		inputMarkup = append(inputMarkup, masc.Attribute("accept", f.Props.Accept))
+	}
+	if f.Props.Multiple {
+		inputMarkup = append(inputMarkup, masc.Attribute("multiple", "true"))
+	}
+	// The browser opens a file dropped anywhere else on the page, so drops
+	// are always handled, and ignored while disabled.
+	disabled := f.Props.Disabled
+	zoneMarkup := []masc.Applyer{
+		masc.Class("slds-file-selector__dropzone"),
+		event.DragOver(func(*masc.Event) {}).PreventDefault(),
+		event.Drop(func(e *masc.Event) {
+			if disabled {
+				return
+			}
+			setDragOver(e, false)
+			readEventFiles(e, true, f.Props.MaxSize, f.received)
+		}).PreventDefault(),
+	}
+	if disabled {
+		inputMarkup = append(inputMarkup, masc.Attribute("disabled", "true"))
+	} else {
+		inputMarkup = append(inputMarkup, event.Change(func(e *masc.Event) {
+			readEventFiles(e, false, f.Props.MaxSize, f.received)
+		}))
+		zoneMarkup = append(zoneMarkup,
+			event.DragEnter(func(e *masc.Event) { setDragOver(e, true) }),
+			event.DragLeave(func(e *masc.Event) { setDragOver(e, false) }),
+		)
+	}
+
+	selector := elem.Div(
+		masc.Markup(masc.Class("slds-file-selector", "slds-file-selector_files")),
+		elem.Div(
+			masc.Markup(zoneMarkup...),
+			elem.Input(masc.Markup(inputMarkup...)),
+			elem.Label(
+				masc.Markup(
+					masc.Class("slds-file-selector__body"),
+					masc.Attribute("for", f.id),
+					masc.Property("id", f.id+"-body"),
+				),
+				elem.Span(
+					masc.Markup(masc.Class("slds-file-selector__button", "slds-button", "slds-button_neutral")),
+					elem.Span(masc.Markup(masc.UnsafeHTML(iconSVG([]string{"slds-button__icon", "slds-button__icon_left"}, UtilityIcon, "upload")))),
+					masc.Text(i18n.T("Thunder_Upload_Files")),
+				),
+				elem.Span(
+					masc.Markup(masc.Class("slds-file-selector__text", "slds-medium-show")),
+					masc.Text(i18n.T("Thunder_Or_Drop_Files")),
+				),
+			),
+		),
+	)
+
+	return validatedFormElement(f.Props.Label, f.Validation, selector)
+}
+
+// received passes the files read from the input or dropzone that match
+// Accept to OnFiles, keeping only the first unless Multiple is set, and the
+// files that were rejected or failed to read to OnError.
+func (f *fileSelector) received(files []api.File, failed []FileError) {
+	var accepted []api.File
+	for _, file := range files {
+		if acceptsFile(f.Props.Accept, file.Name, file.Type) {
+			accepted = append(accepted, file)
+		}
+	}
+	if !f.Props.Multiple && len(accepted) > 1 {
+		accepted = accepted[:1]
+	}
+	if len(accepted) > 0 && f.Props.OnFiles != nil {
+		f.Props.OnFiles(accepted)
+	}
+	if len(failed) > 0 && f.Props.OnError != nil {
+		f.Props.OnError(failed)
+	}
+}
+
+// fileTooLarge reports a file rejected for being larger than maxSize.
+func fileTooLarge(name string, maxSize int64) FileError {
+	return FileError{Name: name, Message: i18n.T("Thunder_File_Too_Large", name, formatFileSize(maxSize))}
+}
+
+// fileUnreadable reports a file the browser could not read.
+func fileUnreadable(name string) FileError {
+	return FileError{Name: name, Message: i18n.T("Thunder_File_Unreadable", name)}
+}
+
+// acceptsFile reports whether a file matches an accept attribute. The
+// browser's picker applies it, but dropped files and the picker's "All
+// files" option are not filtered.
+func acceptsFile(accept, name, mimeType string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+	name = strings.ToLower(name)
+	mimeType = strings.ToLower(mimeType)
+	for _, t := range strings.Split(accept, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch {
+		case t == "":
+		case strings.HasPrefix(t, "."):
+			if strings.HasSuffix(name, t) {
+				return true
+			}
+		case strings.HasSuffix(t, "/*"):
+			if strings.HasPrefix(mimeType, strings.TrimSuffix(t, "*")) {
+				return true
+			}
+		case mimeType == t:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+//go:build js
+
+package components
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// readEventFiles reads the files chosen in a file input, or dropped on a
+// dropzone, and passes them to onFiles once all have been read, with the
+// files larger than maxSize, which are not read, and those that failed.
+func readEventFiles(e *masc.Event, dropped bool, maxSize int64, onFiles func([]api.File, []FileError)) {
+	if e == nil || !e.Value.Truthy() {
+		return
+	}
+	var list js.Value
+	if dropped {
+		transfer := e.Value.Get("dataTransfer")
+		if !transfer.Truthy() {
+			return
+		}
+		list = transfer.Get("files")
+	} else {
+		list = e.Target.Get("files")
+	}
+	if !list.Truthy() || list.Length() == 0 {
+		return
+	}
+	// The list is emptied when the event ends, and clearing the input lets
+	// the same file be chosen again.
+	files := make([]js.Value, list.Length())
+	for i := range files {
+		files[i] = list.Index(i)
+	}
+	if !dropped {
+		e.Target.Set("value", "")
+	}
+
+	go func() {
+		read := make([]api.File, 0, len(files))
+		var failed []FileError
+		for _, file := range files {
+			name := file.Get("name").String()
+			if maxSize > 0 && int64(file.Get("size").Float()) > maxSize {
+				failed = append(failed, fileTooLarge(name, maxSize))
+				continue
+			}
+			data, err := readFile(file)
+			if err != nil {
+				failed = append(failed, fileUnreadable(name))
+				continue
+			}
+			read = append(read, api.File{Name: name, Type: file.Get("type").String(), Data: data})
+		}
+		onFiles(read, failed)
+	}()
+}
+
+// readFile waits for the contents of a browser File.
+func readFile(file js.Value) ([]byte, error) {
+	dataCh := make(chan js.Value, 1)
+	errCh := make(chan error, 1)
+	then := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		dataCh <- args[0]
+		return nil
+	})
+	defer then.Release()
+	catch := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		errCh <- errors.New(args[0].Call("toString").String())
+		return nil
+	})
+	defer catch.Release()
+
+	file.Call("arrayBuffer").Call("then", then, catch)
+	select {
+	case buf := <-dataCh:
+		u8 := js.Global().Get("Uint8Array").New(buf)
+		data := make([]byte, u8.Length())
+		js.CopyBytesToGo(data, u8)
+		return data, nil
+	case err := <-errCh:
+		return nil, err
+	}
+}
+
+// setDragOver highlights the dropzone while files are dragged over it.
+// Moving between the dropzone's own elements is not leaving it.
+func setDragOver(e *masc.Event, over bool) {
+	if e == nil || !e.Value.Truthy() {
+		return
+	}
+	zone := e.Value.Get("currentTarget")
+	if !zone.Truthy() {
+		return
+	}
+	if related := e.Value.Get("relatedTarget"); !over && related.Truthy() && zone.Call("contains", related).Bool() {
+		return
+	}
+	zone.Get("classList").Call("toggle", "slds-has-drag-over", over)
+}
@@ -0,0 +1,16 @@
+//go:build !js
+
+package components
+
+import (
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// Files are read and the dropzone highlighted through the browser's file
+// and drag and drop APIs; outside the browser these are no-ops.
+
+func readEventFiles(e *masc.Event, dropped bool, maxSize int64, onFiles func([]api.File, []FileError)) {
+}
+
+func setDragOver(e *masc.Event, over bool) {}
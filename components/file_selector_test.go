@@ -0,0 +1,167 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// TestFileSelector verifies the SLDS file selector markup, with the label
+// pointing at the file input.
+func TestFileSelector(t *testing.T) {
+	win := renderComponent(t, FileSelector(FileSelectorProps{
+		Label:    "Attachments",
+		Accept:   "image/*,.pdf",
+		Multiple: true,
+	}))
+	if label := querySelector(t, win, ".slds-form-element__label"); label == nil || label.TextContent() != "Attachments" {
+		t.Error("expected the Attachments label")
+	}
+	input := querySelector(t, win, ".slds-file-selector.slds-file-selector_files .slds-file-selector__dropzone input.slds-file-selector__input[type=file]")
+	if input == nil {
+		t.Fatal("expected a file input in the dropzone")
+	}
+	if accept, _ := input.GetAttribute("accept"); accept != "image/*,.pdf" {
+		t.Errorf("expected accept image/*,.pdf, got %q", accept)
+	}
+	if _, ok := input.GetAttribute("multiple"); !ok {
+		t.Error("expected a multiple file input")
+	}
+	if _, ok := input.GetAttribute("disabled"); ok {
+		t.Error("expected an enabled file input")
+	}
+	body := querySelector(t, win, "label.slds-file-selector__body")
+	if body == nil {
+		t.Fatal("expected the selector body label")
+	}
+	id, _ := input.GetAttribute("id")
+	if target, _ := body.GetAttribute("for"); id == "" || target != id {
+		t.Errorf("expected the label for %q, got %q", id, target)
+	}
+	button := querySelector(t, win, ".slds-file-selector__button.slds-button_neutral")
+	if button == nil || button.TextContent() != "Upload Files" {
+		t.Error("expected the Upload Files button")
+	}
+	if button != nil && !strings.Contains(button.OuterHTML(), `href="#upload"`) {
+		t.Error("expected the upload icon")
+	}
+	if text := querySelector(t, win, ".slds-file-selector__text"); text == nil || text.TextContent() != "or Drop Files" {
+		t.Error("expected the drop files text")
+	}
+}
+
+// TestFileSelectorDisabled verifies a disabled selector disables its input.
+func TestFileSelectorDisabled(t *testing.T) {
+	win := renderComponent(t, ValidatedFileSelector(FileSelectorProps{Label: "Attachments", Disabled: true}, ValidationState{
+		HasError:     true,
+		ErrorMessage: "Attach a file",
+	}))
+	input := querySelector(t, win, "input.slds-file-selector__input")
+	if input == nil {
+		t.Fatal("expected a file input")
+	}
+	if _, ok := input.GetAttribute("disabled"); !ok {
+		t.Error("expected a disabled file input")
+	}
+	if querySelector(t, win, ".slds-form-element.slds-has-error") == nil {
+		t.Error("expected the error state")
+	}
+}
+
+// TestFileSelectorReceivedFiles verifies files not matching Accept are left
+// out and only the first is kept unless Multiple is set.
+func TestFileSelectorReceivedFiles(t *testing.T) {
+	files := []api.File{
+		{Name: "notes.txt", Type: "text/plain"},
+		{Name: "photo.JPG", Type: "image/jpeg"},
+		{Name: "scan.pdf", Type: "application/pdf"},
+	}
+	var got []api.File
+	selector := &fileSelector{Props: FileSelectorProps{
+		Accept:   "image/*, .pdf",
+		Multiple: true,
+		OnFiles:  func(files []api.File) { got = files },
+	}}
+	selector.received(files, nil)
+	if len(got) != 2 || got[0].Name != "photo.JPG" || got[1].Name != "scan.pdf" {
+		t.Errorf("expected photo.JPG and scan.pdf, got %+v", got)
+	}
+
+	got = nil
+	selector.Props.Multiple = false
+	selector.received(files, nil)
+	if len(got) != 1 || got[0].Name != "photo.JPG" {
+		t.Errorf("expected only photo.JPG, got %+v", got)
+	}
+
+	got = nil
+	selector.received(files[:1], nil)
+	if got != nil {
+		t.Errorf("expected no files to be sent, got %+v", got)
+	}
+}
+
+// TestFileSelectorReportsErrors verifies oversized and unreadable files are
+// passed to OnError with localized messages.
+func TestFileSelectorReportsErrors(t *testing.T) {
+	i18n.Register("de", i18n.Bundle{
+		"Thunder_File_Too_Large": "{0} ist größer als {1}",
+	})
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage("en_US") })
+
+	var got []FileError
+	var sent []api.File
+	selector := &fileSelector{Props: FileSelectorProps{
+		Multiple: true,
+		MaxSize:  2 * 1024 * 1024,
+		OnFiles:  func(files []api.File) { sent = files },
+		OnError:  func(errs []FileError) { got = errs },
+	}}
+	selector.received([]api.File{{Name: "notes.txt"}}, []FileError{
+		fileTooLarge("video.mp4", selector.Props.MaxSize),
+		fileUnreadable("locked.pdf"),
+	})
+	if len(sent) != 1 || sent[0].Name != "notes.txt" {
+		t.Errorf("expected notes.txt to be sent, got %+v", sent)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 errors, got %+v", got)
+	}
+	if got[0].Name != "video.mp4" || got[0].Message != "video.mp4 ist größer als 2.0 MB" {
+		t.Errorf("unexpected size error %+v", got[0])
+	}
+	if got[1].Name != "locked.pdf" || got[1].Message != "locked.pdf could not be read" {
+		t.Errorf("unexpected read error %+v", got[1])
+	}
+
+	got = nil
+	selector.received([]api.File{{Name: "notes.txt"}}, nil)
+	if got != nil {
+		t.Errorf("expected no errors, got %+v", got)
+	}
+}
+
+// TestAcceptsFile verifies file names and MIME types are matched against
+// accept attributes.
+func TestAcceptsFile(t *testing.T) {
+	tests := []struct {
+		accept, name, mimeType string
+		want                   bool
+	}{
+		{"", "a.exe", "application/octet-stream", true},
+		{".pdf", "a.PDF", "", true},
+		{".pdf", "pdf.txt", "text/plain", false},
+		{"image/*", "a.png", "image/png", true},
+		{"image/*", "a.txt", "text/plain", false},
+		{"text/csv, application/pdf", "a.pdf", "application/pdf", true},
+		{"text/csv", "a.csv", "", false},
+	}
+	for _, tt := range tests {
+		if got := acceptsFile(tt.accept, tt.name, tt.mimeType); got != tt.want {
+			t.Errorf("acceptsFile(%q, %q, %q) = %v, want %v", tt.accept, tt.name, tt.mimeType, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,19 @@
 package components
 
+//go:generate go run icons_gen.go
+
 import (
+	"embed"
 	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/api"
 )
 
 // IconCategory defines SLDS icon categories for sprite lookup.
@@ -16,31 +26,198 @@ const (
 	ActionIcon IconCategory = "action"
 	// StandardIcon corresponds to the SLDS standard sprite.
 	StandardIcon IconCategory = "standard"
+	// CustomIcon corresponds to the SLDS custom sprite (custom1 to custom113),
+	// used by custom object tabs.
+	CustomIcon IconCategory = "custom"
+	// DoctypeIcon corresponds to the SLDS doctype sprite of file type icons.
+	DoctypeIcon IconCategory = "doctype"
 )
 
 // IconSize defines SLDS icon sizes.
 type IconSize string
 
 const (
-	IconSmall  IconSize = "small"
-	IconMedium IconSize = "medium"
-	IconLarge  IconSize = "large"
+	IconXXSmall IconSize = "xx-small"
+	IconXSmall  IconSize = "x-small"
+	IconSmall   IconSize = "small"
+	IconMedium  IconSize = "medium"
+	IconLarge   IconSize = "large"
 )
 
+// IconSpritePath, when set, is the URL path icons load the SLDS sprites from,
+// each at <IconSpritePath>/<category>-sprite/svg/symbols.svg. It is empty by
+// default, so icons refer to sprite symbols already in the page by id (e.g.
+// #close). Pages can set it before the app starts with the
+// thunderIconSpritePath global: Thunder's Visualforce page points it at the
+// sprites bundled with <apex:slds/>, and thunder serve at its sprite proxy.
+var IconSpritePath = ""
+
+//go:embed icons/*.txt
+var iconLists embed.FS
+
+// iconNames holds the names in each bundled sprite, loaded on first use.
+var (
+	iconNames     map[IconCategory]map[string]bool
+	iconNamesOnce sync.Once
+)
+
+// ValidIconName reports whether name is an icon in the given category's
+// sprite, according to the list of names bundled with Thunder. Use it to
+// check icon names that come from data, such as a lookup target's icon.
+func ValidIconName(category IconCategory, name string) bool {
+	iconNamesOnce.Do(func() {
+		iconNames = make(map[IconCategory]map[string]bool)
+		for _, c := range []IconCategory{UtilityIcon, ActionIcon, StandardIcon, CustomIcon, DoctypeIcon} {
+			data, err := iconLists.ReadFile("icons/" + string(c) + ".txt")
+			if err != nil {
+				continue
+			}
+			names := make(map[string]bool)
+			for _, n := range strings.Fields(string(data)) {
+				names[n] = true
+			}
+			iconNames[c] = names
+		}
+	})
+	return iconNames[category][name]
+}
+
+// ParseIconName splits an icon name in Lightning's "category:name" form,
+// e.g. "standard:account", into its category and name. ok is false when name
+// is not in that form or names an unknown category; the icon itself is not
+// checked, see ValidIconName.
+func ParseIconName(name string) (category IconCategory, icon string, ok bool) {
+	c, icon, found := strings.Cut(name, ":")
+	if !found || icon == "" {
+		return "", "", false
+	}
+	switch category = IconCategory(c); category {
+	case UtilityIcon, ActionIcon, StandardIcon, CustomIcon, DoctypeIcon:
+		return category, icon, true
+	}
+	return "", "", false
+}
+
+// iconHref returns the reference to an icon's sprite symbol: its id in the
+// page, or its URL under IconSpritePath when that is set.
+func iconHref(category IconCategory, name string) string {
+	if IconSpritePath == "" {
+		return "#" + name
+	}
+	return fmt.Sprintf("%s/%s-sprite/svg/symbols.svg#%s", strings.TrimSuffix(IconSpritePath, "/"), category, name)
+}
+
+// iconSVG returns the markup of an SVG showing an icon from its sprite.
+func iconSVG(classes []string, category IconCategory, name string) string {
+	return fmt.Sprintf(
+		`<svg class="%s" aria-hidden="true"><use xlink:href="%s"></use></svg>`,
+		html.EscapeString(strings.Join(classes, " ")),
+		html.EscapeString(iconHref(category, name)),
+	)
+}
+
+// iconClasses returns the classes of an icon's container and its SVG.
+// Standard, custom and action icons get their category's background color
+// from the container class; utility icons are drawn in the default text
+// color.
+func iconClasses(category IconCategory, name string, size IconSize) (container, svg []string) {
+	container = []string{"slds-icon_container", fmt.Sprintf("slds-icon-%s-%s", category, strings.ReplaceAll(name, "_", "-"))}
+	svg = []string{"slds-icon"}
+	if size != "" {
+		svg = append(svg, "slds-icon_"+string(size))
+	}
+	if category == UtilityIcon {
+		svg = append(svg, "slds-icon-text-default")
+	}
+	return container, svg
+}
+
 // Icon renders an SLDS icon from the given category, name, and size.
 // For example: Icon(UtilityIcon, "close", IconSmall).
 func Icon(category IconCategory, name string, size IconSize) masc.ComponentOrHTML {
-	// Inline <use> referencing injected SVG sprite symbols by ID
-	svg := fmt.Sprintf(
-		`<svg class="slds-icon slds-icon_%s slds-icon-%s-%s" aria-hidden="true">`+
-			`<use xlink:href="#%s"></use></svg>`,
-		size,
-		category,
-		name,
-		name,
-	)
-	// Wrap SVG HTML in a span to insert via innerHTML
+	return iconWithClasses(category, name, size)
+}
+
+// iconWithClasses renders an Icon with extra classes on its container, e.g.
+// to position it inside an input.
+func iconWithClasses(category IconCategory, name string, size IconSize, classes ...string) masc.ComponentOrHTML {
+	container, svg := iconClasses(category, name, size)
+	container = append(container, classes...)
 	return elem.Span(
-		masc.Markup(masc.UnsafeHTML(svg)),
+		masc.Markup(
+			masc.Class(container...),
+			masc.UnsafeHTML(iconSVG(svg, category, name)),
+		),
+	)
+}
+
+// themeIconFile matches the file name of an object's icon in its theme info,
+// e.g. account_120.png.
+var themeIconFile = regexp.MustCompile(`^(.+?)(?:_\d+)?\.(?:png|svg)$`)
+
+// ObjectIconName returns the SLDS icon of an object, taken from the icon URL
+// in its theme info, e.g. .../img/icon/t4v35/standard/account_120.png for
+// standard:account or .../custom/custom57_120.png for custom:custom57. ok is
+// false when the URL does not name a bundled icon.
+func ObjectIconName(info api.ObjectInfo) (category IconCategory, name string, ok bool) {
+	iconURL := info.ThemeInfo.IconURL
+	if i := strings.IndexAny(iconURL, "?#"); i >= 0 {
+		iconURL = iconURL[:i]
+	}
+	m := themeIconFile.FindStringSubmatch(path.Base(iconURL))
+	if m == nil {
+		return "", "", false
+	}
+	category = IconCategory(path.Base(path.Dir(iconURL)))
+	if !ValidIconName(category, m[1]) {
+		return "", "", false
+	}
+	return category, m[1], true
+}
+
+// ObjectIcon renders the icon of an object as Lightning Experience shows it,
+// using the icon and color from its theme info. An icon URL that does not
+// name a bundled SLDS icon, e.g. a custom image, is shown as an image on the
+// object's color; objects without theme info get the standard:custom icon
+// for custom objects and standard:default otherwise.
+func ObjectIcon(info api.ObjectInfo, size IconSize) masc.ComponentOrHTML {
+	var markup []masc.Applyer
+	if color := info.ThemeInfo.Color; color != "" {
+		if !strings.HasPrefix(color, "#") {
+			color = "#" + color
+		}
+		markup = append(markup, masc.Style("background-color", color))
+	}
+	if info.Label != "" {
+		markup = append(markup, masc.Property("title", info.Label))
+	}
+
+	category, name, ok := ObjectIconName(info)
+	if !ok && info.ThemeInfo.IconURL != "" {
+		imgClasses := []string{"slds-icon"}
+		if size != "" {
+			imgClasses = append(imgClasses, "slds-icon_"+string(size))
+		}
+		markup = append(markup, masc.Class("slds-icon_container"))
+		return elem.Span(
+			masc.Markup(markup...),
+			elem.Image(masc.Markup(
+				masc.Class(imgClasses...),
+				masc.Attribute("src", info.ThemeInfo.IconURL),
+				masc.Attribute("alt", ""),
+			)),
+		)
+	}
+	if !ok {
+		category, name = StandardIcon, "default"
+		if info.Custom || strings.HasSuffix(info.APIName, "__c") {
+			name = "custom"
+		}
+	}
+	container, svg := iconClasses(category, name, size)
+	markup = append(markup,
+		masc.Class(container...),
+		masc.UnsafeHTML(iconSVG(svg, category, name)),
 	)
+	return elem.Span(masc.Markup(markup...))
 }
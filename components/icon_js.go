@@ -0,0 +1,13 @@
+//go:build js
+
+package components
+
+import "syscall/js"
+
+// Pages that serve the SLDS sprites as files, such as Thunder's Visualforce
+// page and thunder serve, set thunderIconSpritePath before the app starts.
+func init() {
+	if p := js.Global().Get("thunderIconSpritePath"); p.Type() == js.TypeString && p.String() != "" {
+		IconSpritePath = p.String()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gost-dom/browser/html"
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/api"
 )
 
 // iconModel is a simple masc.Model wrapper that renders an Icon component.
@@ -49,3 +50,118 @@ func TestIconRendersSVG(t *testing.T) {
 		t.Errorf("expected <svg> in Icon output; got %s", inner)
 	}
 }
+
+// TestIconUsesSprite verifies icons reference their sprite symbol in the page
+// by default and get the category's container class.
+func TestIconUsesSprite(t *testing.T) {
+	win := renderComponent(t, Icon(DoctypeIcon, "box_notes", IconLarge))
+	if el := querySelector(t, win, ".slds-icon_container.slds-icon-doctype-box-notes"); el == nil {
+		t.Fatal("expected a doctype icon container")
+	}
+	svg := querySelector(t, win, "svg.slds-icon.slds-icon_large")
+	if svg == nil {
+		t.Fatal("expected a sized icon")
+	}
+	if !strings.Contains(svg.OuterHTML(), `href="#box_notes"`) {
+		t.Errorf("unexpected sprite reference in %s", svg.OuterHTML())
+	}
+}
+
+// TestIconUsesSpritePath verifies setting IconSpritePath loads icons from the
+// category's sprite file.
+func TestIconUsesSpritePath(t *testing.T) {
+	orig := IconSpritePath
+	IconSpritePath = "/_slds/icons/"
+	t.Cleanup(func() { IconSpritePath = orig })
+
+	win := renderComponent(t, Icon(DoctypeIcon, "box_notes", IconLarge))
+	svg := querySelector(t, win, "svg.slds-icon")
+	if svg == nil || !strings.Contains(svg.OuterHTML(), `href="/_slds/icons/doctype-sprite/svg/symbols.svg#box_notes"`) {
+		t.Errorf("expected the sprite file reference, got %v", svg)
+	}
+}
+
+// TestValidIconName verifies names are checked against the bundled sprites.
+func TestValidIconName(t *testing.T) {
+	for _, tc := range []struct {
+		category IconCategory
+		name     string
+		want     bool
+	}{
+		{UtilityIcon, "close", true},
+		{StandardIcon, "account", true},
+		{CustomIcon, "custom113", true},
+		{CustomIcon, "custom114", false},
+		{DoctypeIcon, "pdf", true},
+		{ActionIcon, "new_contact", true},
+		{StandardIcon, "close", false},
+		{"bogus", "close", false},
+	} {
+		if got := ValidIconName(tc.category, tc.name); got != tc.want {
+			t.Errorf("ValidIconName(%q, %q) = %v, want %v", tc.category, tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestParseIconName verifies Lightning icon names are split into category
+// and name.
+func TestParseIconName(t *testing.T) {
+	if c, n, ok := ParseIconName("custom:custom57"); !ok || c != CustomIcon || n != "custom57" {
+		t.Errorf("unexpected result %q %q %v", c, n, ok)
+	}
+	for _, name := range []string{"close", "bogus:close", "utility:"} {
+		if _, _, ok := ParseIconName(name); ok {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
+
+// TestObjectIconFromThemeInfo verifies object icons are resolved from the
+// theme info's icon URL and colored with its color.
+func TestObjectIconFromThemeInfo(t *testing.T) {
+	for _, tc := range []struct {
+		url      string
+		category IconCategory
+		name     string
+	}{
+		{"https://example.my.salesforce.com/img/icon/t4v35/standard/opportunity_splits_120.png", StandardIcon, "opportunity_splits"},
+		{"https://example.my.salesforce.com/img/icon/t4v35/custom/custom57_120.png", CustomIcon, "custom57"},
+	} {
+		category, name, ok := ObjectIconName(api.ObjectInfo{ThemeInfo: api.ThemeInfo{IconURL: tc.url}})
+		if !ok || category != tc.category || name != tc.name {
+			t.Errorf("ObjectIconName(%q) = %q, %q, %v", tc.url, category, name, ok)
+		}
+	}
+
+	win := renderComponent(t, ObjectIcon(api.ObjectInfo{
+		Label:     "Widget",
+		ThemeInfo: api.ThemeInfo{Color: "3C97DD", IconURL: "https://example.my.salesforce.com/img/icon/t4v35/custom/custom57_120.png"},
+	}, IconSmall))
+	container := querySelector(t, win, ".slds-icon-custom-custom57")
+	if container == nil {
+		t.Fatal("expected a custom57 icon")
+	}
+	if style, _ := container.GetAttribute("style"); !strings.Contains(style, "#3C97DD") {
+		t.Errorf("expected the theme color, got style %q", style)
+	}
+}
+
+// TestObjectIconFallbacks verifies object icons without a known sprite icon
+// show their image, or a default icon without theme info.
+func TestObjectIconFallbacks(t *testing.T) {
+	win := renderComponent(t, ObjectIcon(api.ObjectInfo{
+		ThemeInfo: api.ThemeInfo{Color: "00A1E0", IconURL: "https://example.my.salesforce.com/logo.gif"},
+	}, IconSmall))
+	img := querySelector(t, win, ".slds-icon_container img.slds-icon")
+	if img == nil {
+		t.Fatal("expected the icon image")
+	}
+	if src, _ := img.GetAttribute("src"); src != "https://example.my.salesforce.com/logo.gif" {
+		t.Errorf("unexpected image %q", src)
+	}
+
+	win = renderComponent(t, ObjectIcon(api.ObjectInfo{APIName: "Widget__c"}, IconSmall))
+	if querySelector(t, win, ".slds-icon-standard-custom") == nil {
+		t.Error("expected the standard custom icon for a custom object")
+	}
+}
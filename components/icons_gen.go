@@ -0,0 +1,45 @@
+//go:build ignore
+
+// This program regenerates the icon name lists in icons/ from a copy of the
+// @salesforce-ux/design-system package. Run it with go generate after
+// upgrading SLDS:
+//
+//	SLDS_DIR=node_modules/@salesforce-ux/design-system go generate ./components
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var symbolID = regexp.MustCompile(`<symbol[^>]*\sid="([^"]+)"`)
+
+func main() {
+	dir := os.Getenv("SLDS_DIR")
+	if dir == "" {
+		fmt.Fprintln(os.Stderr, "SLDS_DIR must point to the @salesforce-ux/design-system package")
+		os.Exit(1)
+	}
+	for _, category := range []string{"action", "custom", "doctype", "standard", "utility"} {
+		sprite := filepath.Join(dir, "assets", "icons", category+"-sprite", "svg", "symbols.svg")
+		data, err := os.ReadFile(sprite)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		var names []string
+		for _, m := range symbolID.FindAllSubmatch(data, -1) {
+			names = append(names, string(m[1]))
+		}
+		sort.Strings(names)
+		out := filepath.Join("icons", category+".txt")
+		if err := os.WriteFile(out, []byte(strings.Join(names, "\n")+"\n"), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package components
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+)
 
 // TestLookupBasic verifies that Lookup renders for basic parameters.
 func TestLookupBasic(t *testing.T) {
@@ -18,3 +22,16 @@ func TestLookupEmptySuggestions(t *testing.T) {
 		t.Error("Lookup returned nil when suggestions empty")
 	}
 }
+
+// TestLookupSelectReportsValueAndLabel verifies choosing a suggestion reports
+// its value and puts its label in the input.
+func TestLookupSelectReportsValueAndLabel(t *testing.T) {
+	var selected, input string
+	opts := []LookupOption{{Label: "Acme", Value: "001A"}, {Label: "Globex", Value: "001B"}}
+	win := renderComponent(t, Lookup("Account", opts, "ac", func(s string) { input = s }, func(s string) { selected = s }))
+
+	querySelector(t, win, `[data-value="001A"]`).(html.HTMLElement).Click()
+	if selected != "001A" || input != "Acme" {
+		t.Errorf("expected 001A and Acme, got %q and %q", selected, input)
+	}
+}
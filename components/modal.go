@@ -1,150 +1,133 @@
 package components
 
 import (
+	"fmt"
+	"sync/atomic"
+
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // Modal renders an SLDS modal dialog with the given title and body content.
 // The modal and backdrop are always visible. To toggle visibility, wrap the
 // component in masc.If or similar conditional logic.
 // title is displayed in the header; content is rendered in the modal body.
+//
+// While open, the modal keeps keyboard focus within the dialog, locks
+// scrolling of the page behind it, and returns focus to the previously
+// focused element when it closes.
 func Modal(title string, children ...masc.MarkupOrChild) masc.ComponentOrHTML {
-	return modal(title, "", children...)
+	return &modalComponent{Title: title, Children: children}
 }
 
 // LargeModal renders a Modal at the SLDS "large" width, giving wide content
 // such as multi-column tables room to lay out without horizontal scrolling.
 func LargeModal(title string, children ...masc.MarkupOrChild) masc.ComponentOrHTML {
-	return modal(title, "slds-modal_large", children...)
-}
-
-// modal is the shared implementation behind Modal and LargeModal. sizeClass is
-// an optional SLDS size modifier (e.g. "slds-modal_large") applied to the modal
-// wrapper; an empty string yields the default width.
-func modal(title, sizeClass string, children ...masc.MarkupOrChild) masc.ComponentOrHTML {
-	// Split children into body (first) and footer (rest)
-	var bodyChildren, footerChildren []masc.MarkupOrChild
-	if len(children) > 0 {
-		bodyChildren = append(bodyChildren, children[0])
-	}
-	if len(children) > 1 {
-		footerChildren = children[1:]
-	}
-	// Build container arguments
-	var containerArgs []masc.MarkupOrChild
-	containerArgs = append(containerArgs,
-		masc.Markup(masc.Class("slds-modal__container")),
-	)
-	// Header
-	containerArgs = append(containerArgs,
-		elem.Header(
-			masc.Markup(masc.Class("slds-modal__header")),
-			elem.Heading2(
-				masc.Markup(
-					masc.Class("slds-text-heading_medium", "slds-truncate"),
-					masc.Property("title", title),
-				),
-				masc.Text(title),
-			),
-		),
-	)
-	// Content
-	var contentArgs []masc.MarkupOrChild
-	contentArgs = append(contentArgs,
-		masc.Markup(masc.Class("slds-modal__content", "slds-p-around_medium")),
-	)
-	contentArgs = append(contentArgs, bodyChildren...)
-	containerArgs = append(containerArgs,
-		elem.Div(contentArgs...),
-	)
-	// Footer (optional)
-	if len(footerChildren) > 0 {
-		// Footer container with SLDS footer class
-		var footerArgs []masc.MarkupOrChild
-		footerArgs = append(footerArgs, masc.Markup(masc.Class("slds-modal__footer")))
-		footerArgs = append(footerArgs, footerChildren...)
-		containerArgs = append(containerArgs,
-			elem.Div(footerArgs...),
-		)
-	}
-	// Modal wrapper
-	modalClasses := []string{"slds-modal", "slds-fade-in-open"}
-	if sizeClass != "" {
-		modalClasses = append(modalClasses, sizeClass)
-	}
-	modal := elem.Div(
-		masc.Markup(
-			masc.Class(modalClasses...),
-			masc.Attribute("role", "dialog"),
-			masc.Property("aria-modal", true),
-		),
-		elem.Div(containerArgs...),
-	)
-	// Backdrop
-	backdrop := elem.Div(
-		masc.Markup(masc.Class("slds-backdrop", "slds-backdrop_open")),
-	)
-	// Combine modal and backdrop into a slice literal
-	return masc.List{modal, backdrop}
+	return &modalComponent{Title: title, SizeClass: "slds-modal_large", Children: children}
 }
 
 // ModalWithClose renders an SLDS modal dialog with a close button and backdrop click support.
 // The modal and backdrop are always visible. To toggle visibility, wrap the
 // component in masc.If or similar conditional logic.
-// title is displayed in the header; onClose is called when close button or backdrop is clicked.
+// title is displayed in the header; onClose is called when close button or backdrop is clicked,
+// or when Escape is pressed.
 // children: first element becomes modal body content, remaining elements become footer buttons.
 func ModalWithClose(title string, onClose func(*masc.Event), children ...masc.MarkupOrChild) masc.ComponentOrHTML {
+	return &modalComponent{Title: title, OnClose: onClose, Children: children}
+}
+
+// modalIDs numbers modals so each dialog can reference its heading.
+var modalIDs atomic.Int64
+
+// modalComponent is the shared implementation behind Modal, LargeModal and
+// ModalWithClose. It is a component so the dialog can trap focus and lock
+// page scrolling while mounted.
+type modalComponent struct {
+	masc.Core
+
+	Title string `masc:"prop"`
+	// SizeClass is an optional SLDS size modifier (e.g. "slds-modal_large")
+	// applied to the modal wrapper; an empty string yields the default width.
+	SizeClass string `masc:"prop"`
+	// OnClose, if set, renders a close button and is called on backdrop
+	// click or Escape.
+	OnClose  func(*masc.Event)    `masc:"prop"`
+	Children []masc.MarkupOrChild `masc:"prop"`
+
+	headingID string
+	root      *masc.HTML
+	release   func()
+}
+
+func (m *modalComponent) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if m.headingID == "" {
+		m.headingID = fmt.Sprintf("modal-heading-%d", modalIDs.Add(1))
+	}
 	// Split children into body (first) and footer (rest)
 	var bodyChildren, footerChildren []masc.MarkupOrChild
-	if len(children) > 0 {
-		bodyChildren = append(bodyChildren, children[0])
+	if len(m.Children) > 0 {
+		bodyChildren = append(bodyChildren, m.Children[0])
 	}
-	if len(children) > 1 {
-		footerChildren = children[1:]
+	if len(m.Children) > 1 {
+		footerChildren = m.Children[1:]
 	}
-
 	// Build container arguments
 	var containerArgs []masc.MarkupOrChild
 	containerArgs = append(containerArgs,
 		masc.Markup(masc.Class("slds-modal__container")),
 	)
-
-	// Header with close button
-	containerArgs = append(containerArgs,
-		elem.Header(
-			masc.Markup(masc.Class("slds-modal__header")),
-			elem.Button(
-				masc.Markup(
-					masc.Class("slds-button", "slds-button_icon", "slds-modal__close", "slds-button_icon-inverse"),
-					masc.Attribute("title", "Close"),
-					masc.Attribute("type", "button"),
-					event.Click(onClose),
-				),
-				elem.Span(
+	// Header
+	if m.OnClose != nil {
+		containerArgs = append(containerArgs,
+			elem.Header(
+				masc.Markup(masc.Class("slds-modal__header")),
+				elem.Button(
 					masc.Markup(
-						masc.Class("slds-button__icon"),
-						masc.Style("font-size", "1rem"),
-						masc.Style("line-height", "1"),
+						masc.Class("slds-button", "slds-button_icon", "slds-modal__close", "slds-button_icon-inverse"),
+						masc.Attribute("title", i18n.T("Thunder_Close")),
+						masc.Attribute("type", "button"),
+						event.Click(m.OnClose),
+					),
+					elem.Span(
+						masc.Markup(
+							masc.Class("slds-button__icon"),
+							masc.Style("font-size", "1rem"),
+							masc.Style("line-height", "1"),
+						),
+						masc.Text("✕"),
+					),
+					elem.Span(
+						masc.Markup(masc.Class("slds-assistive-text")),
+						masc.Text(i18n.T("Thunder_Close")),
 					),
-					masc.Text("✕"),
 				),
-				elem.Span(
-					masc.Markup(masc.Class("slds-assistive-text")),
-					masc.Text("Close"),
+				elem.Heading2(
+					masc.Markup(
+						masc.Class("slds-modal__title", "slds-hyphenate"),
+						masc.Property("id", m.headingID),
+						masc.Property("title", m.Title),
+					),
+					masc.Text(m.Title),
 				),
 			),
-			elem.Heading2(
-				masc.Markup(
-					masc.Class("slds-modal__title", "slds-hyphenate"),
-					masc.Property("title", title),
+		)
+	} else {
+		containerArgs = append(containerArgs,
+			elem.Header(
+				masc.Markup(masc.Class("slds-modal__header")),
+				elem.Heading2(
+					masc.Markup(
+						masc.Class("slds-text-heading_medium", "slds-truncate"),
+						masc.Property("id", m.headingID),
+						masc.Property("title", m.Title),
+					),
+					masc.Text(m.Title),
 				),
-				masc.Text(title),
 			),
-		),
-	)
-
+		)
+	}
 	// Content
 	var contentArgs []masc.MarkupOrChild
 	contentArgs = append(contentArgs,
@@ -154,7 +137,6 @@ func ModalWithClose(title string, onClose func(*masc.Event), children ...masc.Ma
 	containerArgs = append(containerArgs,
 		elem.Div(contentArgs...),
 	)
-
 	// Footer (optional)
 	if len(footerChildren) > 0 {
 		// Footer container with SLDS footer class
@@ -165,26 +147,43 @@ func ModalWithClose(title string, onClose func(*masc.Event), children ...masc.Ma
 			elem.Div(footerArgs...),
 		)
 	}
-
 	// Modal wrapper
+	modalClasses := []string{"slds-modal", "slds-fade-in-open"}
+	if m.SizeClass != "" {
+		modalClasses = append(modalClasses, m.SizeClass)
+	}
 	modal := elem.Div(
 		masc.Markup(
-			masc.Class("slds-modal", "slds-fade-in-open"),
+			masc.Class(modalClasses...),
 			masc.Attribute("role", "dialog"),
+			masc.Attribute("tabindex", "-1"),
 			masc.Property("aria-modal", true),
-			masc.Attribute("aria-labelledby", "modal-heading-01"),
+			masc.Attribute("aria-labelledby", m.headingID),
 		),
 		elem.Div(containerArgs...),
 	)
+	// Backdrop, which closes the modal when it has a close handler
+	backdropMarkup := []masc.Applyer{masc.Class("slds-backdrop", "slds-backdrop_open")}
+	if m.OnClose != nil {
+		backdropMarkup = append(backdropMarkup, event.Click(m.OnClose))
+	}
+	backdrop := elem.Div(masc.Markup(backdropMarkup...))
 
-	// Backdrop with click handler
-	backdrop := elem.Div(
-		masc.Markup(
-			masc.Class("slds-backdrop", "slds-backdrop_open"),
-			event.Click(onClose),
-		),
-	)
+	m.root = elem.Div(modal, backdrop)
+	return m.root
+}
 
-	// Combine modal and backdrop into a slice literal
-	return masc.List{backdrop, modal}
+func (m *modalComponent) Mount() {
+	m.release = activateModal(m.root, func(e *masc.Event) {
+		if m.OnClose != nil {
+			m.OnClose(e)
+		}
+	})
+}
+
+func (m *modalComponent) Unmount() {
+	if m.release != nil {
+		m.release()
+		m.release = nil
+	}
 }
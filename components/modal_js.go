@@ -0,0 +1,106 @@
+//go:build js
+
+package components
+
+import (
+	"syscall/js"
+
+	"github.com/octoberswimmer/masc"
+)
+
+// focusableSelector matches the elements a modal's focus trap cycles through.
+const focusableSelector = `a[href], button:not([disabled]), input:not([disabled]):not([type="hidden"]), ` +
+	`select:not([disabled]), textarea:not([disabled]), [tabindex]:not([tabindex="-1"])`
+
+// openModals holds the focus traps of the mounted modals, innermost
+// last. Only the innermost modal handles keyboard events.
+var openModals []*modalTrap
+
+type modalTrap struct {
+	dialog js.Value
+}
+
+// activateModal moves focus into the dialog rendered in root, keeps Tab and
+// Shift+Tab within it, calls onEscape when Escape is pressed and locks page
+// scrolling. The returned function undoes all of this and restores focus to
+// the element focused before the modal opened.
+func activateModal(root *masc.HTML, onEscape func(*masc.Event)) func() {
+	global := js.Global()
+	doc := global.Get("document")
+	if !doc.Truthy() || root == nil {
+		return func() {}
+	}
+	dialog := root.Node().Call("querySelector", "[role=dialog]")
+	if !dialog.Truthy() {
+		return func() {}
+	}
+	trap := &modalTrap{dialog: dialog}
+	previousFocus := doc.Get("activeElement")
+	body := doc.Get("body")
+	previousOverflow := body.Get("style").Get("overflow").String()
+	body.Get("style").Set("overflow", "hidden")
+	openModals = append(openModals, trap)
+
+	focusables := func() []js.Value {
+		nodes := dialog.Call("querySelectorAll", focusableSelector)
+		var els []js.Value
+		for i := 0; i < nodes.Get("length").Int(); i++ {
+			els = append(els, nodes.Index(i))
+		}
+		return els
+	}
+	if els := focusables(); len(els) > 0 {
+		els[0].Call("focus")
+	} else {
+		dialog.Call("focus")
+	}
+
+	handler := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 || openModals[len(openModals)-1] != trap {
+			return nil
+		}
+		evt := args[0]
+		switch evt.Get("key").String() {
+		case "Escape":
+			evt.Call("preventDefault")
+			onEscape(&masc.Event{Value: evt, Target: evt.Get("target")})
+		case "Tab":
+			els := focusables()
+			if len(els) == 0 {
+				evt.Call("preventDefault")
+				dialog.Call("focus")
+				return nil
+			}
+			first, last := els[0], els[len(els)-1]
+			active := doc.Get("activeElement")
+			switch {
+			case !dialog.Call("contains", active).Bool():
+				evt.Call("preventDefault")
+				first.Call("focus")
+			case evt.Get("shiftKey").Bool() && (active.Equal(first) || active.Equal(dialog)):
+				evt.Call("preventDefault")
+				last.Call("focus")
+			case !evt.Get("shiftKey").Bool() && active.Equal(last):
+				evt.Call("preventDefault")
+				first.Call("focus")
+			}
+		}
+		return nil
+	})
+	doc.Call("addEventListener", "keydown", handler)
+
+	return func() {
+		doc.Call("removeEventListener", "keydown", handler)
+		handler.Release()
+		for i, t := range openModals {
+			if t == trap {
+				openModals = append(openModals[:i], openModals[i+1:]...)
+				break
+			}
+		}
+		body.Get("style").Set("overflow", previousOverflow)
+		if previousFocus.Truthy() && previousFocus.Get("focus").Truthy() {
+			previousFocus.Call("focus")
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+// activateModal is a no-op outside the browser, where there is no focus or
+// page scrolling to manage.
+func activateModal(root *masc.HTML, onEscape func(*masc.Event)) func() {
+	return func() {}
+}
@@ -0,0 +1,199 @@
+package components
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// MultiSelectProps configures a MultiSelect.
+type MultiSelectProps struct {
+	Label string
+	// Options are all the values that can be chosen.
+	Options []SelectOption
+	// Selected holds the chosen values; see api.SplitMultiSelect.
+	Selected []string
+	// IsOpen shows the dropdown of options.
+	IsOpen bool
+	// Placeholder is shown when nothing is selected. Defaults to
+	// "Select Options".
+	Placeholder string
+
+	// OnOpenChange receives the new open state when the input is clicked.
+	OnOpenChange func(open bool)
+	// OnChange receives the selected values, in option order, after an
+	// option is toggled or a pill removed; see api.JoinMultiSelect.
+	OnChange func(selected []string)
+}
+
+// MultiSelect renders an SLDS multi-select combobox. Options toggle on and off
+// in the dropdown, which stays open while choosing, and each selected value is
+// shown as a removable Pill beneath the input.
+func MultiSelect(props MultiSelectProps) masc.ComponentOrHTML {
+	return ValidatedMultiSelect(props, ValidationState{})
+}
+
+// ValidatedMultiSelect renders a MultiSelect with validation support.
+func ValidatedMultiSelect(props MultiSelectProps, validation ValidationState) masc.ComponentOrHTML {
+	// toggle adds or removes value, keeping option order.
+	toggle := func(value string) {
+		if props.OnChange == nil {
+			return
+		}
+		var next []string
+		for _, opt := range props.Options {
+			if slices.Contains(props.Selected, opt.Value) != (opt.Value == value) {
+				next = append(next, opt.Value)
+			}
+		}
+		props.OnChange(next)
+	}
+
+	var selectedOptions []SelectOption
+	for _, opt := range props.Options {
+		if slices.Contains(props.Selected, opt.Value) {
+			selectedOptions = append(selectedOptions, opt)
+		}
+	}
+
+	// Summary shown in the read-only input
+	summary := ""
+	switch len(selectedOptions) {
+	case 0:
+	case 1:
+		summary = selectedOptions[0].Label
+	default:
+		summary = i18n.T("Thunder_Options_Selected", len(selectedOptions))
+	}
+	placeholder := props.Placeholder
+	if placeholder == "" {
+		placeholder = validation.Placeholder
+	}
+	if placeholder == "" {
+		placeholder = i18n.T("Thunder_Select_Options")
+	}
+
+	// Build dropdown options
+	listItems := []masc.MarkupOrChild{
+		masc.Markup(
+			masc.Class("slds-listbox", "slds-listbox_vertical"),
+			masc.Attribute("role", "presentation"),
+		),
+	}
+	for _, opt := range props.Options {
+		selected := slices.Contains(props.Selected, opt.Value)
+		optionClasses := []string{"slds-media", "slds-listbox__option", "slds-listbox__option_plain", "slds-media_small"}
+		if selected {
+			optionClasses = append(optionClasses, "slds-is-selected")
+		}
+		check := ""
+		if selected {
+			check = "✓"
+		}
+		listItems = append(listItems, elem.ListItem(
+			masc.Markup(
+				masc.Class("slds-listbox__item"),
+				masc.Attribute("role", "presentation"),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class(optionClasses...),
+					masc.Attribute("role", "option"),
+					masc.Attribute("aria-selected", strconv.FormatBool(selected)),
+					masc.Data("value", opt.Value),
+					event.Click(func(e *masc.Event) { toggle(opt.Value) }),
+				),
+				elem.Span(
+					masc.Markup(masc.Class("slds-media__figure", "slds-listbox__option-icon")),
+					masc.Text(check),
+				),
+				elem.Span(
+					masc.Markup(masc.Class("slds-media__body")),
+					elem.Span(
+						masc.Markup(masc.Class("slds-truncate"), masc.Property("title", opt.Label)),
+						masc.Text(opt.Label),
+					),
+				),
+			),
+		))
+	}
+
+	comboClasses := []string{"slds-combobox", "slds-dropdown-trigger", "slds-dropdown-trigger_click"}
+	if props.IsOpen {
+		comboClasses = append(comboClasses, "slds-is-open")
+	}
+	combobox := elem.Div(
+		masc.Markup(masc.Class("slds-combobox_container")),
+		elem.Div(
+			masc.Markup(
+				masc.Class(comboClasses...),
+				masc.Attribute("aria-expanded", strconv.FormatBool(props.IsOpen)),
+				masc.Attribute("aria-haspopup", "listbox"),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-combobox__form-element", "slds-input-has-icon", "slds-input-has-icon_right"),
+					masc.Attribute("role", "none"),
+				),
+				elem.Input(masc.Markup(
+					masc.Class("slds-input", "slds-combobox__input"),
+					masc.Attribute("role", "combobox"),
+					masc.Attribute("aria-expanded", strconv.FormatBool(props.IsOpen)),
+					masc.Property("type", "text"),
+					masc.Property("value", summary),
+					masc.Property("placeholder", placeholder),
+					masc.Property("readOnly", true),
+					event.Click(func(e *masc.Event) {
+						if props.OnOpenChange != nil {
+							props.OnOpenChange(!props.IsOpen)
+						}
+					}),
+				)),
+				elem.Span(
+					masc.Markup(masc.Class("slds-icon_container", "slds-input__icon", "slds-input__icon_right")),
+					Icon(UtilityIcon, "down", IconSmall),
+				),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-dropdown", "slds-dropdown_length-5", "slds-dropdown_fluid"),
+					masc.Attribute("role", "listbox"),
+					masc.Attribute("aria-multiselectable", "true"),
+				),
+				elem.UnorderedList(listItems...),
+			),
+		),
+	)
+
+	// Selected values as removable pills
+	var pills masc.ComponentOrHTML
+	if len(selectedOptions) > 0 {
+		pillItems := []masc.MarkupOrChild{
+			masc.Markup(
+				masc.Class("slds-listbox", "slds-listbox_horizontal"),
+				masc.Attribute("role", "listbox"),
+				masc.Attribute("aria-label", i18n.T("Thunder_Selected_Options")),
+				masc.Attribute("aria-orientation", "horizontal"),
+			),
+		}
+		for _, opt := range selectedOptions {
+			pillItems = append(pillItems, elem.ListItem(
+				masc.Markup(
+					masc.Class("slds-listbox-item"),
+					masc.Attribute("role", "presentation"),
+				),
+				Pill(opt.Label, func(e *masc.Event) { toggle(opt.Value) }),
+			))
+		}
+		pills = elem.Div(
+			masc.Markup(masc.Class("slds-listbox_selection-group")),
+			elem.UnorderedList(pillItems...),
+		)
+	}
+
+	return validatedFormElement(props.Label, validation, elem.Div(combobox, pills))
+}
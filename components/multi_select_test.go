@@ -0,0 +1,96 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// TestMultiSelectPills verifies each selected value is shown as a pill and
+// removing a pill deselects it.
+func TestMultiSelectPills(t *testing.T) {
+	var changed []string
+	win := renderComponent(t, MultiSelect(MultiSelectProps{
+		Label:    "Colors",
+		Options:  colorOptions,
+		Selected: []string{"Blue", "Red"},
+		OnChange: func(v []string) { changed = v },
+	}))
+
+	pills, err := win.Document().QuerySelectorAll(".slds-pill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pills.Length(); got != 2 {
+		t.Fatalf("expected 2 pills, got %d", got)
+	}
+	if got, _ := querySelector(t, win, "input.slds-combobox__input").GetAttribute("placeholder"); got != "Select Options" {
+		t.Errorf("expected default placeholder, got %q", got)
+	}
+
+	querySelector(t, win, ".slds-pill__remove").(html.HTMLElement).Click()
+	if want := []string{"Blue"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected %v, got %v", want, changed)
+	}
+}
+
+// TestMultiSelectToggleOption verifies clicking options in the open dropdown
+// toggles them, keeping option order.
+func TestMultiSelectToggleOption(t *testing.T) {
+	var changed []string
+	win := renderComponent(t, ValidatedMultiSelect(MultiSelectProps{
+		Label:    "Colors",
+		Options:  colorOptions,
+		Selected: []string{"Blue"},
+		IsOpen:   true,
+		OnChange: func(v []string) { changed = v },
+	}, Required()))
+
+	if el := querySelector(t, win, ".slds-combobox.slds-is-open"); el == nil {
+		t.Fatal("expected open dropdown")
+	}
+	if el := querySelector(t, win, ".slds-required"); el == nil {
+		t.Error("expected required marker")
+	}
+	querySelector(t, win, "[role=option][data-value=Red]").(html.HTMLElement).Click()
+	if want := []string{"Red", "Blue"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected %v, got %v", want, changed)
+	}
+	querySelector(t, win, "[role=option][data-value=Blue]").(html.HTMLElement).Click()
+	if len(changed) != 0 {
+		t.Errorf("expected no selection, got %v", changed)
+	}
+}
+
+// TestMultiSelectOpen verifies clicking the input toggles the dropdown.
+func TestMultiSelectOpen(t *testing.T) {
+	var open bool
+	win := renderComponent(t, MultiSelect(MultiSelectProps{
+		Label:        "Colors",
+		Options:      colorOptions,
+		OnOpenChange: func(v bool) { open = v },
+	}))
+	querySelector(t, win, "input.slds-combobox__input").(html.HTMLElement).Click()
+	if !open {
+		t.Error("expected OnOpenChange(true)")
+	}
+}
+
+// TestMultiSelectTranslatesSummary verifies the placeholder and the summary of
+// several selections follow the user's language.
+func TestMultiSelectTranslatesSummary(t *testing.T) {
+	i18n.Register("de", i18n.Bundle{"Thunder_Select_Options": "Optionen auswählen", "Thunder_Options_Selected": "{0} Optionen ausgewählt"})
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage("en_US") })
+
+	win := renderComponent(t, MultiSelect(MultiSelectProps{Label: "Colors", Options: colorOptions}))
+	if got, _ := querySelector(t, win, "input.slds-combobox__input").GetAttribute("placeholder"); got != "Optionen auswählen" {
+		t.Errorf("expected a translated placeholder, got %q", got)
+	}
+	win = renderComponent(t, MultiSelect(MultiSelectProps{Label: "Colors", Options: colorOptions, Selected: []string{"Red", "Blue"}}))
+	if got, _ := querySelector(t, win, "input.slds-combobox__input").GetAttribute("value"); got != "2 Optionen ausgewählt" {
+		t.Errorf("expected a translated summary, got %q", got)
+	}
+}
@@ -0,0 +1,264 @@
+package components
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NumberLocale describes how numbers and currency amounts are written in a
+// locale.
+type NumberLocale struct {
+	// Decimal separates the fraction, e.g. "." or ",".
+	Decimal string
+	// Group separates thousands, e.g. "," or ".".
+	Group string
+	// SymbolAfter places the currency symbol after the amount, as in
+	// "1.234,50 €".
+	SymbolAfter bool
+}
+
+// DefaultNumberLocale writes numbers as in en_US.
+var DefaultNumberLocale = NumberLocale{Decimal: ".", Group: ","}
+
+// numberLocales holds the separators of common Salesforce locales, by full
+// locale and by language.
+var numberLocales = map[string]NumberLocale{
+	"en":    DefaultNumberLocale,
+	"ja":    DefaultNumberLocale,
+	"zh":    DefaultNumberLocale,
+	"ko":    DefaultNumberLocale,
+	"de":    {Decimal: ",", Group: ".", SymbolAfter: true},
+	"de_CH": {Decimal: ".", Group: "'"},
+	"es":    {Decimal: ",", Group: ".", SymbolAfter: true},
+	"es_MX": DefaultNumberLocale,
+	"it":    {Decimal: ",", Group: ".", SymbolAfter: true},
+	"pt":    {Decimal: ",", Group: "."},
+	"nl":    {Decimal: ",", Group: "."},
+	"da":    {Decimal: ",", Group: ".", SymbolAfter: true},
+	"tr":    {Decimal: ",", Group: "."},
+	"id":    {Decimal: ",", Group: "."},
+	"fr":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"fr_CH": {Decimal: ".", Group: "'"},
+	"sv":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"nb":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"no":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"fi":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"pl":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"cs":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+	"ru":    {Decimal: ",", Group: "\u00a0", SymbolAfter: true},
+}
+
+// NumberLocaleFor returns the NumberLocale for a Salesforce locale such as
+// "en_US" or "de_DE", falling back to the language and then to
+// DefaultNumberLocale.
+func NumberLocaleFor(locale string) NumberLocale {
+	locale = strings.ReplaceAll(locale, "-", "_")
+	if l, ok := numberLocales[locale]; ok {
+		return l
+	}
+	lang, _, _ := strings.Cut(locale, "_")
+	if l, ok := numberLocales[strings.ToLower(lang)]; ok {
+		return l
+	}
+	return DefaultNumberLocale
+}
+
+// currencySymbols maps ISO currency codes to their symbols. Other codes are
+// shown as the code itself.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"BRL": "R$",
+	"AUD": "A$",
+	"CAD": "CA$",
+	"MXN": "MX$",
+}
+
+// CurrencySymbol returns the symbol for an ISO currency code, e.g. "€" for
+// "EUR". An empty code means US dollars.
+func CurrencySymbol(code string) string {
+	if code == "" {
+		return "$"
+	}
+	if s, ok := currencySymbols[strings.ToUpper(code)]; ok {
+		return s
+	}
+	return strings.ToUpper(code)
+}
+
+// FormatNumber writes n with scale decimal places and grouped thousands.
+func (l NumberLocale) FormatNumber(n float64, scale int) string {
+	s := strconv.FormatFloat(math.Abs(n), 'f', scale, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	if n < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteString("-")
+	}
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// FormatCurrency writes n as an amount in the given ISO currency, e.g.
+// "$1,234.50" or "1.234,50 €".
+func (l NumberLocale) FormatCurrency(n float64, scale int, currency string) string {
+	amount := l.FormatNumber(n, scale)
+	symbol := CurrencySymbol(currency)
+	if l.SymbolAfter {
+		return amount + "\u00a0" + symbol
+	}
+	if strings.HasPrefix(amount, "-") {
+		return "-" + symbol + amount[1:]
+	}
+	return symbol + amount
+}
+
+// errInvalidNumber is returned by ParseNumber for text that is not a number.
+var errInvalidNumber = errors.New("invalid number")
+
+// ParseNumber reads a number written in the locale. Group separators are
+// accepted between groups of three digits, and a currency symbol or code may
+// lead or trail the amount, as may a percent sign; anything else makes the
+// text invalid.
+func (l NumberLocale) ParseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutSuffix(s, "%"); ok {
+		s = trimNumberSpace(rest)
+	}
+	sign, s := cutSign(s)
+	s = trimCurrency(s)
+	if sign == "" {
+		sign, s = cutSign(s)
+	}
+
+	whole, frac, hasDecimal := strings.Cut(s, l.Decimal)
+	if hasDecimal && !allDigits(frac) {
+		return 0, errInvalidNumber
+	}
+	whole, ok := l.ungroup(whole)
+	if !ok || whole+frac == "" {
+		return 0, errInvalidNumber
+	}
+	n, err := strconv.ParseFloat(sign+whole+"."+frac, 64)
+	if err != nil {
+		return 0, errInvalidNumber
+	}
+	return n, nil
+}
+
+// ungroup removes the group separators from the whole part of a number,
+// reporting false unless they separate groups of three digits after a
+// leading group of one to three. Locales grouping with a space also accept
+// the other spaces, which keyboards and formatters use interchangeably.
+func (l NumberLocale) ungroup(whole string) (string, bool) {
+	if isNumberSpace(l.Group) {
+		whole = strings.Map(func(r rune) rune {
+			if isNumberSpace(string(r)) {
+				return ' '
+			}
+			return r
+		}, whole)
+		l.Group = " "
+	}
+	if l.Group == "" || !strings.Contains(whole, l.Group) {
+		return whole, allDigits(whole)
+	}
+	groups := strings.Split(whole, l.Group)
+	for i, g := range groups {
+		if !allDigits(g) || g == "" || len(g) > 3 || (i > 0 && len(g) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// cutSign splits a leading plus or minus sign from s.
+func cutSign(s string) (sign, rest string) {
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return s[:1], trimNumberSpace(s[1:])
+	}
+	return "", s
+}
+
+// trimCurrency removes a currency symbol or three-letter code from the start
+// or end of s.
+func trimCurrency(s string) string {
+	for _, symbol := range currencySymbolsByLength {
+		if rest, ok := strings.CutPrefix(s, symbol); ok {
+			return trimNumberSpace(rest)
+		}
+		if rest, ok := strings.CutSuffix(s, symbol); ok {
+			return trimNumberSpace(rest)
+		}
+	}
+	if len(s) > 3 && isCurrencyCode(s[:3]) {
+		return trimNumberSpace(s[3:])
+	}
+	if len(s) > 3 && isCurrencyCode(s[len(s)-3:]) {
+		return trimNumberSpace(s[:len(s)-3])
+	}
+	return s
+}
+
+// currencySymbolsByLength lists the currency symbols, longest first so "CA$"
+// is matched before "$".
+var currencySymbolsByLength = func() []string {
+	symbols := []string{"$"}
+	for _, symbol := range currencySymbols {
+		if !slices.Contains(symbols, symbol) {
+			symbols = append(symbols, symbol)
+		}
+	}
+	slices.SortFunc(symbols, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	return symbols
+}()
+
+// isCurrencyCode reports whether s is a three-letter ISO currency code.
+func isCurrencyCode(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return len(s) == 3
+}
+
+// isNumberSpace reports whether s is one of the spaces used to separate a
+// number from its symbol or to group its digits.
+func isNumberSpace(s string) bool {
+	return s == " " || s == "\u00a0" || s == "\u202f"
+}
+
+// trimNumberSpace trims the spaces isNumberSpace accepts.
+func trimNumberSpace(s string) string {
+	return strings.Trim(s, " \u00a0\u202f")
+}
+
+// allDigits reports whether s consists only of ASCII digits.
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
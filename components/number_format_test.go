@@ -0,0 +1,76 @@
+package components
+
+import "testing"
+
+// TestFormatNumber verifies numbers are grouped and rounded per locale.
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      float64
+		scale  int
+		want   string
+	}{
+		{"en_US", 1234567.891, 2, "1,234,567.89"},
+		{"en_US", -1234.5, 2, "-1,234.50"},
+		{"en_US", 999, 0, "999"},
+		{"de_DE", 1234.5, 2, "1.234,50"},
+		{"fr_FR", 1234.5, 1, "1\u00a0234,5"},
+		{"de_CH", 1234.5, 2, "1'234.50"},
+		{"xx_YY", 1000, 0, "1,000"},
+	}
+	for _, tt := range tests {
+		if got := NumberLocaleFor(tt.locale).FormatNumber(tt.n, tt.scale); got != tt.want {
+			t.Errorf("%s FormatNumber(%v, %d) = %q, want %q", tt.locale, tt.n, tt.scale, got, tt.want)
+		}
+	}
+}
+
+// TestFormatCurrency verifies the currency symbol is placed as the locale
+// writes it.
+func TestFormatCurrency(t *testing.T) {
+	if got := NumberLocaleFor("en_US").FormatCurrency(-1234.5, 2, "USD"); got != "-$1,234.50" {
+		t.Errorf("unexpected en_US amount %q", got)
+	}
+	if got := NumberLocaleFor("de_DE").FormatCurrency(1234.5, 2, "EUR"); got != "1.234,50\u00a0€" {
+		t.Errorf("unexpected de_DE amount %q", got)
+	}
+	if got := CurrencySymbol("sek"); got != "SEK" {
+		t.Errorf("expected unknown codes to be shown as the code, got %q", got)
+	}
+}
+
+// TestParseNumber verifies locale separators, symbols and invalid text.
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		locale string
+		text   string
+		want   float64
+		ok     bool
+	}{
+		{"en_US", "1,234.5", 1234.5, true},
+		{"en_US", "$ 1,234", 1234, true},
+		{"en_US", "12.5%", 12.5, true},
+		{"de_DE", "1.234,5", 1234.5, true},
+		{"fr_FR", "1 234,5 €", 1234.5, true},
+		{"en_US", "12abc", 0, false},
+		{"en_US", "1.2.3", 0, false},
+		{"en_US", "", 0, false},
+		{"en_US", "-$1,234.50", -1234.5, true},
+		{"en_US", "1,234 USD", 1234, true},
+		{"en_US", "CA$12", 12, true},
+		{"de_CH", "1'234.5", 1234.5, true},
+		{"en_US", "1E5", 0, false},
+		{"en_US", "12X34", 0, false},
+		{"en_US", "1,2,3.4", 0, false},
+		{"en_US", "1,23", 0, false},
+		{"en_US", "1.2,345", 0, false},
+		{"en_US", "12$34", 0, false},
+		{"de_DE", "1.5", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := NumberLocaleFor(tt.locale).ParseNumber(tt.text)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("%s ParseNumber(%q) = %v, %v; want %v, ok %v", tt.locale, tt.text, got, err, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package components
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// NumberInputProps configures a NumberInput, CurrencyInput or PercentInput.
+type NumberInputProps struct {
+	Label string
+	// Value is the current number, or nil when the input is blank.
+	Value *float64
+	// Scale is the number of decimal places allowed and shown.
+	Scale int
+	// Precision limits the total number of digits; 0 means no limit.
+	Precision int
+	// Min and Max bound the value when set.
+	Min *float64
+	Max *float64
+	// Locale sets the decimal and group separators. Defaults to those of
+	// the user's locale (see i18n.Locale).
+	Locale *NumberLocale
+	// Currency is the ISO code of a CurrencyInput's currency, e.g. "EUR".
+	// Defaults to the user's currency (see i18n.Currency).
+	Currency    string
+	Placeholder string
+
+	// OnChange receives each valid edit: the number, or nil when cleared.
+	OnChange func(value *float64)
+	// OnInvalid receives the error message when the text entered is not a
+	// valid number for the input. The value is left unchanged.
+	OnInvalid func(message string)
+}
+
+// FromField returns props with the label, precision and scale of a number,
+// currency or percent field.
+func (props NumberInputProps) FromField(field api.FieldInfo) NumberInputProps {
+	props.Label = field.Label
+	if field.Scale != nil {
+		props.Scale = *field.Scale
+	}
+	if field.Precision != nil {
+		props.Precision = *field.Precision
+	}
+	if field.DataType == "Int" || field.DataType == "Long" {
+		props.Scale = 0
+	}
+	return props
+}
+
+type numberKind int
+
+const (
+	plainNumber numberKind = iota
+	currencyNumber
+	percentNumber
+)
+
+// NumberInput renders a text input for a number written in the user's
+// locale. Text that is not a number, or exceeds Scale, Precision, Min or Max,
+// is rejected with an error beneath the input; the value is formatted with
+// grouped thousands when the input loses focus.
+func NumberInput(props NumberInputProps) masc.ComponentOrHTML {
+	return ValidatedNumberInput(props, ValidationState{})
+}
+
+// ValidatedNumberInput renders a NumberInput with validation support.
+func ValidatedNumberInput(props NumberInputProps, validation ValidationState) masc.ComponentOrHTML {
+	return &numberInput{Props: props, Kind: plainNumber, Validation: validation}
+}
+
+// CurrencyInput renders a NumberInput for an amount, with the currency symbol
+// before or after it as the locale writes it.
+func CurrencyInput(props NumberInputProps) masc.ComponentOrHTML {
+	return ValidatedCurrencyInput(props, ValidationState{})
+}
+
+// ValidatedCurrencyInput renders a CurrencyInput with validation support.
+func ValidatedCurrencyInput(props NumberInputProps, validation ValidationState) masc.ComponentOrHTML {
+	return &numberInput{Props: props, Kind: currencyNumber, Validation: validation}
+}
+
+// PercentInput renders a NumberInput followed by a percent sign. As in
+// Salesforce, the value is the percentage itself: 12.5 for 12.5%.
+func PercentInput(props NumberInputProps) masc.ComponentOrHTML {
+	return ValidatedPercentInput(props, ValidationState{})
+}
+
+// ValidatedPercentInput renders a PercentInput with validation support.
+func ValidatedPercentInput(props NumberInputProps, validation ValidationState) masc.ComponentOrHTML {
+	return &numberInput{Props: props, Kind: percentNumber, Validation: validation}
+}
+
+// numberInput keeps the text being typed into a number input, which may not
+// yet be a valid number, while the app's model holds the parsed value.
+type numberInput struct {
+	masc.Core
+
+	Props      NumberInputProps `masc:"prop"`
+	Kind       numberKind       `masc:"prop"`
+	Validation ValidationState  `masc:"prop"`
+
+	text    string
+	editing bool
+	invalid string
+}
+
+func (n *numberInput) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	locale := NumberLocaleFor(i18n.Locale())
+	if n.Props.Locale != nil {
+		locale = *n.Props.Locale
+	}
+	if !n.editing {
+		n.text = n.format(locale)
+		n.invalid = ""
+	}
+	validation := WithError(n.Validation, n.invalid)
+	placeholder := n.Props.Placeholder
+	if placeholder == "" {
+		placeholder = validation.Placeholder
+	}
+
+	input := elem.Input(masc.Markup(
+		masc.Class("slds-input"),
+		masc.Property("type", "text"),
+		masc.Property("value", n.text),
+		masc.Property("placeholder", placeholder),
+		masc.Property("required", validation.Required),
+		masc.Attribute("inputmode", n.inputMode()),
+		event.Focus(func(e *masc.Event) {
+			n.editing = true
+		}),
+		event.Input(func(e *masc.Event) {
+			n.editing = true
+			n.text = e.Target.Get("value").String()
+			value, msg := n.parse(locale, n.text)
+			n.invalid = msg
+			if msg != "" {
+				if n.Props.OnInvalid != nil {
+					n.Props.OnInvalid(msg)
+				}
+				return
+			}
+			if n.Props.OnChange != nil {
+				n.Props.OnChange(value)
+			}
+		}),
+		event.Blur(func(e *masc.Event) {
+			if n.invalid != "" {
+				return
+			}
+			n.editing = false
+			n.text = n.format(locale)
+			e.Target.Set("value", n.text)
+		}),
+	))
+
+	var control masc.ComponentOrHTML = input
+	switch n.Kind {
+	case currencyNumber:
+		currency := n.Props.Currency
+		if currency == "" {
+			currency = i18n.Currency()
+		}
+		symbol := elem.Span(
+			masc.Markup(masc.Class("slds-form-element__addon")),
+			masc.Text(CurrencySymbol(currency)),
+		)
+		if locale.SymbolAfter {
+			control = elem.Div(masc.Markup(masc.Class("slds-input-has-fixed-addon")), input, symbol)
+		} else {
+			control = elem.Div(masc.Markup(masc.Class("slds-input-has-fixed-addon")), symbol, input)
+		}
+	case percentNumber:
+		control = elem.Div(
+			masc.Markup(masc.Class("slds-input-has-fixed-addon")),
+			input,
+			elem.Span(
+				masc.Markup(masc.Class("slds-form-element__addon")),
+				masc.Text("%"),
+			),
+		)
+	}
+	return validatedFormElement(n.Props.Label, validation, control)
+}
+
+// format writes the current value for display.
+func (n *numberInput) format(locale NumberLocale) string {
+	if n.Props.Value == nil {
+		return ""
+	}
+	return locale.FormatNumber(*n.Props.Value, n.Props.Scale)
+}
+
+func (n *numberInput) inputMode() string {
+	if n.Props.Scale == 0 {
+		return "numeric"
+	}
+	return "decimal"
+}
+
+// parse reads text as the input's value, returning an error message when it
+// is not acceptable.
+func (n *numberInput) parse(locale NumberLocale, text string) (*float64, string) {
+	if IsEmptyOrWhitespace(text) {
+		return nil, ""
+	}
+	v, err := locale.ParseNumber(text)
+	if err != nil {
+		return nil, i18n.T("Thunder_Enter_Number")
+	}
+	if msg := checkNumber(v, n.Props); msg != "" {
+		return nil, msg
+	}
+	return &v, ""
+}
+
+// checkNumber checks v against the scale, precision and bounds in props.
+func checkNumber(v float64, props NumberInputProps) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return i18n.T("Thunder_Enter_Number")
+	}
+	if decimalPlaces(v) > props.Scale {
+		if props.Scale == 0 {
+			return i18n.T("Thunder_Enter_Whole_Number")
+		}
+		return i18n.T("Thunder_Enter_Max_Decimal_Places", props.Scale)
+	}
+	if props.Precision > 0 {
+		if digits := props.Precision - props.Scale; integerDigits(v) > digits {
+			return i18n.T("Thunder_Enter_Max_Integer_Digits", digits)
+		}
+	}
+	if props.Min != nil && v < *props.Min {
+		return i18n.T("Thunder_Enter_Min", strconv.FormatFloat(*props.Min, 'f', -1, 64))
+	}
+	if props.Max != nil && v > *props.Max {
+		return i18n.T("Thunder_Enter_Max", strconv.FormatFloat(*props.Max, 'f', -1, 64))
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// TestCurrencyInputFormatsValue verifies the value is shown formatted with
+// the currency symbol.
+func TestCurrencyInputFormatsValue(t *testing.T) {
+	amount := 1234.5
+	de := NumberLocaleFor("de_DE")
+	win := renderComponent(t, CurrencyInput(NumberInputProps{
+		Label:    "Amount",
+		Value:    &amount,
+		Scale:    2,
+		Locale:   &de,
+		Currency: "EUR",
+	}))
+
+	input := querySelector(t, win, "input.slds-input")
+	if value, _ := input.GetAttribute("value"); value != "1.234,50" {
+		t.Errorf("expected formatted amount, got %q", value)
+	}
+	addons, err := win.Document().QuerySelectorAll(".slds-form-element__addon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addons.Length() != 1 || addons.Item(0).TextContent() != "€" {
+		t.Fatal("expected a euro symbol addon")
+	}
+	if querySelector(t, win, ".slds-input-has-fixed-addon input + .slds-form-element__addon") == nil {
+		t.Error("expected the symbol after the amount in de_DE")
+	}
+}
+
+// TestNumberInputRejectsInvalidText verifies text is checked against the
+// locale, scale, precision and bounds.
+func TestNumberInputRejectsInvalidText(t *testing.T) {
+	max := 100.0
+	n := &numberInput{Props: NumberInputProps{Scale: 2, Precision: 5, Max: &max}}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"12.5", ""},
+		{"", ""},
+		{"abc", "Enter a valid number"},
+		{"1.234", "Enter a number with at most 2 decimal places"},
+		{"1234", "Enter a number with at most 3 digits before the decimal point"},
+		{"150", "Enter a number no greater than 100"},
+	}
+	for _, tt := range tests {
+		if _, msg := n.parse(DefaultNumberLocale, tt.text); msg != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.text, msg, tt.want)
+		}
+	}
+	if v, _ := n.parse(DefaultNumberLocale, " "); v != nil {
+		t.Error("expected blank text to clear the value")
+	}
+}
+
+// TestNumberInputPropsFromField verifies precision and scale come from the
+// field metadata.
+func TestNumberInputPropsFromField(t *testing.T) {
+	precision, scale := 18, 2
+	props := NumberInputProps{}.FromField(api.FieldInfo{Label: "Quantity", DataType: "Int", Precision: &precision, Scale: &scale})
+	if props.Label != "Quantity" || props.Precision != 18 || props.Scale != 0 {
+		t.Errorf("unexpected props %+v", props)
+	}
+}
@@ -0,0 +1,236 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// PathStage is one stage of a Path.
+type PathStage struct {
+	Label string
+	Value string
+	// Closed stages end the path, e.g. Opportunity's Closed Won and Closed
+	// Lost; Won marks the closed stage that succeeded.
+	Closed bool
+	Won    bool
+}
+
+// PathStagesFromPicklist returns a Path's stages from a picklist's values
+// (see api.GetPicklistValuesByRecordType), reading the closed and won
+// attributes Salesforce gives stage picklists such as Opportunity.StageName.
+func PathStagesFromPicklist(field api.PicklistFieldValue) []PathStage {
+	stages := make([]PathStage, 0, len(field.Values))
+	for _, pv := range field.Values {
+		stage := PathStage{Label: pv.Label, Value: pv.Value}
+		if attrs, ok := pv.Attributes.(map[string]interface{}); ok {
+			stage.Closed, _ = attrs["closed"].(bool)
+			stage.Won, _ = attrs["won"].(bool)
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// PathProps configures a Path. Like the other components it keeps no state
+// of its own beyond its element ids: the app stores the selected stage and
+// passes it back in.
+type PathProps struct {
+	Stages []PathStage
+	// Current is the value of the record's current stage.
+	Current string
+	// Selected is the value of the stage the user clicked. It defaults to
+	// Current.
+	Selected string
+	// OnSelect receives the value of a clicked stage.
+	OnSelect func(value string)
+	// OnMarkCurrent receives the stage to make current: the selected stage
+	// from "Mark as Current Stage", or the next stage from "Mark Stage as
+	// Complete". The app saves it, e.g. with api.Patch, and passes the new
+	// Current back in. Without it no button is shown.
+	OnMarkCurrent func(value string)
+}
+
+// Path renders an SLDS path showing the stages of a record, such as an
+// Opportunity's StageName, with the open stages before the current one
+// complete. Closed stages other than the current one are never complete: a
+// Closed Lost opportunity did not pass through Closed Won. Clicking a stage
+// selects it; the button beside the path marks the selected stage as current,
+// or completes the current stage. The next stage is never completed into a
+// closed one, since the user should choose between the closed stages.
+func Path(props PathProps) masc.ComponentOrHTML {
+	return &pathComponent{Props: props}
+}
+
+// pathIDs numbers paths so each gives its stages unique ids.
+var pathIDs atomic.Int64
+
+// pathComponent is the implementation behind Path. It is a component so its
+// generated ids survive re-renders.
+type pathComponent struct {
+	masc.Core
+
+	Props PathProps `masc:"prop"`
+
+	id string
+}
+
+func (c *pathComponent) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if c.id == "" {
+		c.id = fmt.Sprintf("path-%d", pathIDs.Add(1))
+	}
+	props := c.Props
+	current := -1
+	for i, stage := range props.Stages {
+		if stage.Value == props.Current {
+			current = i
+		}
+	}
+	selected := props.Selected
+	if selected == "" {
+		selected = props.Current
+	}
+
+	items := []masc.MarkupOrChild{
+		masc.Markup(
+			masc.Class("slds-path__nav"),
+			masc.Attribute("role", "listbox"),
+			masc.Attribute("aria-orientation", "horizontal"),
+		),
+	}
+	for i, stage := range props.Stages {
+		items = append(items, pathItem(fmt.Sprintf("%s-stage-%d", c.id, i), i, stage, current, stage.Value == selected, props.OnSelect))
+	}
+
+	track := []masc.MarkupOrChild{
+		masc.Markup(masc.Class("slds-grid", "slds-path__track")),
+		elem.Div(
+			masc.Markup(masc.Class("slds-grid", "slds-path__scroller-container")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-path__scroller")),
+				elem.Div(
+					masc.Markup(masc.Class("slds-path__scroller_inner")),
+					elem.UnorderedList(items...),
+				),
+			),
+		),
+	}
+	if action := pathAction(props, current, selected); action != nil {
+		track = append(track, elem.Div(
+			masc.Markup(masc.Class("slds-grid", "slds-path__action")),
+			action,
+		))
+	}
+	return elem.Div(
+		masc.Markup(masc.Class("slds-path")),
+		elem.Div(track...),
+	)
+}
+
+// pathItem renders the stage at index i of a Path whose current stage is at
+// index current.
+func pathItem(id string, i int, stage PathStage, current int, selected bool, onSelect func(string)) masc.ComponentOrHTML {
+	complete := i < current && !stage.Closed
+	classes := []string{"slds-path__item"}
+	var assistive string
+	switch {
+	case i == current && stage.Closed && stage.Won:
+		classes = append(classes, "slds-is-current", "slds-is-won")
+		assistive = i18n.T("Thunder_Current_Stage")
+	case i == current && stage.Closed:
+		classes = append(classes, "slds-is-current", "slds-is-lost")
+		assistive = i18n.T("Thunder_Current_Stage")
+	case i == current:
+		classes = append(classes, "slds-is-current")
+		assistive = i18n.T("Thunder_Current_Stage")
+	case complete:
+		classes = append(classes, "slds-is-complete")
+		assistive = i18n.T("Thunder_Stage_Complete")
+	default:
+		classes = append(classes, "slds-is-incomplete")
+	}
+	if selected {
+		classes = append(classes, "slds-is-active")
+	}
+
+	var marker masc.MarkupOrChild
+	if complete {
+		marker = Icon(UtilityIcon, "check", IconSmall)
+	}
+	link := []masc.Applyer{
+		masc.Class("slds-path__link"),
+		masc.Attribute("href", "javascript:void(0);"),
+		masc.Property("id", id),
+		masc.Attribute("role", "option"),
+		masc.Attribute("aria-selected", strconv.FormatBool(selected)),
+		masc.Data("value", stage.Value),
+	}
+	if selected {
+		link = append(link, masc.Attribute("tabindex", "0"))
+	} else {
+		link = append(link, masc.Attribute("tabindex", "-1"))
+	}
+	if onSelect != nil {
+		value := stage.Value
+		link = append(link, event.Click(func(*masc.Event) { onSelect(value) }).PreventDefault())
+	}
+	return elem.ListItem(
+		masc.Markup(
+			masc.Class(classes...),
+			masc.Attribute("role", "presentation"),
+		),
+		elem.Anchor(
+			masc.Markup(link...),
+			elem.Span(
+				masc.Markup(masc.Class("slds-path__stage")),
+				marker,
+				masc.If(assistive != "", elem.Span(
+					masc.Markup(masc.Class("slds-assistive-text")),
+					masc.Text(assistive),
+				)),
+			),
+			elem.Span(
+				masc.Markup(masc.Class("slds-path__title")),
+				masc.Text(stage.Label),
+			),
+		),
+	)
+}
+
+// pathAction returns the Path's button, or nil when there is nothing to do.
+func pathAction(props PathProps, current int, selected string) masc.ComponentOrHTML {
+	if props.OnMarkCurrent == nil {
+		return nil
+	}
+	if selected != props.Current {
+		for _, stage := range props.Stages {
+			if stage.Value == selected {
+				return pathButton(i18n.T("Thunder_Mark_Current_Stage"), func() { props.OnMarkCurrent(selected) })
+			}
+		}
+		return nil
+	}
+	if current < 0 || current+1 >= len(props.Stages) || props.Stages[current].Closed || props.Stages[current+1].Closed {
+		return nil
+	}
+	next := props.Stages[current+1].Value
+	return pathButton(i18n.T("Thunder_Mark_Stage_Complete"), func() { props.OnMarkCurrent(next) })
+}
+
+// pathButton renders the Path's brand button.
+func pathButton(label string, onClick func()) masc.ComponentOrHTML {
+	return elem.Button(
+		masc.Markup(
+			masc.Class("slds-button", "slds-button_brand", "slds-path__mark-complete"),
+			masc.Attribute("type", "button"),
+			event.Click(func(*masc.Event) { onClick() }),
+		),
+		masc.Text(label),
+	)
+}
@@ -0,0 +1,127 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+var opportunityStages = []PathStage{
+	{Label: "Prospecting", Value: "Prospecting"},
+	{Label: "Negotiation", Value: "Negotiation"},
+	{Label: "Closed Won", Value: "Closed Won", Closed: true, Won: true},
+	{Label: "Closed Lost", Value: "Closed Lost", Closed: true},
+}
+
+// TestPathStagesFromPicklist verifies the closed and won attributes of stage
+// picklists are read.
+func TestPathStagesFromPicklist(t *testing.T) {
+	stages := PathStagesFromPicklist(api.PicklistFieldValue{Values: []api.PicklistValue{
+		{Label: "Prospecting", Value: "Prospecting", Attributes: map[string]interface{}{"closed": false, "won": false}},
+		{Label: "Closed Won", Value: "Closed Won", Attributes: map[string]interface{}{"closed": true, "won": true}},
+		{Label: "Other", Value: "Other"},
+	}})
+	if len(stages) != 3 || stages[0].Closed || !stages[1].Closed || !stages[1].Won || stages[2].Closed {
+		t.Errorf("unexpected stages %+v", stages)
+	}
+}
+
+// TestPathMarksStages verifies stages before the current one are complete
+// and the current stage can be completed.
+func TestPathMarksStages(t *testing.T) {
+	var marked string
+	win := renderComponent(t, Path(PathProps{
+		Stages:        opportunityStages,
+		Current:       "Prospecting",
+		OnMarkCurrent: func(v string) { marked = v },
+	}))
+
+	if item := querySelector(t, win, ".slds-is-current.slds-is-active [data-value]"); item == nil {
+		t.Fatal("expected the current stage to be active")
+	} else if v, _ := item.GetAttribute("data-value"); v != "Prospecting" {
+		t.Errorf("expected Prospecting to be current, got %q", v)
+	}
+	button := querySelector(t, win, ".slds-path__mark-complete")
+	if button == nil || button.TextContent() != "Mark Stage as Complete" {
+		t.Fatal("expected a Mark Stage as Complete button")
+	}
+	if typ, _ := button.GetAttribute("type"); typ != "button" {
+		t.Errorf("expected a non-submitting button, got type %q", typ)
+	}
+	button.(html.HTMLElement).Click()
+	if marked != "Negotiation" {
+		t.Errorf("expected the next stage to be marked current, got %q", marked)
+	}
+}
+
+// TestPathMarksSelectedStageCurrent verifies a selected stage can be made
+// current, and the last open stage is not completed into a closed one.
+func TestPathMarksSelectedStageCurrent(t *testing.T) {
+	var marked string
+	win := renderComponent(t, Path(PathProps{
+		Stages:        opportunityStages,
+		Current:       "Negotiation",
+		Selected:      "Closed Lost",
+		OnMarkCurrent: func(v string) { marked = v },
+	}))
+	if complete := querySelector(t, win, ".slds-is-complete [data-value=Prospecting]"); complete == nil {
+		t.Error("expected the earlier stage to be complete")
+	}
+	button := querySelector(t, win, ".slds-path__mark-complete")
+	if button == nil || button.TextContent() != "Mark as Current Stage" {
+		t.Fatal("expected a Mark as Current Stage button")
+	}
+	button.(html.HTMLElement).Click()
+	if marked != "Closed Lost" {
+		t.Errorf("expected the selected stage to be marked current, got %q", marked)
+	}
+
+	win = renderComponent(t, Path(PathProps{
+		Stages:        opportunityStages,
+		Current:       "Negotiation",
+		OnMarkCurrent: func(string) {},
+	}))
+	if button := querySelector(t, win, ".slds-path__mark-complete"); button != nil {
+		t.Error("expected no button before the closed stages")
+	}
+}
+
+// TestPathShowsLostStage verifies a lost current stage is marked as such, and
+// the won stage before it is not shown complete.
+func TestPathShowsLostStage(t *testing.T) {
+	win := renderComponent(t, Path(PathProps{Stages: opportunityStages, Current: "Closed Lost"}))
+	if lost := querySelector(t, win, ".slds-is-lost [data-value='Closed Lost']"); lost == nil {
+		t.Error("expected the lost stage to be marked")
+	}
+	if won := querySelector(t, win, ".slds-is-won"); won != nil {
+		t.Error("expected no won stage")
+	}
+	if won := querySelector(t, win, ".slds-is-complete [data-value='Closed Won']"); won != nil {
+		t.Error("expected Closed Won not to be complete")
+	}
+	if open := querySelector(t, win, ".slds-is-complete [data-value=Negotiation]"); open == nil {
+		t.Error("expected the open stages to be complete")
+	}
+}
+
+// TestPathUniqueIDs verifies two paths on a page give their stages distinct
+// ids.
+func TestPathUniqueIDs(t *testing.T) {
+	props := PathProps{Stages: opportunityStages, Current: "Prospecting"}
+	win := renderComponent(t, elem.Div(Path(props), Path(props)))
+
+	links, err := win.Document().QuerySelectorAll("[data-value=Prospecting]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if links.Length() != 2 {
+		t.Fatalf("expected 2 Prospecting stages, got %d", links.Length())
+	}
+	first, _ := links.Item(0).(html.HTMLElement).GetAttribute("id")
+	second, _ := links.Item(1).(html.HTMLElement).GetAttribute("id")
+	if first == "" || first == second {
+		t.Errorf("expected distinct stage ids, got %q and %q", first, second)
+	}
+}
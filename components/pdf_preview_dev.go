@@ -0,0 +1,21 @@
+//go:build js && dev
+
+package components
+
+import "syscall/js"
+
+// pdfFrameURL returns a blob URL for a PDF's contents that thunder serve's
+// page can frame.
+func pdfFrameURL(data []byte) string {
+	u8 := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(u8, data)
+	parts := js.Global().Get("Array").New(1)
+	parts.SetIndex(0, u8)
+	blob := js.Global().Get("Blob").New(parts, map[string]interface{}{"type": "application/pdf"})
+	return js.Global().Get("URL").Call("createObjectURL", blob).String()
+}
+
+// revokeFrameURL releases a URL from pdfFrameURL.
+func revokeFrameURL(url string) {
+	js.Global().Get("URL").Call("revokeObjectURL", url)
+}
@@ -0,0 +1,10 @@
+//go:build js && !dev
+
+package components
+
+// Lightning's Content Security Policy doesn't allow frames from data: or
+// blob: URLs, so PDFs are offered for download instead of framed.
+
+func pdfFrameURL(data []byte) string { return "" }
+
+func revokeFrameURL(url string) {}
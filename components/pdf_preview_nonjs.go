@@ -0,0 +1,10 @@
+//go:build !js
+
+package components
+
+// PDFs are framed from blob URLs, which only exist in the browser; outside
+// it they are offered for download.
+
+func pdfFrameURL(data []byte) string { return "" }
+
+func revokeFrameURL(url string) {}
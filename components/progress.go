@@ -1,11 +1,16 @@
 package components
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
-// ProgressStep represents a single step in a vertical progress indicator.
+// ProgressStep represents a single step in a progress indicator.
 type ProgressStep struct {
 	Name        string
 	IsActive    bool
@@ -68,3 +73,76 @@ func VerticalProgress(steps []ProgressStep) masc.ComponentOrHTML {
 		elem.OrderedList(olArgs...),
 	)
 }
+
+// HorizontalProgress renders an SLDS progress indicator: a marker for each
+// step along a bar filled up to the active step. Step names show as
+// tooltips. When onSelect is set, clicking a completed step's marker sends
+// its index, e.g. to go back to it.
+func HorizontalProgress(steps []ProgressStep, onSelect func(index int)) masc.ComponentOrHTML {
+	items := []masc.MarkupOrChild{masc.Markup(masc.Class("slds-progress__list"))}
+	active := 0
+	for i, step := range steps {
+		itemClasses := []string{"slds-progress__item"}
+		markerClasses := []string{"slds-button", "slds-progress__marker"}
+		title := step.Name
+		var icon masc.MarkupOrChild
+		switch {
+		case step.IsCompleted:
+			itemClasses = append(itemClasses, "slds-is-completed")
+			markerClasses = append(markerClasses, "slds-button_icon", "slds-progress__marker_icon")
+			title = i18n.T("Thunder_Step_Completed", step.Name)
+			icon = Icon(UtilityIcon, "success", IconSmall)
+		case step.IsActive:
+			itemClasses = append(itemClasses, "slds-is-active")
+			title = i18n.T("Thunder_Step_Active", step.Name)
+		}
+		if step.IsActive {
+			active = i
+		}
+
+		marker := []masc.Applyer{
+			masc.Class(markerClasses...),
+			masc.Attribute("type", "button"),
+			masc.Property("title", title),
+		}
+		if onSelect != nil && step.IsCompleted && !step.IsActive {
+			index := i
+			marker = append(marker, event.Click(func(*masc.Event) { onSelect(index) }))
+		} else {
+			marker = append(marker, masc.Attribute("aria-disabled", "true"))
+		}
+		items = append(items, elem.ListItem(
+			masc.Markup(masc.Class(itemClasses...)),
+			elem.Button(
+				masc.Markup(marker...),
+				icon,
+				elem.Span(
+					masc.Markup(masc.Class("slds-assistive-text")),
+					masc.Text(title),
+				),
+			),
+		))
+	}
+
+	percent := 0
+	if len(steps) > 1 {
+		percent = active * 100 / (len(steps) - 1)
+	}
+	return elem.Div(
+		masc.Markup(masc.Class("slds-progress")),
+		elem.OrderedList(items...),
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-progress-bar", "slds-progress-bar_x-small"),
+				masc.Attribute("role", "progressbar"),
+				masc.Attribute("aria-valuemin", "0"),
+				masc.Attribute("aria-valuemax", "100"),
+				masc.Attribute("aria-valuenow", strconv.Itoa(percent)),
+			),
+			elem.Span(masc.Markup(
+				masc.Class("slds-progress-bar__value"),
+				masc.Style("width", fmt.Sprintf("%d%%", percent)),
+			)),
+		),
+	)
+}
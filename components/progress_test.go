@@ -36,3 +36,29 @@ func TestVerticalProgressStepStates(t *testing.T) {
 		t.Error("VerticalProgress returned nil for mixed step states")
 	}
 }
+
+// TestHorizontalProgressMarksSteps verifies completed and active steps are
+// marked and the bar is filled up to the active step.
+func TestHorizontalProgressMarksSteps(t *testing.T) {
+	win := renderComponent(t, HorizontalProgress([]ProgressStep{
+		{Name: "One", IsCompleted: true},
+		{Name: "Two", IsActive: true},
+		{Name: "Three"},
+	}, nil))
+
+	if done := querySelector(t, win, ".slds-is-completed .slds-progress__marker"); done == nil {
+		t.Fatal("expected a completed step")
+	} else if title, _ := done.GetAttribute("title"); title != "One - Completed" {
+		t.Errorf("expected a completed title, got %q", title)
+	} else if typ, _ := done.GetAttribute("type"); typ != "button" {
+		t.Errorf("expected a non-submitting marker, got type %q", typ)
+	}
+	if active := querySelector(t, win, ".slds-is-active .slds-assistive-text"); active == nil || active.TextContent() != "Two - Active" {
+		t.Error("expected an active step")
+	}
+	if bar := querySelector(t, win, "[role=progressbar]"); bar == nil {
+		t.Fatal("expected a progress bar")
+	} else if now, _ := bar.GetAttribute("aria-valuenow"); now != "50" {
+		t.Errorf("expected the bar half full, got %q", now)
+	}
+}
@@ -0,0 +1,609 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// RecordFormSection groups fields under a heading in a RecordForm.
+type RecordFormSection struct {
+	Title  string
+	Fields []string
+}
+
+// RecordFormProps configures a RecordForm. Like the other components it keeps
+// no state: the app stores the edited Values and passes them back in,
+// updating them from OnChange.
+type RecordFormProps struct {
+	// ObjectInfo describes the object's fields (see api.GetObjectInfo).
+	ObjectInfo api.ObjectInfo
+	// Picklists holds picklist values for the record type (see
+	// api.GetPicklistValuesByRecordType).
+	Picklists map[string]api.PicklistFieldValue
+	// Fields lists the field API names to show, in order. Ignored when
+	// Sections is set.
+	Fields []string
+	// Sections lays the fields out under headings.
+	Sections []RecordFormSection
+	// Columns is the number of fields per row: 1 or 2 (the default).
+	Columns int
+
+	// Record holds the saved field values; an Id means the record exists.
+	Record map[string]interface{}
+	// Values holds the edited field values, overriding Record.
+	Values map[string]interface{}
+	// Errors holds errors for fields, e.g. from a failed save. They are
+	// shown alongside validation errors.
+	Errors map[string]string
+	// ShowErrors shows validation errors; set it after the first failed
+	// submit so untouched fields are not flagged while the user is typing.
+	ShowErrors bool
+	// Locale and Currency format number and currency fields; see
+	// NumberLocaleFor. They default to the user's (see i18n.Locale and
+	// i18n.Currency).
+	Locale   *NumberLocale
+	Currency string
+	// Location is the time zone DateTime fields are shown and entered in.
+	// Defaults to the user's (see i18n.Location).
+	Location *time.Location
+	// IsSaving shows a spinner on the Save button.
+	IsSaving bool
+	// Lookups holds the search state of reference fields, by field name.
+	// Store the state passed to OnLookupChange and pass it back here.
+	Lookups map[string]RecordFormLookup
+	// OpenPicklist is the multi-select picklist field whose options are
+	// showing.
+	OpenPicklist string
+
+	// OnChange receives each edit: a string for text, date, time, picklist
+	// and reference fields, a float64 (or nil when cleared) for numbers and
+	// a bool for checkboxes. Date/time values use Salesforce's formats.
+	OnChange func(field string, value interface{})
+	// OnSubmit receives the changed fields (see RecordChanges) when Save is
+	// clicked and the form is valid.
+	OnSubmit func(changes map[string]interface{})
+	// OnInvalid receives the validation errors when Save is clicked and the
+	// form is not valid.
+	OnInvalid func(errors map[string]string)
+	// OnCancel adds a Cancel button when set.
+	OnCancel func()
+	// OnLookupChange receives the new search state of a reference field as
+	// the user types, switches object or picks a record.
+	OnLookupChange func(field string, lookup RecordFormLookup)
+	// OnLookupSearch is called once typing in a reference field pauses; run
+	// the search, e.g. with api.SearchRecords, and store the results in the
+	// field's Lookups entry. Like RecordLookupProps.OnSearch it runs on a
+	// timer goroutine.
+	OnLookupSearch func(field string, target LookupTarget, text string)
+	// OnOpenPicklist receives the multi-select picklist field whose options
+	// are opened, or "" when they are closed.
+	OnOpenPicklist func(field string)
+}
+
+// RecordFormLookup is the search state of a reference field in a RecordForm.
+type RecordFormLookup struct {
+	// Target is the object searched by a polymorphic field.
+	Target      string
+	SearchText  string
+	Results     []LookupResult
+	IsSearching bool
+	// SelectedLabel is the name of the selected record. It defaults to the
+	// Name of the related record in the form's values, e.g. Account.Name for
+	// AccountId.
+	SelectedLabel string
+}
+
+// RecordForm renders an edit form for a record, choosing the input for each
+// field from its FieldInfo.DataType. Required, length and precision rules
+// come from the field metadata, dependent picklists only offer the values
+// valid for their controlling field, and read-only fields are shown as text.
+func RecordForm(props RecordFormProps) masc.ComponentOrHTML {
+	sections := props.Sections
+	if len(sections) == 0 {
+		sections = []RecordFormSection{{Fields: props.Fields}}
+	}
+	columns := props.Columns
+	if columns != 1 {
+		columns = 2
+	}
+	values := recordValues(props.Record, props.Values)
+	var validation map[string]string
+	if props.ShowErrors {
+		validation = ValidateRecord(props.ObjectInfo, formFields(props), values)
+	}
+
+	var content []masc.MarkupOrChild
+	for _, section := range sections {
+		var rows []masc.MarkupOrChild
+		for i := 0; i < len(section.Fields); i += columns {
+			var cols []masc.MarkupOrChild
+			for _, name := range section.Fields[i:min(i+columns, len(section.Fields))] {
+				errMsg := props.Errors[name]
+				if errMsg == "" {
+					errMsg = validation[name]
+				}
+				cols = append(cols, FormColumn(12/columns, masc.Markup(fieldTarget(name)), recordField(props, name, values, errMsg)))
+			}
+			rows = append(rows, FormRow(cols...))
+		}
+		if section.Title != "" {
+			content = append(content, FormSection(section.Title, rows...))
+		} else {
+			content = append(content, rows...)
+		}
+	}
+
+	save := Button(i18n.T("Thunder_Save"), VariantBrand, func(*masc.Event) {
+		if errs := ValidateRecord(props.ObjectInfo, formFields(props), values); len(errs) > 0 {
+			if props.OnInvalid != nil {
+				props.OnInvalid(errs)
+			}
+			return
+		}
+		if props.OnSubmit != nil {
+			props.OnSubmit(RecordChanges(props.Record, props.Values))
+		}
+	})
+	if props.IsSaving {
+		save = LoadingButton(i18n.T("Thunder_Saving"), VariantBrand)
+	}
+	var cancel masc.ComponentOrHTML
+	if props.OnCancel != nil {
+		cancel = Button(i18n.T("Thunder_Cancel"), VariantNeutral, func(*masc.Event) { props.OnCancel() })
+	}
+	content = append(content, MarginTop(SpaceMedium, ButtonGroupSpaced(cancel, save)))
+	// Buttons inside a form submit it; saving is handled by the Save button.
+	return FormWithAttributes(append([]masc.MarkupOrChild{
+		masc.Markup(event.Submit(func(*masc.Event) {}).PreventDefault()),
+	}, content...)...)
+}
+
+// formFields returns every field shown by the form.
+func formFields(props RecordFormProps) []string {
+	if len(props.Sections) == 0 {
+		return props.Fields
+	}
+	var fields []string
+	for _, s := range props.Sections {
+		fields = append(fields, s.Fields...)
+	}
+	return fields
+}
+
+// recordValues merges edited values over the saved record.
+func recordValues(record, edits map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(record)+len(edits))
+	for k, v := range record {
+		values[k] = v
+	}
+	for k, v := range edits {
+		values[k] = v
+	}
+	return values
+}
+
+// RecordChanges returns the edited values that differ from the saved record,
+// ready to send as the body of a record update. Empty strings are sent as
+// nil so the field is cleared.
+func RecordChanges(record, values map[string]interface{}) map[string]interface{} {
+	changes := make(map[string]interface{})
+	for field, v := range values {
+		if s, ok := v.(string); ok && s == "" {
+			v = nil
+		}
+		orig := record[field]
+		if s, ok := orig.(string); ok && s == "" {
+			orig = nil
+		}
+		if !reflect.DeepEqual(orig, v) {
+			changes[field] = v
+		}
+	}
+	return changes
+}
+
+// ValidateRecord checks values against the metadata for the given fields and
+// returns an error message per invalid field: missing required values, text
+// longer than the field length and numbers exceeding the field's precision
+// or scale. Read-only fields are not checked.
+func ValidateRecord(info api.ObjectInfo, fields []string, values map[string]interface{}) map[string]string {
+	_, exists := values["Id"]
+	errs := make(map[string]string)
+	for _, name := range fields {
+		field, ok := info.Fields[name]
+		if !ok || !fieldEditable(field, exists) {
+			continue
+		}
+		if msg := validateField(field, values[name]); msg != "" {
+			errs[name] = msg
+		}
+	}
+	return errs
+}
+
+// validateField checks one field value against its metadata.
+func validateField(field api.FieldInfo, v interface{}) string {
+	if isEmptyValue(v) {
+		if field.Required && field.DataType != "Boolean" {
+			return i18n.T("Thunder_Required", field.Label)
+		}
+		return ""
+	}
+	switch field.DataType {
+	case "String", "TextArea", "Email", "Phone", "Url", "EncryptedString", "Picklist", "MultiPicklist":
+		s := fmt.Sprint(v)
+		if field.Length != nil && *field.Length > 0 && len([]rune(s)) > *field.Length {
+			return i18n.T("Thunder_Max_Length", field.Label, *field.Length)
+		}
+		if field.DataType == "Email" {
+			if _, err := mail.ParseAddress(s); err != nil {
+				return i18n.T("Thunder_Bad_Email", field.Label)
+			}
+		}
+	case "Currency", "Double", "Percent", "Int", "Long":
+		n, ok := toFloat(v)
+		if !ok {
+			return i18n.T("Thunder_Bad_Number", field.Label)
+		}
+		scale := 0
+		if field.Scale != nil {
+			scale = *field.Scale
+		}
+		if field.DataType == "Int" || field.DataType == "Long" {
+			scale = 0
+		}
+		if decimals := decimalPlaces(n); decimals > scale {
+			if scale == 0 {
+				return i18n.T("Thunder_Bad_Whole_Number", field.Label)
+			}
+			return i18n.T("Thunder_Max_Decimal_Places", field.Label, scale)
+		}
+		if field.Precision != nil && *field.Precision > 0 {
+			digits := *field.Precision - scale
+			if integerDigits(n) > digits {
+				return i18n.T("Thunder_Max_Integer_Digits", field.Label, digits)
+			}
+		}
+	}
+	return ""
+}
+
+// decimalPlaces returns the number of digits after the decimal point in n.
+func decimalPlaces(n float64) int {
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// integerDigits returns the number of digits before the decimal point in n.
+func integerDigits(n float64) int {
+	return len(strconv.FormatFloat(math.Trunc(math.Abs(n)), 'f', 0, 64))
+}
+
+// fieldEditable reports whether field can be edited on a new (exists false)
+// or existing record.
+func fieldEditable(field api.FieldInfo, exists bool) bool {
+	if field.Calculated {
+		return false
+	}
+	if exists {
+		return field.Updateable
+	}
+	return field.Createable
+}
+
+// recordField renders the input for one field.
+func recordField(props RecordFormProps, name string, values map[string]interface{}, errMsg string) masc.ComponentOrHTML {
+	field, ok := props.ObjectInfo.Fields[name]
+	if !ok {
+		return nil
+	}
+	_, exists := values["Id"]
+	value := values[name]
+	if !fieldEditable(field, exists) {
+		if field.HTMLFormatted {
+			return readOnlyField(field.Label, FormattedRichText(recordDisplayValue(field, value, props.Picklists[name], props.Location)))
+		}
+		return readOnlyField(field.Label, masc.Text(recordDisplayValue(field, value, props.Picklists[name], props.Location)))
+	}
+
+	validation := ValidationState{
+		Required:     field.Required && field.DataType != "Boolean",
+		HasError:     errMsg != "",
+		ErrorMessage: errMsg,
+	}
+	if field.InlineHelpText != nil {
+		validation.Tooltip = *field.InlineHelpText
+	}
+	change := func(v interface{}) {
+		if props.OnChange == nil {
+			return
+		}
+		props.OnChange(name, v)
+		// Clear dependent picklist values no longer valid for the new value.
+		updated := recordValues(values, map[string]interface{}{name: v})
+		reconciled := api.ReconcileDependentPicklists(props.ObjectInfo, props.Picklists, updated)
+		for _, dep := range sortedKeys(reconciled) {
+			props.OnChange(dep, reconciled[dep])
+		}
+	}
+	text, _ := value.(string)
+
+	switch field.DataType {
+	case "Boolean":
+		checked, _ := value.(bool)
+		return Checkbox(field.Label, checked, func(e *masc.Event) {
+			change(e.Target.Get("checked").Bool())
+		})
+	case "Picklist":
+		picklist := props.Picklists[name]
+		options := picklistOptions(picklist.Values)
+		if field.ControllerName != nil {
+			options = picklistOptions(picklist.ValidValues(api.ControllingValue(values[*field.ControllerName])))
+		}
+		options = append([]SelectOption{{Label: i18n.T("Thunder_None"), Value: ""}}, options...)
+		return ValidatedSelect(field.Label, options, text, validation, func(e *masc.Event) {
+			change(e.Target.Get("value").String())
+		})
+	case "MultiPicklist":
+		picklist := props.Picklists[name]
+		options := picklistOptions(picklist.Values)
+		if field.ControllerName != nil {
+			options = picklistOptions(picklist.ValidValues(api.ControllingValue(values[*field.ControllerName])))
+		}
+		return ValidatedMultiSelect(MultiSelectProps{
+			Label:    field.Label,
+			Options:  options,
+			Selected: api.SplitMultiSelect(text),
+			IsOpen:   props.OpenPicklist == name,
+			OnOpenChange: func(open bool) {
+				if props.OnOpenPicklist == nil {
+					return
+				}
+				if open {
+					props.OnOpenPicklist(name)
+				} else {
+					props.OnOpenPicklist("")
+				}
+			},
+			OnChange: func(selected []string) { change(api.JoinMultiSelect(selected)) },
+		}, validation)
+	case "Reference":
+		return recordLookupField(props, name, field, values, text, validation, change)
+	case "TextArea":
+		if field.HTMLFormatted {
+			return ValidatedRichTextEditor(RichTextEditorProps{
+				Label:    field.Label,
+				Value:    text,
+				OnChange: func(html string) { change(html) },
+			}, validation)
+		}
+		rows := 3
+		if field.Length != nil && *field.Length > 255 {
+			rows = 6
+		}
+		return ValidatedTextarea(field.Label, text, rows, validation, func(e *masc.Event) {
+			change(e.Target.Get("value").String())
+		})
+	case "Currency", "Double", "Percent", "Int", "Long":
+		number := NumberInputProps{
+			Locale:   props.Locale,
+			Currency: props.Currency,
+			OnChange: func(v *float64) {
+				if v == nil {
+					change(nil)
+					return
+				}
+				change(*v)
+			},
+		}.FromField(field)
+		if n, ok := toFloat(value); ok && !isEmptyValue(value) {
+			number.Value = &n
+		}
+		switch field.DataType {
+		case "Currency":
+			return ValidatedCurrencyInput(number, validation)
+		case "Percent":
+			return ValidatedPercentInput(number, validation)
+		}
+		return ValidatedNumberInput(number, validation)
+	case "Email":
+		return ValidatedEmailInput(field.Label, text, validation, func(v string, _ bool) { change(v) })
+	case "Phone":
+		return ValidatedPhoneInput(field.Label, text, validation, func(v string, _ bool) { change(v) })
+	case "Url":
+		return ValidatedURLInput(field.Label, text, validation, func(v string, _ bool) { change(v) })
+	case "Date":
+		var date time.Time
+		if text != "" {
+			date, _ = time.Parse("2006-01-02", text)
+		}
+		return ValidatedDatepicker(field.Label, date, validation, func(t time.Time) {
+			if t.IsZero() {
+				change(nil)
+				return
+			}
+			change(t.Format("2006-01-02"))
+		})
+	case "Time":
+		return ValidatedTimepicker(field.Label, formatSalesforceTime(text), validation, func(e *masc.Event) {
+			if s := e.Target.Get("value").String(); s != "" {
+				change(s + ":00.000Z")
+			} else {
+				change(nil)
+			}
+		})
+	case "DateTime":
+		var instant time.Time
+		if text != "" {
+			instant, _ = api.ParseDateTime(text)
+		}
+		return ValidatedDateTimePicker(DateTimePickerProps{
+			Label:    field.Label,
+			Value:    instant,
+			Location: props.Location,
+			OnChange: func(t time.Time) {
+				if t.IsZero() {
+					change(nil)
+					return
+				}
+				change(api.FormatDateTime(t))
+			},
+		}, validation)
+	default:
+		return ValidatedTextInput(field.Label, text, validation, func(e *masc.Event) {
+			change(e.Target.Get("value").String())
+		})
+	}
+}
+
+// recordLookupField renders a RecordLookup for a reference field, keeping its
+// search state in props.Lookups.
+func recordLookupField(props RecordFormProps, name string, field api.FieldInfo, values map[string]interface{}, id string, validation ValidationState, change func(interface{})) masc.ComponentOrHTML {
+	lookup := props.Lookups[name]
+	update := func(next RecordFormLookup) {
+		if props.OnLookupChange != nil {
+			props.OnLookupChange(name, next)
+		}
+	}
+	selectedLabel := lookup.SelectedLabel
+	if selectedLabel == "" && id != "" {
+		selectedLabel = id
+		if field.RelationshipName != nil {
+			if related, ok := values[*field.RelationshipName].(map[string]interface{}); ok {
+				if s, ok := related["Name"].(string); ok && s != "" {
+					selectedLabel = s
+				}
+			}
+		}
+	}
+	return RecordLookup(RecordLookupProps{
+		Label:         field.Label,
+		Targets:       LookupTargetsFromField(field),
+		Target:        lookup.Target,
+		SearchText:    lookup.SearchText,
+		Results:       lookup.Results,
+		IsSearching:   lookup.IsSearching,
+		SelectedID:    id,
+		SelectedLabel: selectedLabel,
+		Validation:    validation,
+		OnInput: func(text string) {
+			next := lookup
+			next.SearchText = text
+			update(next)
+		},
+		OnSearch: func(target LookupTarget, text string) {
+			if props.OnLookupSearch != nil {
+				props.OnLookupSearch(name, target, text)
+			}
+		},
+		OnSelect: func(result LookupResult) {
+			change(result.Id)
+			update(RecordFormLookup{Target: lookup.Target, SelectedLabel: result.Title})
+		},
+		OnTargetChange: func(objectName string) {
+			update(RecordFormLookup{Target: objectName})
+		},
+		OnClear: func() {
+			change(nil)
+			update(RecordFormLookup{Target: lookup.Target})
+		},
+	})
+}
+
+// readOnlyField renders a field the user cannot edit as static content.
+func readOnlyField(label string, value masc.ComponentOrHTML) masc.ComponentOrHTML {
+	return elem.Div(
+		masc.Markup(masc.Class("slds-form-element", "slds-form-element_readonly", "slds-m-bottom_small")),
+		elem.Span(
+			masc.Markup(masc.Class("slds-form-element__label")),
+			masc.Text(label),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-form-element__control")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-form-element__static")),
+				value,
+			),
+		),
+	)
+}
+
+// formatSalesforceTime converts a Time value such as "13:30:00.000Z" to the
+// HH:MM format used by time inputs.
+func formatSalesforceTime(s string) string {
+	if len(s) >= 5 {
+		return s[:5]
+	}
+	return s
+}
+
+// recordDisplayValue renders a field value as text, showing dates in the
+// user's locale and DateTime values in loc.
+func recordDisplayValue(field api.FieldInfo, v interface{}, picklist api.PicklistFieldValue, loc *time.Location) string {
+	if isEmptyValue(v) {
+		return ""
+	}
+	switch field.DataType {
+	case "Boolean":
+		if b, _ := v.(bool); b {
+			return i18n.T("Thunder_Yes")
+		}
+		return i18n.T("Thunder_No")
+	case "Picklist":
+		s := fmt.Sprint(v)
+		for _, pv := range picklist.Values {
+			if pv.Value == s {
+				return pv.Label
+			}
+		}
+		return s
+	case "DateTime":
+		if t, err := api.ParseDateTime(fmt.Sprint(v)); err == nil {
+			return i18n.FormatDateTime(t.In(dateTimeLocation(loc)))
+		}
+	case "Date":
+		if t, err := api.ParseDate(fmt.Sprint(v)); err == nil {
+			return i18n.FormatDate(t)
+		}
+	case "Time":
+		return formatSalesforceTime(fmt.Sprint(v))
+	}
+	if n, ok := v.(float64); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// picklistOptions converts picklist values to select options.
+func picklistOptions(values []api.PicklistValue) []SelectOption {
+	options := make([]SelectOption, 0, len(values))
+	for _, pv := range values {
+		options = append(options, SelectOption{Label: pv.Label, Value: pv.Value})
+	}
+	return options
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
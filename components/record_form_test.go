@@ -0,0 +1,290 @@
+package components
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+func intPtr(i int) *int       { return &i }
+func strPtr(s string) *string { return &s }
+
+var opportunityInfo = api.ObjectInfo{
+	APIName: "Opportunity",
+	Fields: map[string]api.FieldInfo{
+		"Name":        {APIName: "Name", Label: "Name", DataType: "String", Length: intPtr(10), Required: true, Createable: true, Updateable: true},
+		"Amount":      {APIName: "Amount", Label: "Amount", DataType: "Currency", Precision: intPtr(5), Scale: intPtr(2), Createable: true, Updateable: true},
+		"Quantity":    {APIName: "Quantity", Label: "Quantity", DataType: "Int", Precision: intPtr(3), Createable: true, Updateable: true},
+		"Region":      {APIName: "Region", Label: "Region", DataType: "Picklist", Createable: true, Updateable: true},
+		"Country":     {APIName: "Country", Label: "Country", DataType: "Picklist", ControllerName: strPtr("Region"), Createable: true, Updateable: true},
+		"IsPrivate":   {APIName: "IsPrivate", Label: "Private", DataType: "Boolean", Required: true, Createable: true, Updateable: true},
+		"Probability": {APIName: "Probability", Label: "Probability", DataType: "Percent", Calculated: true},
+	},
+}
+
+var opportunityPicklists = map[string]api.PicklistFieldValue{
+	"Region": {Values: []api.PicklistValue{{Label: "Europe", Value: "EU"}, {Label: "Americas", Value: "AM"}}},
+	"Country": {
+		ControllerValues: map[string]int{"EU": 0, "AM": 1},
+		Values: []api.PicklistValue{
+			{Label: "France", Value: "FR", ValidFor: []int{0}},
+			{Label: "Canada", Value: "CA", ValidFor: []int{1}},
+			{Label: "Germany", Value: "DE", ValidFor: []int{0}},
+		},
+	},
+}
+
+// TestValidateRecord verifies required, length, precision and scale rules are
+// taken from the field metadata.
+func TestValidateRecord(t *testing.T) {
+	fields := []string{"Name", "Amount", "Quantity", "IsPrivate", "Probability"}
+
+	errs := ValidateRecord(opportunityInfo, fields, map[string]interface{}{"Probability": 500.0})
+	if want := map[string]string{"Name": "Name is required"}; !reflect.DeepEqual(errs, want) {
+		t.Errorf("expected %v, got %v", want, errs)
+	}
+
+	errs = ValidateRecord(opportunityInfo, fields, map[string]interface{}{
+		"Name":     "Far too long a name",
+		"Amount":   1234.5,
+		"Quantity": 1.5,
+	})
+	want := map[string]string{
+		"Name":     "Name must be 10 characters or fewer",
+		"Amount":   "Amount can have at most 3 digits before the decimal point",
+		"Quantity": "Quantity must be a whole number",
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("expected %v, got %v", want, errs)
+	}
+
+	errs = ValidateRecord(opportunityInfo, fields, map[string]interface{}{"Name": "Deal", "Amount": 12.345})
+	if got := errs["Amount"]; got != "Amount can have at most 2 decimal places" {
+		t.Errorf("expected a scale error, got %q", got)
+	}
+}
+
+// TestRecordChanges verifies only changed fields are returned and cleared
+// values are sent as nil.
+func TestRecordChanges(t *testing.T) {
+	record := map[string]interface{}{"Id": "006A", "Name": "Deal", "Amount": 10.0, "Region": "EU"}
+	values := map[string]interface{}{"Name": "Deal", "Amount": 20.0, "Region": ""}
+	want := map[string]interface{}{"Amount": 20.0, "Region": nil}
+	if got := RecordChanges(record, values); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestRecordFormRendersFieldsByType verifies each field gets the input for its
+// data type, dependent picklists are filtered and calculated fields are
+// read-only.
+func TestRecordFormRendersFieldsByType(t *testing.T) {
+	win := renderComponent(t, RecordForm(RecordFormProps{
+		ObjectInfo: opportunityInfo,
+		Picklists:  opportunityPicklists,
+		Fields:     []string{"Name", "Amount", "Region", "Country", "IsPrivate", "Probability"},
+		Record:     map[string]interface{}{"Id": "006A", "Name": "Deal", "Region": "AM", "Probability": 50.0},
+		OnChange:   func(string, interface{}) {},
+	}))
+	doc := win.Document()
+
+	selects, err := doc.QuerySelectorAll("select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := selects.Length(); got != 2 {
+		t.Errorf("expected 2 picklists, got %d", got)
+	}
+	countries, err := doc.QuerySelectorAll("select option")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// --None-- plus two regions, then --None-- plus the one Americas country.
+	if got := countries.Length(); got != 5 {
+		t.Errorf("expected 5 options, got %d", got)
+	}
+	checkbox, err := doc.QuerySelector("input[type=checkbox]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkbox == nil {
+		t.Error("expected a checkbox for the Boolean field, got none")
+	}
+	static, err := doc.QuerySelector(".slds-form-element_readonly .slds-form-element__static")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if static == nil || static.TextContent() != "50" {
+		t.Error("expected the calculated field to be shown read-only")
+	}
+	required, err := doc.QuerySelectorAll(".slds-required")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := required.Length(); got != 1 {
+		t.Errorf("expected 1 required marker, got %d", got)
+	}
+	// Fields are marked for ErrorPopover links, whatever their input.
+	if el, _ := doc.QuerySelector(`[data-field="IsPrivate"] input[type=checkbox]`); el == nil {
+		t.Error("expected the checkbox field to be marked with its API name")
+	}
+}
+
+// TestRecordFormSubmit verifies Save reports validation errors, or the changed
+// fields when the form is valid.
+func TestRecordFormSubmit(t *testing.T) {
+	var invalid map[string]string
+	var submitted map[string]interface{}
+	props := RecordFormProps{
+		ObjectInfo: opportunityInfo,
+		Fields:     []string{"Name", "Amount"},
+		Record:     map[string]interface{}{"Id": "006A", "Name": "Deal"},
+		Values:     map[string]interface{}{"Name": ""},
+		OnChange:   func(string, interface{}) {},
+		OnSubmit:   func(changes map[string]interface{}) { submitted = changes },
+		OnInvalid:  func(errs map[string]string) { invalid = errs },
+	}
+
+	win := renderComponent(t, RecordForm(props))
+	save, err := win.Document().QuerySelector("button.slds-button_brand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	save.(html.HTMLElement).Click()
+	if invalid["Name"] == "" || submitted != nil {
+		t.Fatalf("expected a validation error for Name, got %v (submitted %v)", invalid, submitted)
+	}
+
+	props.Values = map[string]interface{}{"Name": "Big Deal", "Amount": 100.0}
+	win = renderComponent(t, RecordForm(props))
+	save, err = win.Document().QuerySelector("button.slds-button_brand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	save.(html.HTMLElement).Click()
+	if want := (map[string]interface{}{"Name": "Big Deal", "Amount": 100.0}); !reflect.DeepEqual(submitted, want) {
+		t.Errorf("expected %v, got %v", want, submitted)
+	}
+}
+
+// TestRecordFormDateTimeUsesLocation verifies DateTime fields are edited and
+// displayed in the form's location.
+func TestRecordFormDateTimeUsesLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := api.ObjectInfo{Fields: map[string]api.FieldInfo{
+		"CloseDate__c": {Label: "Close", DataType: "DateTime", Createable: true, Updateable: true},
+		"CreatedDate":  {Label: "Created", DataType: "DateTime"},
+	}}
+	win := renderComponent(t, RecordForm(RecordFormProps{
+		ObjectInfo: info,
+		Fields:     []string{"CloseDate__c", "CreatedDate"},
+		Record: map[string]interface{}{
+			"Id":           "006A",
+			"CloseDate__c": "2025-01-15T18:00:00.000+0000",
+			"CreatedDate":  "2025-01-01T00:00:00.000+0000",
+		},
+		Location: loc,
+	}))
+
+	if date, _ := querySelector(t, win, `input[type="date"]`).GetAttribute("value"); date != "2025-01-16" {
+		t.Errorf("expected the date in Tokyo, got %q", date)
+	}
+	if static := querySelector(t, win, ".slds-form-element__static"); static == nil || static.TextContent() != "1/1/2025 9:00 AM" {
+		t.Error("expected the read-only DateTime in Tokyo")
+	}
+}
+
+// TestRecordFormReferenceField verifies reference fields are edited with a
+// RecordLookup that shows the related record's name, keeps its search state
+// in Lookups and stores the chosen record's Id.
+func TestRecordFormReferenceField(t *testing.T) {
+	info := api.ObjectInfo{Fields: map[string]api.FieldInfo{
+		"AccountId": {
+			Label: "Account", DataType: "Reference", RelationshipName: strPtr("Account"),
+			ReferenceToInfos: []json.RawMessage{[]byte(`{"apiName":"Account","nameFields":["Name"]}`)},
+			Createable:       true, Updateable: true,
+		},
+	}}
+	var changes []interface{}
+	var lookups []RecordFormLookup
+	props := RecordFormProps{
+		ObjectInfo:     info,
+		Fields:         []string{"AccountId"},
+		Record:         map[string]interface{}{"Id": "003A", "AccountId": "001A", "Account": map[string]interface{}{"Name": "Acme"}},
+		OnChange:       func(_ string, v interface{}) { changes = append(changes, v) },
+		OnLookupChange: func(_ string, l RecordFormLookup) { lookups = append(lookups, l) },
+	}
+	win := renderComponent(t, RecordForm(props))
+	input := querySelector(t, win, ".slds-has-selection input.slds-combobox__input-value")
+	if input == nil {
+		t.Fatal("expected the selected account")
+	}
+	if el := querySelector(t, win, ".slds-icon-standard-account"); el == nil {
+		t.Error("expected the Account icon")
+	}
+	querySelector(t, win, "button[title='Remove selected option']").(html.HTMLElement).Click()
+	if len(changes) != 1 || changes[0] != nil {
+		t.Errorf("expected the field cleared, got %v", changes)
+	}
+
+	props.Record = map[string]interface{}{"Id": "003A"}
+	props.Lookups = map[string]RecordFormLookup{"AccountId": {
+		SearchText: "Ac",
+		Results:    []LookupResult{{Id: "001B", Title: "Acme West", ObjectName: "Account"}},
+	}}
+	changes, lookups = nil, nil
+	win = renderComponent(t, RecordForm(props))
+	querySelector(t, win, "[role=option]").(html.HTMLElement).Click()
+	if len(changes) != 1 || changes[0] != "001B" {
+		t.Errorf("expected the chosen Id stored, got %v", changes)
+	}
+	if len(lookups) != 1 || lookups[0].SelectedLabel != "Acme West" || lookups[0].SearchText != "" {
+		t.Errorf("expected the search reset with the chosen name, got %+v", lookups)
+	}
+}
+
+// TestRecordFormMultiPicklistField verifies multi-select picklists are edited
+// with a MultiSelect and stored as semicolon-delimited values.
+func TestRecordFormMultiPicklistField(t *testing.T) {
+	info := api.ObjectInfo{Fields: map[string]api.FieldInfo{
+		"Colors__c": {Label: "Colors", DataType: "MultiPicklist", Createable: true, Updateable: true},
+	}}
+	var changed interface{}
+	var opened []string
+	props := RecordFormProps{
+		ObjectInfo: info,
+		Picklists: map[string]api.PicklistFieldValue{"Colors__c": {Values: []api.PicklistValue{
+			{Label: "Red", Value: "Red"}, {Label: "Green", Value: "Green"}, {Label: "Blue", Value: "Blue"},
+		}}},
+		Fields:         []string{"Colors__c"},
+		Record:         map[string]interface{}{"Id": "a01A", "Colors__c": "Red;Blue"},
+		OnChange:       func(_ string, v interface{}) { changed = v },
+		OnOpenPicklist: func(field string) { opened = append(opened, field) },
+	}
+	win := renderComponent(t, RecordForm(props))
+	pills, err := win.Document().QuerySelectorAll(".slds-pill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pills.Length(); got != 2 {
+		t.Fatalf("expected 2 pills, got %d", got)
+	}
+	querySelector(t, win, "input.slds-combobox__input").(html.HTMLElement).Click()
+	if !reflect.DeepEqual(opened, []string{"Colors__c"}) {
+		t.Errorf("expected the options opened, got %v", opened)
+	}
+
+	props.OpenPicklist = "Colors__c"
+	win = renderComponent(t, RecordForm(props))
+	querySelector(t, win, "[role=option][data-value=Green]").(html.HTMLElement).Click()
+	if changed != "Red;Green;Blue" {
+		t.Errorf("expected Red;Green;Blue, got %v", changed)
+	}
+}
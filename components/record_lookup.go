@@ -0,0 +1,492 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// LookupTarget is an object a RecordLookup can search.
+type LookupTarget struct {
+	ObjectName string
+	// Label is shown in the object switcher of a polymorphic lookup.
+	// Defaults to ObjectName.
+	Label string
+	// IconName is the SLDS standard icon shown beside results, e.g.
+	// "account". Defaults to the lowercased object name, or "custom" for
+	// custom objects.
+	IconName string
+	// NameField is the field searched and shown as each result's title.
+	// Defaults to "Name".
+	NameField string
+	// SecondaryFields are shown beneath each result's title.
+	SecondaryFields []string
+}
+
+// LookupResult is a record matching a RecordLookup search.
+type LookupResult struct {
+	Id         string
+	Title      string
+	Subtitle   string
+	ObjectName string
+}
+
+// RecordLookupProps configures a RecordLookup.
+type RecordLookupProps struct {
+	Label string
+	// Targets are the objects the lookup can reference. More than one
+	// renders an object switcher, as for polymorphic fields like WhoId.
+	Targets []LookupTarget
+	// Target is the ObjectName of the selected target; defaults to the first.
+	Target string
+	// SearchText is the text typed into the search input.
+	SearchText string
+	// Results are the records found for SearchText.
+	Results []LookupResult
+	// IsSearching shows a spinner while a search is in flight.
+	IsSearching bool
+	// SelectedID is the Id of the selected record, if any.
+	SelectedID string
+	// SelectedLabel is the name shown for the selected record.
+	SelectedLabel string
+	// Validation holds the required flag and error state.
+	Validation ValidationState
+	// Debounce is how long to wait after typing stops before calling
+	// OnSearch. Defaults to 300ms.
+	Debounce time.Duration
+	// MinSearchLength is the number of characters needed before OnSearch is
+	// called. Defaults to 2.
+	MinSearchLength int
+
+	// OnInput receives the search text on every keystroke.
+	OnInput func(text string)
+	// OnSearch is called once typing pauses, with the target to search.
+	// It runs on a timer goroutine, so it should send a message rather than
+	// update the model directly. See LookupTarget.SearchTarget and
+	// api.SearchRecords.
+	OnSearch func(target LookupTarget, text string)
+	// OnSelect receives the chosen record; store its Id as the field value.
+	OnSelect func(result LookupResult)
+	// OnTargetChange receives the ObjectName chosen in the object switcher.
+	OnTargetChange func(objectName string)
+	// OnClear is called when the selected record is removed.
+	OnClear func()
+}
+
+const (
+	defaultLookupDebounce        = 300 * time.Millisecond
+	defaultLookupMinSearchLength = 2
+)
+
+// RecordLookup renders an SLDS lookup that searches Salesforce records, like
+// a standard Lightning lookup field. Typing calls OnInput immediately and
+// OnSearch after a pause; the app runs the search, for example with
+// api.SearchRecords, and passes the matches back as Results. Once a record is
+// selected it is shown with its object icon until cleared.
+func RecordLookup(props RecordLookupProps) masc.ComponentOrHTML {
+	return &recordLookupComponent{Props: props}
+}
+
+type recordLookupComponent struct {
+	masc.Core
+
+	Props RecordLookupProps `masc:"prop"`
+	timer *time.Timer
+}
+
+func (c *recordLookupComponent) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	p := c.Props
+	target := p.currentTarget()
+
+	var controls []masc.MarkupOrChild
+	controls = append(controls, masc.Markup(masc.Class("slds-form-element__control")))
+	combobox := c.combobox(target)
+	if len(p.Targets) > 1 && p.SelectedID == "" {
+		controls = append(controls, elem.Div(
+			masc.Markup(masc.Class("slds-combobox-group")),
+			c.objectSwitcher(target),
+			elem.Div(
+				masc.Markup(masc.Class("slds-combobox_object-switcher-container", "slds-col")),
+				combobox,
+			),
+		))
+	} else {
+		controls = append(controls, combobox)
+	}
+
+	classes := []string{"slds-form-element", "slds-m-bottom_small"}
+	if p.Validation.HasError {
+		classes = append(classes, "slds-has-error")
+	}
+
+	// Build label with required indicator
+	labelContent := []masc.MarkupOrChild{
+		masc.Markup(masc.Class("slds-form-element__label")),
+		masc.Text(p.Label),
+	}
+	if p.Validation.Required {
+		labelContent = append(labelContent,
+			elem.Span(
+				masc.Markup(masc.Class("slds-required")),
+				masc.Text(" *"),
+			),
+		)
+	}
+
+	args := []masc.MarkupOrChild{
+		masc.Markup(masc.Class(classes...)),
+		elem.Label(labelContent...),
+		elem.Div(controls...),
+	}
+	if p.Validation.HasError && p.Validation.ErrorMessage != "" {
+		args = append(args, elem.Div(
+			masc.Markup(masc.Class("slds-form-element__help")),
+			masc.Text(p.Validation.ErrorMessage),
+		))
+	} else if p.Validation.HelpText != "" {
+		args = append(args, elem.Div(
+			masc.Markup(masc.Class("slds-form-element__help")),
+			masc.Text(p.Validation.HelpText),
+		))
+	}
+	return elem.Div(args...)
+}
+
+func (c *recordLookupComponent) Unmount() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+// search restarts the debounce timer for text, replacing any pending search.
+func (c *recordLookupComponent) search(target LookupTarget, text string) {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	p := c.Props
+	if p.OnSearch == nil || len([]rune(strings.TrimSpace(text))) < p.minSearchLength() {
+		return
+	}
+	debounce := p.Debounce
+	if debounce <= 0 {
+		debounce = defaultLookupDebounce
+	}
+	onSearch := p.OnSearch
+	c.timer = time.AfterFunc(debounce, func() {
+		onSearch(target, text)
+	})
+}
+
+// combobox renders the search input and results, or the selected record.
+func (c *recordLookupComponent) combobox(target LookupTarget) masc.ComponentOrHTML {
+	p := c.Props
+	if p.SelectedID != "" {
+		return elem.Div(
+			masc.Markup(masc.Class("slds-combobox_container", "slds-has-selection")),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-combobox", "slds-dropdown-trigger", "slds-dropdown-trigger_click"),
+					masc.Attribute("role", "combobox"),
+					masc.Attribute("aria-expanded", "false"),
+					masc.Attribute("aria-haspopup", "listbox"),
+				),
+				elem.Div(
+					masc.Markup(
+						masc.Class("slds-combobox__form-element", "slds-input-has-icon", "slds-input-has-icon_left-right"),
+						masc.Attribute("role", "none"),
+					),
+					iconWithClasses(StandardIcon, target.iconName(), IconSmall, "slds-combobox__input-entity-icon"),
+					elem.Input(masc.Markup(
+						masc.Class("slds-input", "slds-combobox__input", "slds-combobox__input-value"),
+						masc.Property("type", "text"),
+						masc.Property("value", p.SelectedLabel),
+						masc.Property("readOnly", true),
+					)),
+					elem.Button(
+						masc.Markup(
+							masc.Class("slds-button", "slds-button_icon", "slds-input__icon", "slds-input__icon_right"),
+							masc.Attribute("type", "button"),
+							masc.Property("title", i18n.T("Thunder_Remove_Selected_Option")),
+							event.Click(func(e *masc.Event) {
+								if p.OnClear != nil {
+									p.OnClear()
+								}
+							}),
+						),
+						masc.Text("×"),
+						elem.Span(
+							masc.Markup(masc.Class("slds-assistive-text")),
+							masc.Text(i18n.T("Thunder_Remove_Selected_Option")),
+						),
+					),
+				),
+			),
+		)
+	}
+
+	text := strings.TrimSpace(p.SearchText)
+	open := len([]rune(text)) >= p.minSearchLength()
+	comboClasses := []string{"slds-combobox", "slds-dropdown-trigger", "slds-dropdown-trigger_click"}
+	if open {
+		comboClasses = append(comboClasses, "slds-is-open")
+	}
+	placeholder := p.Validation.Placeholder
+	if placeholder == "" {
+		placeholder = i18n.T("Thunder_Search_For", target.label())
+	}
+
+	var options []masc.MarkupOrChild
+	options = append(options, masc.Markup(
+		masc.Class("slds-listbox", "slds-listbox_vertical"),
+		masc.Attribute("role", "presentation"),
+	))
+	for _, result := range p.Results {
+		options = append(options, c.resultOption(target, result))
+	}
+	if p.IsSearching {
+		options = append(options, elem.ListItem(
+			masc.Markup(masc.Class("slds-listbox__item"), masc.Attribute("role", "presentation")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-align_absolute-center", "slds-p-top_medium")),
+				Spinner("x-small"),
+			),
+		))
+	} else if len(p.Results) == 0 {
+		options = append(options, elem.ListItem(
+			masc.Markup(masc.Class("slds-listbox__item"), masc.Attribute("role", "presentation")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-media", "slds-listbox__option", "slds-listbox__option_plain")),
+				elem.Span(
+					masc.Markup(masc.Class("slds-media__body")),
+					masc.Text(i18n.T("Thunder_No_Results_For", text)),
+				),
+			),
+		))
+	}
+
+	return elem.Div(
+		masc.Markup(masc.Class("slds-combobox_container")),
+		elem.Div(
+			masc.Markup(
+				masc.Class(comboClasses...),
+				masc.Attribute("role", "combobox"),
+				masc.Attribute("aria-expanded", strconv.FormatBool(open)),
+				masc.Attribute("aria-haspopup", "listbox"),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-combobox__form-element", "slds-input-has-icon", "slds-input-has-icon_right"),
+					masc.Attribute("role", "none"),
+				),
+				elem.Input(masc.Markup(
+					masc.Class("slds-input", "slds-combobox__input"),
+					masc.Property("type", "text"),
+					masc.Property("value", p.SearchText),
+					masc.Property("placeholder", placeholder),
+					masc.Attribute("autocomplete", "off"),
+					masc.Attribute("aria-autocomplete", "list"),
+					event.Input(func(e *masc.Event) {
+						value := e.Target.Get("value").String()
+						if p.OnInput != nil {
+							p.OnInput(value)
+						}
+						c.search(target, value)
+					}),
+				)),
+				iconWithClasses(UtilityIcon, "search", IconSmall, "slds-input__icon", "slds-input__icon_right"),
+			),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-dropdown", "slds-dropdown_length-with-icon-7", "slds-dropdown_fluid"),
+					masc.Attribute("role", "listbox"),
+				),
+				elem.UnorderedList(options...),
+			),
+		),
+	)
+}
+
+// resultOption renders one search result with its object icon and
+// secondary fields.
+func (c *recordLookupComponent) resultOption(target LookupTarget, result LookupResult) masc.ComponentOrHTML {
+	onSelect := c.Props.OnSelect
+	icon := target
+	if result.ObjectName != "" && result.ObjectName != target.ObjectName {
+		icon = LookupTarget{ObjectName: result.ObjectName}
+		for _, t := range c.Props.Targets {
+			if t.ObjectName == result.ObjectName {
+				icon = t
+			}
+		}
+	}
+	body := []masc.MarkupOrChild{
+		masc.Markup(masc.Class("slds-media__body")),
+		elem.Span(
+			masc.Markup(masc.Class("slds-listbox__option-text", "slds-listbox__option-text_entity")),
+			masc.Text(result.Title),
+		),
+	}
+	if result.Subtitle != "" {
+		body = append(body, elem.Span(
+			masc.Markup(masc.Class("slds-listbox__option-meta", "slds-listbox__option-meta_entity")),
+			masc.Text(result.Subtitle),
+		))
+	}
+	return elem.ListItem(
+		masc.Markup(masc.Class("slds-listbox__item"), masc.Attribute("role", "presentation")),
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-media", "slds-listbox__option", "slds-listbox__option_entity", "slds-listbox__option_has-meta"),
+				masc.Attribute("role", "option"),
+				masc.Data("record-id", result.Id),
+				event.Click(func(e *masc.Event) {
+					if onSelect != nil {
+						onSelect(result)
+					}
+				}),
+			),
+			elem.Span(
+				masc.Markup(masc.Class("slds-media__figure", "slds-listbox__option-icon")),
+				Icon(StandardIcon, icon.iconName(), IconSmall),
+			),
+			elem.Span(body...),
+		),
+	)
+}
+
+// objectSwitcher renders the select used to pick the object searched by a
+// polymorphic lookup.
+func (c *recordLookupComponent) objectSwitcher(target LookupTarget) masc.ComponentOrHTML {
+	onChange := c.Props.OnTargetChange
+	var options []masc.MarkupOrChild
+	options = append(options, masc.Markup(
+		masc.Class("slds-select"),
+		masc.Attribute("aria-label", i18n.T("Thunder_Object")),
+		event.Change(func(e *masc.Event) {
+			if onChange != nil {
+				onChange(e.Target.Get("value").String())
+			}
+		}),
+	))
+	for _, t := range c.Props.Targets {
+		options = append(options, elem.Option(
+			masc.Markup(
+				masc.Property("value", t.ObjectName),
+				masc.Property("selected", t.ObjectName == target.ObjectName),
+			),
+			masc.Text(t.label()),
+		))
+	}
+	return elem.Div(
+		masc.Markup(masc.Class("slds-combobox_object-switcher", "slds-combobox-addon_start")),
+		elem.Div(
+			masc.Markup(masc.Class("slds-select_container")),
+			elem.Select(options...),
+		),
+	)
+}
+
+// currentTarget returns the target being searched.
+func (p RecordLookupProps) currentTarget() LookupTarget {
+	for _, t := range p.Targets {
+		if t.ObjectName == p.Target {
+			return t
+		}
+	}
+	if len(p.Targets) > 0 {
+		return p.Targets[0]
+	}
+	return LookupTarget{ObjectName: p.Target}
+}
+
+func (p RecordLookupProps) minSearchLength() int {
+	if p.MinSearchLength <= 0 {
+		return defaultLookupMinSearchLength
+	}
+	return p.MinSearchLength
+}
+
+func (t LookupTarget) label() string {
+	if t.Label != "" {
+		return t.Label
+	}
+	return t.ObjectName
+}
+
+func (t LookupTarget) nameField() string {
+	if t.NameField != "" {
+		return t.NameField
+	}
+	return "Name"
+}
+
+func (t LookupTarget) iconName() string {
+	switch {
+	case t.IconName != "":
+		return t.IconName
+	case strings.HasSuffix(t.ObjectName, "__c"):
+		return "custom"
+	case ValidIconName(StandardIcon, strings.ToLower(t.ObjectName)):
+		return strings.ToLower(t.ObjectName)
+	}
+	return "record"
+}
+
+// SearchTarget returns the api.SearchTarget for searching t with
+// api.SearchRecords.
+func (t LookupTarget) SearchTarget() api.SearchTarget {
+	return api.SearchTarget{
+		ObjectName: t.ObjectName,
+		NameField:  t.nameField(),
+		Fields:     t.SecondaryFields,
+	}
+}
+
+// LookupTargetsFromField returns the lookup targets for a reference field,
+// one per object in its ReferenceToInfos.
+func LookupTargetsFromField(field api.FieldInfo) []LookupTarget {
+	var targets []LookupTarget
+	for _, ref := range field.ReferenceTargets() {
+		target := LookupTarget{ObjectName: ref.APIName}
+		if len(ref.NameFields) > 0 {
+			target.NameField = ref.NameFields[0]
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// LookupResultsFromRecords converts records found for target into lookup
+// results, joining its SecondaryFields into each subtitle.
+func LookupResultsFromRecords(target LookupTarget, records []api.Record) []LookupResult {
+	results := make([]LookupResult, 0, len(records))
+	for _, r := range records {
+		id, _ := r.StringValue("Id")
+		title, _ := r.StringValue(target.nameField())
+		var secondary []string
+		for _, f := range target.SecondaryFields {
+			if v, err := r.StringValue(f); err == nil && v != "" {
+				secondary = append(secondary, v)
+			}
+		}
+		objectName := target.ObjectName
+		if r.Attributes.Type != "" {
+			objectName = r.Attributes.Type
+		}
+		results = append(results, LookupResult{
+			Id:         id,
+			Title:      title,
+			Subtitle:   strings.Join(secondary, " • "),
+			ObjectName: objectName,
+		})
+	}
+	return results
+}
@@ -0,0 +1,168 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	forcequery "github.com/ForceCLI/force/lib/query"
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// querySelector returns the first element in win matching selector, or nil.
+func querySelector(t *testing.T, win html.Window, selector string) dom.Element {
+	t.Helper()
+	el, err := win.Document().QuerySelector(selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return el
+}
+
+var lookupTargets = []LookupTarget{
+	{ObjectName: "Contact", SecondaryFields: []string{"Email"}},
+	{ObjectName: "Lead", Label: "Leads"},
+}
+
+// TestRecordLookupRendersResults verifies search results are listed with
+// their object icons and secondary text, and selecting one returns it.
+func TestRecordLookupRendersResults(t *testing.T) {
+	var selected LookupResult
+	win := renderComponent(t, RecordLookup(RecordLookupProps{
+		Label:      "Name",
+		Targets:    lookupTargets,
+		SearchText: "Ann",
+		Results: []LookupResult{
+			{Id: "003A", Title: "Ann Smith", Subtitle: "ann@example.com", ObjectName: "Contact"},
+			{Id: "00QB", Title: "Ann Jones", ObjectName: "Lead"},
+		},
+		OnSelect: func(r LookupResult) { selected = r },
+	}))
+
+	if el := querySelector(t, win, ".slds-combobox.slds-is-open"); el == nil {
+		t.Fatal("expected the results dropdown to be open")
+	}
+	options, err := win.Document().QuerySelectorAll("[role=option]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := options.Length(); got != 2 {
+		t.Fatalf("expected 2 results, got %d", got)
+	}
+	if el := querySelector(t, win, ".slds-listbox__option-meta"); el == nil || el.TextContent() != "ann@example.com" {
+		t.Error("expected secondary field text")
+	}
+	if el := querySelector(t, win, ".slds-icon-standard-lead"); el == nil {
+		t.Error("expected Lead icon on the Lead result")
+	}
+	if el := querySelector(t, win, ".slds-combobox_object-switcher select"); el == nil {
+		t.Error("expected object switcher for a polymorphic lookup")
+	}
+
+	querySelector(t, win, "[data-record-id='00QB']").(html.HTMLElement).Click()
+	if selected.Id != "00QB" {
+		t.Errorf("expected 00QB to be selected, got %q", selected.Id)
+	}
+}
+
+// TestRecordLookupNoResults verifies an empty search shows a message.
+func TestRecordLookupNoResults(t *testing.T) {
+	win := renderComponent(t, RecordLookup(RecordLookupProps{
+		Label:      "Account",
+		Targets:    []LookupTarget{{ObjectName: "Account"}},
+		SearchText: "zz",
+	}))
+	el := querySelector(t, win, ".slds-listbox__option_plain")
+	if el == nil || el.TextContent() != `No results for "zz"` {
+		t.Error("expected no results message")
+	}
+	if el := querySelector(t, win, ".slds-combobox_object-switcher"); el != nil {
+		t.Error("expected no object switcher for a single target")
+	}
+}
+
+// TestRecordLookupSelection verifies a selected record replaces the search
+// input and can be cleared.
+func TestRecordLookupSelection(t *testing.T) {
+	cleared := false
+	win := renderComponent(t, RecordLookup(RecordLookupProps{
+		Label:         "Account",
+		Targets:       []LookupTarget{{ObjectName: "Invoice__c"}},
+		SelectedID:    "a01A",
+		SelectedLabel: "INV-001",
+		OnClear:       func() { cleared = true },
+	}))
+	if el := querySelector(t, win, ".slds-has-selection"); el == nil {
+		t.Fatal("expected selected state")
+	}
+	if el := querySelector(t, win, ".slds-icon_container.slds-icon-standard-custom.slds-combobox__input-entity-icon"); el == nil {
+		t.Error("expected custom object icon")
+	}
+	if el := querySelector(t, win, ".slds-icon_container .slds-icon_container"); el != nil {
+		t.Error("expected no nested icon containers")
+	}
+	querySelector(t, win, "button[title='Remove selected option']").(html.HTMLElement).Click()
+	if !cleared {
+		t.Error("expected OnClear to be called")
+	}
+}
+
+// TestRecordLookupDebouncesSearch verifies only the last input within the
+// debounce interval is searched, and short input is not searched.
+func TestRecordLookupDebouncesSearch(t *testing.T) {
+	searches := make(chan string, 3)
+	c := &recordLookupComponent{Props: RecordLookupProps{
+		Debounce: 10 * time.Millisecond,
+		OnSearch: func(target LookupTarget, text string) { searches <- text },
+	}}
+	target := LookupTarget{ObjectName: "Account"}
+	c.search(target, "Ac")
+	c.search(target, "Acm")
+	c.search(target, "A")
+	c.search(target, "Acme")
+
+	select {
+	case got := <-searches:
+		if got != "Acme" {
+			t.Errorf("expected Acme, got %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a search")
+	}
+	select {
+	case got := <-searches:
+		t.Errorf("expected a single search, also got %q", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestLookupTargetsFromField verifies polymorphic fields produce a target per
+// referenced object, using its name field.
+func TestLookupTargetsFromField(t *testing.T) {
+	field := api.FieldInfo{ReferenceToInfos: nil}
+	if got := LookupTargetsFromField(field); len(got) != 0 {
+		t.Errorf("expected no targets, got %v", got)
+	}
+	field.ReferenceToInfos = append(field.ReferenceToInfos,
+		[]byte(`{"apiName":"Case","nameFields":["CaseNumber"]}`),
+		[]byte(`{"apiName":"Account","nameFields":[]}`))
+	targets := LookupTargetsFromField(field)
+	if len(targets) != 2 || targets[0].NameField != "CaseNumber" || targets[1].ObjectName != "Account" {
+		t.Errorf("unexpected targets: %+v", targets)
+	}
+}
+
+// TestLookupResultsFromRecords verifies records become results with their
+// secondary fields joined into the subtitle.
+func TestLookupResultsFromRecords(t *testing.T) {
+	record := api.Record{Record: forcequery.Record{
+		Fields: map[string]interface{}{"Id": "003A", "Name": "Ann Smith", "Email": "ann@example.com"},
+	}}
+	record.Attributes.Type = "Contact"
+	results := LookupResultsFromRecords(LookupTarget{ObjectName: "Contact", SecondaryFields: []string{"Email", "Phone"}}, []api.Record{record})
+	want := LookupResult{Id: "003A", Title: "Ann Smith", Subtitle: "ann@example.com", ObjectName: "Contact"}
+	if len(results) != 1 || results[0] != want {
+		t.Errorf("expected %+v, got %+v", want, results)
+	}
+}
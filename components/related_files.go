@@ -0,0 +1,326 @@
+package components
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// RelatedFilesProps configures RelatedFiles.
+type RelatedFilesProps struct {
+	// Title heads the card, followed by the number of files. It defaults to
+	// "Files".
+	Title string
+	// Files are the record's files, as returned by api.RecordFiles.
+	Files []api.RecordFile
+	// Location is the time zone dates are shown in; nil means the user's,
+	// from i18n.Location.
+	Location *time.Location
+	// PreviewID is the ContentDocumentID of the file previewed below its row,
+	// and PreviewData its contents from api.FileData. A spinner is shown
+	// while PreviewData is nil.
+	PreviewID   string
+	PreviewData []byte
+	// OnPreview is called when the title of an image or PDF is clicked.
+	// Clicking the title of another type of file downloads it.
+	OnPreview      func(file api.RecordFile)
+	OnClosePreview func()
+	// OnDownload is called to download a file, e.g. with api.DownloadFile.
+	OnDownload func(file api.RecordFile)
+	// OnFiles, if set, shows a FileSelector for adding files.
+	OnFiles func(files []api.File)
+}
+
+// RelatedFiles renders a card listing a record's files with their type
+// icon, size, owner and date. Files can be downloaded, and images and PDFs
+// previewed inline.
+func RelatedFiles(props RelatedFilesProps) masc.ComponentOrHTML {
+	title := props.Title
+	if title == "" {
+		title = i18n.T("Thunder_Files")
+	}
+	loc := props.Location
+	if loc == nil {
+		loc = i18n.Location()
+	}
+
+	var body []masc.MarkupOrChild
+	if props.OnFiles != nil {
+		body = append(body, FileSelector(FileSelectorProps{
+			Label:    i18n.T("Thunder_Upload_Files"),
+			Multiple: true,
+			OnFiles:  props.OnFiles,
+		}))
+	}
+	if len(props.Files) == 0 {
+		body = append(body, elem.Paragraph(
+			masc.Markup(masc.Class("slds-text-color_weak")),
+			masc.Text(i18n.T("Thunder_No_Files")),
+		))
+	} else {
+		items := []masc.MarkupOrChild{masc.Markup(masc.Class("slds-has-dividers_bottom-space"))}
+		for _, file := range props.Files {
+			items = append(items, relatedFile(props, file, loc))
+		}
+		body = append(body, elem.UnorderedList(items...))
+	}
+
+	return Card(i18n.T("Thunder_Files_Count", title, strconv.Itoa(len(props.Files))), body...)
+}
+
+// relatedFile renders a file's row, with its preview when it is previewed.
+func relatedFile(props RelatedFilesProps, file api.RecordFile, loc *time.Location) masc.ComponentOrHTML {
+	open := func(*masc.Event) {
+		switch {
+		case canPreview(file) && props.OnPreview != nil:
+			props.OnPreview(file)
+		case props.OnDownload != nil:
+			props.OnDownload(file)
+		}
+	}
+	download := func() {
+		if props.OnDownload != nil {
+			props.OnDownload(file)
+		}
+	}
+
+	var meta []masc.MarkupOrChild
+	meta = append(meta, masc.Markup(masc.Class("slds-list_horizontal", "slds-has-dividers_right", "slds-text-body_small", "slds-text-color_weak")))
+	details := []string{i18n.FormatDate(file.LastModifiedDate.In(loc)), formatFileSize(file.ContentSize), strings.ToLower(file.FileExtension), file.OwnerName}
+	if file.LastModifiedDate.IsZero() {
+		details[0] = ""
+	}
+	for _, detail := range details {
+		if detail != "" {
+			meta = append(meta, elem.ListItem(masc.Markup(masc.Class("slds-item")), masc.Text(detail)))
+		}
+	}
+
+	row := []masc.MarkupOrChild{
+		masc.Markup(masc.Class("slds-item")),
+		elem.Div(
+			masc.Markup(masc.Class("slds-media", "slds-media_center")),
+			elem.Div(
+				masc.Markup(masc.Class("slds-media__figure")),
+				Icon(DoctypeIcon, fileTypeIcon(file.FileType), IconSmall),
+			),
+			elem.Div(
+				masc.Markup(masc.Class("slds-media__body")),
+				elem.Anchor(
+					masc.Markup(
+						masc.Class("slds-truncate"),
+						masc.Attribute("href", "javascript:void(0);"),
+						masc.Property("title", file.Title),
+						event.Click(open).PreventDefault(),
+					),
+					masc.Text(file.Title),
+				),
+				elem.UnorderedList(meta...),
+			),
+			elem.Div(
+				masc.Markup(masc.Class("slds-media__figure", "slds-media__figure_reverse")),
+				ButtonIcon(ButtonIconProps{
+					IconName:      "utility:download",
+					Variant:       ButtonIconBorderFilled,
+					Size:          IconXSmall,
+					AssistiveText: i18n.T("Thunder_Download_File", file.Title),
+					OnClick:       download,
+				}),
+			),
+		),
+	}
+	if props.PreviewID != "" && props.PreviewID == file.ContentDocumentID {
+		preview := []masc.MarkupOrChild{
+			masc.Markup(masc.Class("slds-m-top_small")),
+		}
+		if props.OnClosePreview != nil {
+			onClose := props.OnClosePreview
+			preview = append(preview, elem.Div(
+				masc.Markup(masc.Class("slds-text-align_right")),
+				ButtonIcon(ButtonIconProps{
+					IconName:      "utility:close",
+					AssistiveText: i18n.T("Thunder_Close_Preview"),
+					OnClick:       onClose,
+				}),
+			))
+		}
+		if props.PreviewData == nil {
+			preview = append(preview, CenteredSpinner("small"))
+		} else {
+			preview = append(preview, FilePreview(file, props.PreviewData))
+		}
+		row = append(row, elem.Div(preview...))
+	}
+	return elem.ListItem(row...)
+}
+
+// FilePreview shows an image or PDF file's contents, as returned by
+// api.FileData. Other types of file show that no preview is available.
+// Under Lightning, whose Content Security Policy doesn't let pages frame
+// data: or blob: URLs, a PDF is offered for download instead.
+func FilePreview(file api.RecordFile, data []byte) masc.ComponentOrHTML {
+	if !canPreview(file) {
+		return elem.Paragraph(
+			masc.Markup(masc.Class("slds-text-color_weak", "slds-text-align_center", "slds-p-around_medium")),
+			masc.Text(i18n.T("Thunder_No_Preview")),
+		)
+	}
+	if strings.EqualFold(file.FileType, "PDF") {
+		return &pdfPreview{File: file, Data: data}
+	}
+	src := "data:" + file.MimeType() + ";base64," + base64.StdEncoding.EncodeToString(data)
+	return elem.Div(
+		masc.Markup(masc.Class("slds-box", "slds-box_xx-small", "slds-text-align_center")),
+		elem.Image(masc.Markup(
+			masc.Attribute("src", src),
+			masc.Attribute("alt", file.Title),
+			masc.Style("max-width", "100%"),
+			masc.Style("max-height", "30rem"),
+		)),
+	)
+}
+
+// pdfPreview frames a PDF from a blob URL, which it revokes when its data
+// changes or it is unmounted.
+type pdfPreview struct {
+	masc.Core
+
+	File api.RecordFile `masc:"prop"`
+	Data []byte         `masc:"prop"`
+
+	url     string
+	urlData []byte
+}
+
+func (p *pdfPreview) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if p.url == "" || !sameData(p.urlData, p.Data) {
+		p.revoke()
+		p.url = pdfFrameURL(p.Data)
+		p.urlData = p.Data
+	}
+	if p.url == "" {
+		file, data := p.File, p.Data
+		return elem.Div(
+			masc.Markup(masc.Class("slds-box", "slds-box_xx-small", "slds-text-align_center", "slds-p-around_medium")),
+			elem.Paragraph(
+				masc.Markup(masc.Class("slds-text-color_weak", "slds-m-bottom_small")),
+				masc.Text(i18n.T("Thunder_Preview_Blocked")),
+			),
+			Button(i18n.T("Thunder_Download_File", file.Title), VariantNeutral, func(*masc.Event) {
+				api.Download(file.FileName(), file.MimeType(), data)
+			}),
+		)
+	}
+	return elem.InlineFrame(masc.Markup(
+		masc.Class("slds-box", "slds-box_xx-small"),
+		masc.Attribute("src", p.url),
+		masc.Property("title", p.File.Title),
+		masc.Style("width", "100%"),
+		masc.Style("height", "30rem"),
+	))
+}
+
+func (p *pdfPreview) Unmount() {
+	p.revoke()
+}
+
+func (p *pdfPreview) revoke() {
+	if p.url != "" {
+		revokeFrameURL(p.url)
+		p.url = ""
+	}
+}
+
+// sameData reports whether a and b are the same slice, without comparing
+// their contents.
+func sameData(a, b []byte) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// previewTypes lists the file types shown by FilePreview: those browsers
+// display without a plugin.
+var previewTypes = map[string]bool{
+	"PDF": true, "PNG": true, "JPG": true, "JPEG": true, "GIF": true,
+	"BMP": true, "WEBP": true, "SVG": true,
+}
+
+// canPreview reports whether FilePreview can show a file.
+func canPreview(file api.RecordFile) bool {
+	return previewTypes[strings.ToUpper(file.FileType)]
+}
+
+// fileTypeIcons maps Salesforce file types to doctype icons.
+var fileTypeIcons = map[string]string{
+	"AI":                  "ai",
+	"BMP":                 "image",
+	"CSV":                 "csv",
+	"EPS":                 "eps",
+	"EXCEL":               "excel",
+	"EXCEL_M":             "excel",
+	"EXCEL_X":             "excel",
+	"EXE":                 "exe",
+	"GIF":                 "image",
+	"GOOGLE_DOCUMENT":     "gdoc",
+	"GOOGLE_PRESENTATION": "gpres",
+	"GOOGLE_SPREADSHEET":  "gsheet",
+	"HTML":                "html",
+	"JPEG":                "image",
+	"JPG":                 "image",
+	"KEYNOTE":             "keynote",
+	"LINK":                "link",
+	"M4A":                 "audio",
+	"MOV":                 "video",
+	"MP3":                 "audio",
+	"MP4":                 "mp4",
+	"PACK":                "pack",
+	"PAGES":               "pages",
+	"PDF":                 "pdf",
+	"PNG":                 "image",
+	"POWER_POINT":         "ppt",
+	"POWER_POINT_M":       "ppt",
+	"POWER_POINT_X":       "ppt",
+	"PSD":                 "psd",
+	"RTF":                 "rtf",
+	"SNOTE":               "box_notes",
+	"SVG":                 "image",
+	"TEXT":                "txt",
+	"VISIO":               "visio",
+	"WAV":                 "audio",
+	"WEBP":                "image",
+	"WORD":                "word",
+	"WORD_M":              "word",
+	"WORD_X":              "word",
+	"XML":                 "xml",
+	"ZIP":                 "zip",
+}
+
+// fileTypeIcon returns the doctype icon for a Salesforce file type.
+func fileTypeIcon(fileType string) string {
+	if icon, ok := fileTypeIcons[strings.ToUpper(fileType)]; ok {
+		return icon
+	}
+	return "unknown"
+}
+
+// formatFileSize writes a size in bytes the way Salesforce lists files,
+// e.g. "120 KB" or "1.5 MB", in the user's number format.
+func formatFileSize(size int64) string {
+	locale := NumberLocaleFor(i18n.Locale())
+	switch {
+	case size < 1024:
+		return strconv.FormatInt(size, 10) + " B"
+	case size < 1024*1024:
+		return locale.FormatNumber(float64(size)/1024, 0) + " KB"
+	case size < 1024*1024*1024:
+		return locale.FormatNumber(float64(size)/(1024*1024), 1) + " MB"
+	default:
+		return locale.FormatNumber(float64(size)/(1024*1024*1024), 1) + " GB"
+	}
+}
@@ -0,0 +1,195 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+var testRecordFiles = []api.RecordFile{
+	{
+		ContentDocumentID: "069A",
+		LatestVersionID:   "068A",
+		Title:             "Q3 Report",
+		FileType:          "PDF",
+		FileExtension:     "pdf",
+		ContentSize:       1572864,
+		OwnerName:         "Ann Admin",
+		LastModifiedDate:  time.Date(2025, 3, 14, 17, 30, 0, 0, time.UTC),
+	},
+	{
+		ContentDocumentID: "069B",
+		LatestVersionID:   "068B",
+		Title:             "Budget",
+		FileType:          "EXCEL_X",
+		FileExtension:     "xlsx",
+		ContentSize:       2048,
+		OwnerName:         "Bob Builder",
+	},
+}
+
+// TestRelatedFiles verifies each file is listed with its type icon, date,
+// size, type and owner.
+func TestRelatedFiles(t *testing.T) {
+	win := renderComponent(t, RelatedFiles(RelatedFilesProps{
+		Files:    testRecordFiles,
+		Location: time.UTC,
+	}))
+	if heading := querySelector(t, win, ".slds-card__header h2"); heading == nil || heading.TextContent() != "Files (2)" {
+		t.Error("expected the heading Files (2)")
+	}
+	doc := win.Document()
+	rows, err := doc.QuerySelectorAll("ul.slds-has-dividers_bottom-space > li.slds-item")
+	if err != nil || rows.Length() != 2 {
+		t.Fatalf("expected 2 file rows, got %v (%v)", rows, err)
+	}
+	if icon := querySelector(t, win, ".slds-icon-doctype-pdf"); icon == nil {
+		t.Error("expected the PDF doctype icon")
+	}
+	if icon := querySelector(t, win, ".slds-icon-doctype-excel"); icon == nil {
+		t.Error("expected the Excel doctype icon")
+	}
+	if link := querySelector(t, win, ".slds-media__body a"); link == nil || link.TextContent() != "Q3 Report" {
+		t.Error("expected the file title link")
+	}
+	details := querySelector(t, win, ".slds-media__body .slds-list_horizontal")
+	if details == nil {
+		t.Fatal("expected the file details")
+	}
+	if got := details.TextContent(); got != "3/14/20251.5 MBpdfAnn Admin" {
+		t.Errorf("expected the date, size, type and owner, got %q", got)
+	}
+	if button := querySelector(t, win, `button[title="Download Budget"]`); button == nil {
+		t.Error("expected a download button for Budget")
+	}
+}
+
+// TestRelatedFilesUserDate verifies dates are shown in the user's locale
+// and time zone by default.
+func TestRelatedFilesUserDate(t *testing.T) {
+	i18n.SetLocale("de_DE")
+	i18n.SetLocation(time.FixedZone("JST", 9*60*60))
+	t.Cleanup(func() {
+		i18n.SetLocale("en_US")
+		i18n.SetLocation(time.Local)
+	})
+	win := renderComponent(t, RelatedFiles(RelatedFilesProps{Files: testRecordFiles[:1]}))
+	details := querySelector(t, win, ".slds-media__body .slds-list_horizontal .slds-item")
+	if details == nil || details.TextContent() != "15.3.2025" {
+		t.Error("expected the date 15.3.2025")
+	}
+}
+
+// TestRelatedFilesEmpty verifies a record without files says so and offers
+// a file selector when OnFiles is set.
+func TestRelatedFilesEmpty(t *testing.T) {
+	win := renderComponent(t, RelatedFiles(RelatedFilesProps{
+		Title:   "Attachments",
+		OnFiles: func([]api.File) {},
+	}))
+	if heading := querySelector(t, win, ".slds-card__header h2"); heading == nil || heading.TextContent() != "Attachments (0)" {
+		t.Error("expected the heading Attachments (0)")
+	}
+	if querySelector(t, win, ".slds-file-selector") == nil {
+		t.Error("expected a file selector")
+	}
+	if text := querySelector(t, win, ".slds-card__body p"); text == nil || text.TextContent() != "No files" {
+		t.Error("expected No files")
+	}
+}
+
+// TestRelatedFilesOpen verifies clicking an image or PDF previews it, other
+// files are downloaded, and the download button downloads.
+func TestRelatedFilesOpen(t *testing.T) {
+	var previewed, downloaded []string
+	win := renderComponent(t, RelatedFiles(RelatedFilesProps{
+		Files:      testRecordFiles,
+		OnPreview:  func(f api.RecordFile) { previewed = append(previewed, f.Title) },
+		OnDownload: func(f api.RecordFile) { downloaded = append(downloaded, f.Title) },
+	}))
+	doc := win.Document()
+	links, err := doc.QuerySelectorAll(".slds-media__body a")
+	if err != nil || links.Length() != 2 {
+		t.Fatalf("expected 2 file links, got %v (%v)", links, err)
+	}
+	links.Item(0).(html.HTMLElement).Click()
+	links.Item(1).(html.HTMLElement).Click()
+	querySelector(t, win, `button[title="Download Q3 Report"]`).(html.HTMLElement).Click()
+	if strings.Join(previewed, ",") != "Q3 Report" {
+		t.Errorf("expected Q3 Report previewed, got %v", previewed)
+	}
+	if strings.Join(downloaded, ",") != "Budget,Q3 Report" {
+		t.Errorf("expected Budget and Q3 Report downloaded, got %v", downloaded)
+	}
+}
+
+// TestRelatedFilesPreview verifies the previewed file shows a spinner until
+// its contents arrive, then the preview with a close button.
+func TestRelatedFilesPreview(t *testing.T) {
+	closed := false
+	props := RelatedFilesProps{
+		Files:          testRecordFiles,
+		PreviewID:      "069A",
+		OnClosePreview: func() { closed = true },
+	}
+	win := renderComponent(t, RelatedFiles(props))
+	if querySelector(t, win, ".slds-spinner") == nil {
+		t.Error("expected a spinner while the preview loads")
+	}
+
+	props.PreviewData = []byte("%PDF-1.4")
+	win = renderComponent(t, RelatedFiles(props))
+	if querySelector(t, win, "iframe") != nil {
+		t.Error("expected no frame where PDFs can't be framed")
+	}
+	if text := querySelector(t, win, ".slds-box p"); text == nil || text.TextContent() != "This file can't be previewed here" {
+		t.Error("expected the PDF to say it can't be previewed")
+	}
+	if button := querySelector(t, win, ".slds-box button.slds-button_neutral"); button == nil || button.TextContent() != "Download Q3 Report" {
+		t.Error("expected a button downloading the PDF")
+	}
+	querySelector(t, win, `button[title="Close preview"]`).(html.HTMLElement).Click()
+	if !closed {
+		t.Error("expected OnClosePreview to be called")
+	}
+}
+
+// TestFilePreview verifies images are shown inline and other files say no
+// preview is available.
+func TestFilePreview(t *testing.T) {
+	win := renderComponent(t, FilePreview(api.RecordFile{Title: "Logo", FileType: "PNG", FileExtension: "png"}, []byte("png")))
+	img := querySelector(t, win, "img")
+	if img == nil {
+		t.Fatal("expected an image")
+	}
+	if src, _ := img.GetAttribute("src"); src != "data:image/png;base64,cG5n" {
+		t.Errorf("expected a PNG data URL, got %q", src)
+	}
+	if alt, _ := img.GetAttribute("alt"); alt != "Logo" {
+		t.Errorf("expected alt Logo, got %q", alt)
+	}
+
+	win = renderComponent(t, FilePreview(testRecordFiles[1], []byte("xlsx")))
+	if text := querySelector(t, win, "p"); text == nil || text.TextContent() != "No preview available" {
+		t.Error("expected No preview available")
+	}
+}
+
+// TestFormatFileSize verifies sizes are written in bytes, KB, MB or GB.
+func TestFormatFileSize(t *testing.T) {
+	tests := map[int64]string{
+		512:        "512 B",
+		2048:       "2 KB",
+		1572864:    "1.5 MB",
+		3221225472: "3.0 GB",
+	}
+	for size, want := range tests {
+		if got := formatFileSize(size); got != want {
+			t.Errorf("formatFileSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"golang.org/x/net/html"
+)
+
+// richTextElements lists the elements kept by SanitizeRichText: the
+// formatting Salesforce's Rich Text Area fields produce.
+var richTextElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "blockquote": true, "br": true,
+	"caption": true, "cite": true, "code": true, "col": true, "colgroup": true,
+	"dd": true, "del": true, "div": true, "dl": true, "dt": true, "em": true,
+	"font": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "hr": true, "i": true, "img": true, "ins": true, "kbd": true,
+	"li": true, "ol": true, "p": true, "pre": true, "q": true, "s": true,
+	"samp": true, "small": true, "span": true, "strike": true, "strong": true,
+	"sub": true, "sup": true, "table": true, "tbody": true, "td": true,
+	"tfoot": true, "th": true, "thead": true, "tr": true, "tt": true, "u": true,
+	"ul": true, "var": true,
+}
+
+// richTextDropped lists elements removed along with their content. Other
+// unknown elements are removed but their content is kept.
+var richTextDropped = map[string]bool{
+	"applet": true, "button": true, "embed": true, "frame": true,
+	"frameset": true, "head": true, "iframe": true, "math": true,
+	"noembed": true, "noframes": true, "noscript": true, "object": true,
+	"script": true, "select": true, "style": true, "svg": true,
+	"template": true, "textarea": true, "title": true, "xmp": true,
+}
+
+// voidElements have no content or end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// richTextAttributes lists the attributes kept on each element; those under
+// "" are kept on any element.
+var richTextAttributes = map[string]map[string]bool{
+	"":         {"style": true, "title": true, "dir": true, "lang": true},
+	"a":        {"href": true, "target": true, "name": true},
+	"img":      {"src": true, "alt": true, "width": true, "height": true},
+	"font":     {"color": true, "face": true, "size": true},
+	"ol":       {"start": true, "type": true},
+	"li":       {"value": true},
+	"table":    {"border": true, "cellpadding": true, "cellspacing": true, "width": true, "align": true},
+	"td":       {"colspan": true, "rowspan": true, "align": true, "valign": true, "width": true},
+	"th":       {"colspan": true, "rowspan": true, "align": true, "valign": true, "width": true, "scope": true},
+	"col":      {"span": true, "width": true},
+	"colgroup": {"span": true, "width": true},
+	"p":        {"align": true},
+	"div":      {"align": true},
+}
+
+// richTextStyles lists the CSS properties kept in style attributes.
+var richTextStyles = map[string]bool{
+	"color": true, "background-color": true, "text-align": true,
+	"text-decoration": true, "font-weight": true, "font-style": true,
+	"font-size": true, "font-family": true,
+}
+
+// safeStyleValue matches CSS values without functions other than rgb(),
+// escapes or anything else that could load content.
+var safeStyleValue = regexp.MustCompile(`^(?:[#\w\s.,%'"-]|rgba?\([\d\s.,%]*\))+$`)
+
+// SanitizeRichText returns value, such as a Rich Text Area field, reduced to
+// an allowlist of formatting elements, attributes and styles so it can be
+// shown as HTML. Scripts, styles, embedded content and event handlers are
+// removed, links may only use http, https, mailto and tel URLs, images only
+// http and https ones, and unclosed elements are closed.
+func SanitizeRichText(value string) string {
+	z := html.NewTokenizer(strings.NewReader(value))
+	var b strings.Builder
+	var open []string
+	skip := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				b.WriteString("</" + open[i] + ">")
+			}
+			return b.String()
+		case html.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(string(z.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			name := tok.Data
+			if richTextDropped[name] || skip > 0 {
+				if richTextDropped[name] && tt == html.StartTagToken && !voidElements[name] {
+					skip++
+				}
+				continue
+			}
+			if !richTextElements[name] {
+				continue
+			}
+			b.WriteString("<" + name)
+			for _, attr := range sanitizeRichTextAttributes(name, tok.Attr) {
+				b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			b.WriteString(">")
+			if !voidElements[name] {
+				open = append(open, name)
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if skip > 0 {
+				if richTextDropped[tag] {
+					skip--
+				}
+				continue
+			}
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tag {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+}
+
+// sanitizeRichTextAttributes returns the allowed attributes of an element.
+func sanitizeRichTextAttributes(element string, attrs []html.Attribute) []html.Attribute {
+	var kept []html.Attribute
+	blank := false
+	for _, attr := range attrs {
+		key := attr.Key
+		if attr.Namespace != "" || !(richTextAttributes[""][key] || richTextAttributes[element][key]) {
+			continue
+		}
+		val := attr.Val
+		switch key {
+		case "style":
+			val = sanitizeRichTextStyle(val)
+		case "href":
+			if !safeRichTextURL(val, "http", "https", "mailto", "tel") {
+				continue
+			}
+		case "src":
+			if !safeRichTextURL(val, "http", "https") {
+				continue
+			}
+		case "target":
+			if val != "_blank" {
+				continue
+			}
+			blank = true
+		}
+		if val == "" && key != "alt" {
+			continue
+		}
+		kept = append(kept, html.Attribute{Key: key, Val: val})
+	}
+	if blank {
+		kept = append(kept, html.Attribute{Key: "rel", Val: "noopener noreferrer"})
+	}
+	return kept
+}
+
+// sanitizeRichTextStyle keeps the allowed declarations of a style attribute.
+func sanitizeRichTextStyle(style string) string {
+	var kept []string
+	for _, decl := range strings.Split(style, ";") {
+		prop, val, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		prop = strings.ToLower(strings.TrimSpace(prop))
+		val = strings.TrimSpace(val)
+		if !richTextStyles[prop] || !safeStyleValue.MatchString(val) {
+			continue
+		}
+		kept = append(kept, prop+": "+val+";")
+	}
+	return strings.Join(kept, " ")
+}
+
+// safeRichTextURL reports whether u is relative or uses one of schemes.
+func safeRichTextURL(u string, schemes ...string) bool {
+	u = strings.TrimSpace(u)
+	if strings.ContainsAny(u, "\x00\t\n\r\\") {
+		return false
+	}
+	i := strings.IndexAny(u, ":/?#")
+	if i < 0 || u[i] != ':' {
+		return true
+	}
+	scheme := strings.ToLower(u[:i])
+	for _, s := range schemes {
+		if scheme == s {
+			return true
+		}
+	}
+	return false
+}
+
+// richTextEmpty reports whether sanitized rich text shows nothing, like the
+// "<p><br></p>" browsers leave in an emptied editor.
+func richTextEmpty(value string) bool {
+	if strings.Contains(value, "<img") || strings.Contains(value, "<hr") {
+		return false
+	}
+	z := html.NewTokenizer(strings.NewReader(value))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return true
+		case html.TextToken:
+			if strings.TrimSpace(string(z.Text())) != "" {
+				return false
+			}
+		}
+	}
+}
+
+// FormattedRichText shows rich text, such as a Rich Text Area field, as
+// formatted HTML after passing it through SanitizeRichText.
+func FormattedRichText(value string) masc.ComponentOrHTML {
+	return elem.Div(
+		masc.Markup(
+			masc.Class("slds-rich-text-editor__output"),
+			masc.UnsafeHTML(SanitizeRichText(value)),
+		),
+	)
+}
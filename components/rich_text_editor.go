@@ -0,0 +1,154 @@
+package components
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// RichTextEditorProps configures a RichTextEditor.
+type RichTextEditorProps struct {
+	Label string
+	// Value is the HTML being edited, e.g. a Rich Text Area field.
+	Value string
+	// OnChange receives the edited HTML, sanitized with SanitizeRichText.
+	// Content that shows nothing, such as an emptied editor's "<p><br></p>",
+	// is sent as "".
+	OnChange func(html string)
+}
+
+// RichTextEditor renders an SLDS rich text editor with a toolbar for bold,
+// italic and underlined text, lists and links.
+func RichTextEditor(props RichTextEditorProps) masc.ComponentOrHTML {
+	return ValidatedRichTextEditor(props, ValidationState{})
+}
+
+// ValidatedRichTextEditor renders a RichTextEditor with validation state.
+func ValidatedRichTextEditor(props RichTextEditorProps, validation ValidationState) masc.ComponentOrHTML {
+	return &richTextEditor{Props: props, Validation: validation}
+}
+
+// richTextEditorIDs numbers editors so the toolbar can find its content.
+var richTextEditorIDs atomic.Int64
+
+// richTextEditor keeps the HTML it last rendered into its editable area.
+// The browser edits that content in place, so it is only replaced when the
+// app changes Value to something other than what the editor sent, which
+// would otherwise move the caret on every keystroke.
+type richTextEditor struct {
+	masc.Core
+
+	Props      RichTextEditorProps `masc:"prop"`
+	Validation ValidationState     `masc:"prop"`
+
+	id       string
+	rendered bool
+	content  string
+	sent     string
+}
+
+func (r *richTextEditor) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	if r.id == "" {
+		r.id = fmt.Sprintf("rich-text-%d", richTextEditorIDs.Add(1))
+	}
+	if !r.rendered || r.Props.Value != r.sent {
+		content := SanitizeRichText(r.Props.Value)
+		if r.rendered && content == r.content {
+			// The rendered content is unchanged but the browser's copy holds
+			// edits the app discarded.
+			setRichTextContent(r.id, content)
+		}
+		r.content = content
+		r.sent = r.Props.Value
+		r.rendered = true
+	}
+
+	editorClasses := []string{"slds-rich-text-editor", "slds-grid", "slds-grid_vertical", "slds-nowrap"}
+	if r.Validation.HasError {
+		editorClasses = append(editorClasses, "slds-has-error")
+	}
+	editor := elem.Div(
+		masc.Markup(masc.Class(editorClasses...)),
+		elem.Div(
+			masc.Markup(
+				masc.Class("slds-rich-text-editor__toolbar", "slds-shrink-none"),
+				masc.Attribute("role", "toolbar"),
+				// Keep the focus, and the selection, in the editable area
+				// while toolbar buttons are clicked.
+				event.MouseDown(func(*masc.Event) {}).PreventDefault(),
+			),
+			r.toolbarGroup(i18n.T("Thunder_Format_Text"),
+				r.toolbarButton("utility:bold", i18n.T("Thunder_Bold"), func() { richTextCommand(r.id, "bold", "") }),
+				r.toolbarButton("utility:italic", i18n.T("Thunder_Italic"), func() { richTextCommand(r.id, "italic", "") }),
+				r.toolbarButton("utility:underline", i18n.T("Thunder_Underline"), func() { richTextCommand(r.id, "underline", "") }),
+			),
+			r.toolbarGroup(i18n.T("Thunder_Format_Body"),
+				r.toolbarButton("utility:richtextbulletedlist", i18n.T("Thunder_Bulleted_List"), func() { richTextCommand(r.id, "insertUnorderedList", "") }),
+				r.toolbarButton("utility:richtextnumberedlist", i18n.T("Thunder_Numbered_List"), func() { richTextCommand(r.id, "insertOrderedList", "") }),
+			),
+			r.toolbarGroup(i18n.T("Thunder_Link"),
+				r.toolbarButton("utility:link", i18n.T("Thunder_Link"), func() { richTextLink(r.id, i18n.T("Thunder_Enter_Link_URL")) }),
+				r.toolbarButton("utility:unlinked", i18n.T("Thunder_Remove_Link"), func() { richTextCommand(r.id, "unlink", "") }),
+			),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-rich-text-editor__textarea", "slds-grid")),
+			elem.Div(
+				masc.Markup(
+					masc.Class("slds-rich-text-area__content", "slds-grow"),
+					masc.Property("id", r.id),
+					masc.Attribute("contenteditable", "true"),
+					masc.Attribute("role", "textbox"),
+					masc.Attribute("aria-multiline", "true"),
+					masc.Attribute("aria-label", r.Props.Label),
+					masc.Style("min-height", "6rem"),
+					masc.UnsafeHTML(r.content),
+					event.Input(func(e *masc.Event) {
+						r.edited(e.Target.Get("innerHTML").String())
+					}),
+					event.Paste(func(e *masc.Event) { richTextPaste(e) }),
+				),
+			),
+		),
+	)
+
+	return validatedFormElement(r.Props.Label, r.Validation, editor)
+}
+
+// edited sends the browser's content after an edit.
+func (r *richTextEditor) edited(content string) {
+	value := SanitizeRichText(content)
+	if richTextEmpty(value) {
+		value = ""
+	}
+	r.sent = value
+	if r.Props.OnChange != nil {
+		r.Props.OnChange(value)
+	}
+}
+
+// toolbarGroup renders a labelled group of toolbar buttons.
+func (r *richTextEditor) toolbarGroup(label string, buttons ...masc.ComponentOrHTML) masc.ComponentOrHTML {
+	items := []masc.MarkupOrChild{masc.Markup(
+		masc.Class("slds-button-group-list"),
+		masc.Attribute("aria-label", label),
+	)}
+	for _, b := range buttons {
+		items = append(items, elem.ListItem(b))
+	}
+	return elem.UnorderedList(items...)
+}
+
+// toolbarButton renders a toolbar button running a formatting command.
+func (r *richTextEditor) toolbarButton(icon, label string, onClick func()) masc.ComponentOrHTML {
+	return ButtonIcon(ButtonIconProps{
+		IconName:      icon,
+		Variant:       ButtonIconBorderFilled,
+		AssistiveText: label,
+		OnClick:       onClick,
+	})
+}
@@ -0,0 +1,75 @@
+//go:build js
+
+package components
+
+import (
+	"syscall/js"
+
+	"github.com/octoberswimmer/masc"
+)
+
+// richTextCommand applies a formatting command to the selection in the
+// editor with the given id. The browser then fires an input event, which
+// sends the new content to the app.
+func richTextCommand(id, command, value string) {
+	el := js.Global().Get("document").Call("getElementById", id)
+	if !el.Truthy() {
+		return
+	}
+	el.Call("focus")
+	js.Global().Get("document").Call("execCommand", command, false, value)
+}
+
+// richTextLink asks for a URL and links the selected text in the editor to
+// it. The selection is restored after the prompt takes the focus.
+func richTextLink(id, prompt string) {
+	el := js.Global().Get("document").Call("getElementById", id)
+	selection := js.Global().Call("getSelection")
+	if !el.Truthy() || !selection.Truthy() || selection.Get("rangeCount").Int() == 0 {
+		return
+	}
+	selected := selection.Call("getRangeAt", 0)
+	if !el.Call("contains", selected.Get("commonAncestorContainer")).Bool() {
+		return
+	}
+	url := js.Global().Call("prompt", prompt, "https://")
+	if url.Type() != js.TypeString || url.String() == "" || !safeRichTextURL(url.String(), "http", "https", "mailto", "tel") {
+		return
+	}
+	el.Call("focus")
+	selection.Call("removeAllRanges")
+	selection.Call("addRange", selected)
+	richTextCommand(id, "createLink", url.String())
+}
+
+// richTextPaste inserts pasted HTML sanitized, so the editor shows what will
+// be saved.
+func richTextPaste(e *masc.Event) {
+	if e == nil || !e.Value.Truthy() {
+		return
+	}
+	data := e.Value.Get("clipboardData")
+	if !data.Truthy() {
+		return
+	}
+	pasted := data.Call("getData", "text/html").String()
+	if pasted == "" {
+		return
+	}
+	e.Value.Call("preventDefault")
+	js.Global().Get("document").Call("execCommand", "insertHTML", false, SanitizeRichText(pasted))
+}
+
+// setRichTextContent replaces the content of the editor with the given id
+// once the current render has been applied.
+func setRichTextContent(id, content string) {
+	var update js.Func
+	update = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		update.Release()
+		if el := js.Global().Get("document").Call("getElementById", id); el.Truthy() {
+			el.Set("innerHTML", content)
+		}
+		return nil
+	})
+	js.Global().Call("setTimeout", update, 0)
+}
@@ -0,0 +1,16 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+// The rich text editor formats its content with the browser's editing
+// commands; outside the browser these are no-ops.
+
+func richTextCommand(id, command, value string) {}
+
+func richTextLink(id, prompt string) {}
+
+func richTextPaste(e *masc.Event) {}
+
+func setRichTextContent(id, content string) {}
@@ -0,0 +1,113 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// TestSanitizeRichText verifies rich text is reduced to the allowlist.
+func TestSanitizeRichText(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{`<p>Hello <b>world</b></p>`, `<p>Hello <b>world</b></p>`},
+		{`<p onclick="alert(1)">Hi<script>alert(1)</script></p>`, `<p>Hi</p>`},
+		{`<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href=" JavaScript:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href="https://example.com" target="_blank">x</a>`, `<a href="https://example.com" target="_blank" rel="noopener noreferrer">x</a>`},
+		{`<a href="/lightning/r/Account/001/view">x</a>`, `<a href="/lightning/r/Account/001/view">x</a>`},
+		{`<img src="data:image/png;base64,AAAA" alt="x"><img src="https://example.com/a.png">`, `<img alt="x"><img src="https://example.com/a.png">`},
+		{`<span style="color: red; background-image: url(x); font-weight:bold">x</span>`, `<span style="color: red; font-weight: bold;">x</span>`},
+		{`<span style="color: expression(alert(1))">x</span>`, `<span>x</span>`},
+		{`<div><iframe src="https://example.com"></iframe><form><label>Name</label></form></div>`, `<div>Name</div>`},
+		{`<ul><li>one<li>two</ul><p>open`, `<ul><li>one<li>two</li></li></ul><p>open</p>`},
+		{`1 &lt; 2 &amp; <!-- note -->3`, `1 &lt; 2 &amp; 3`},
+		{`<svg><script>alert(1)</script><text>x</text></svg>after`, `after`},
+	} {
+		if got := SanitizeRichText(tc.in); got != tc.want {
+			t.Errorf("SanitizeRichText(%q)\n got %q\nwant %q", tc.in, got, tc.want)
+		}
+		if got := SanitizeRichText(tc.want); got != tc.want {
+			t.Errorf("expected sanitized output %q to be unchanged, got %q", tc.want, got)
+		}
+	}
+}
+
+// TestFormattedRichText verifies rich text is rendered sanitized.
+func TestFormattedRichText(t *testing.T) {
+	win := renderComponent(t, FormattedRichText(`<p>Hi <strong>there</strong><script>alert(1)</script></p>`))
+	out := querySelector(t, win, ".slds-rich-text-editor__output")
+	if out == nil {
+		t.Fatal("expected rich text output")
+	}
+	if out.InnerHTML() != `<p>Hi <strong>there</strong></p>` {
+		t.Errorf("unexpected output %q", out.InnerHTML())
+	}
+}
+
+// TestRichTextEditor verifies the editor shows its value, toolbar and error.
+func TestRichTextEditor(t *testing.T) {
+	win := renderComponent(t, ValidatedRichTextEditor(RichTextEditorProps{
+		Label: "Description",
+		Value: "<p>Start</p>",
+	}, ValidationState{Required: true, HasError: true, ErrorMessage: "Description is required"}))
+
+	content := querySelector(t, win, `[contenteditable="true"].slds-rich-text-area__content`)
+	if content == nil || content.InnerHTML() != "<p>Start</p>" {
+		t.Fatal("expected the value in the editable area")
+	}
+	if querySelector(t, win, ".slds-has-error .slds-rich-text-editor.slds-has-error") == nil {
+		t.Error("expected the editor to show the error")
+	}
+	if buttons, err := win.Document().QuerySelectorAll(`[role="toolbar"] button`); err != nil || buttons.Length() != 7 {
+		t.Error("expected seven toolbar buttons")
+	}
+}
+
+// TestRichTextEditorSendsSanitizedHTML verifies edits are sent sanitized and
+// an emptied editor sends "".
+func TestRichTextEditorSendsSanitizedHTML(t *testing.T) {
+	var got []string
+	editor := &richTextEditor{Props: RichTextEditorProps{
+		OnChange: func(html string) { got = append(got, html) },
+	}}
+	editor.edited(`<p>Start <b onmouseover="x()">bold</b></p>`)
+	editor.edited(`<p><br></p>`)
+	want := []string{`<p>Start <b>bold</b></p>`, ""}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if editor.sent != "" {
+		t.Errorf("expected the editor to remember what it sent, got %q", editor.sent)
+	}
+}
+
+// TestRecordFormRichTextFields verifies HTML-formatted fields are edited with
+// the rich text editor and shown formatted when read-only.
+func TestRecordFormRichTextFields(t *testing.T) {
+	info := api.ObjectInfo{Fields: map[string]api.FieldInfo{
+		"Notes__c":   {Label: "Notes", DataType: "TextArea", HTMLFormatted: true, Createable: true, Updateable: true},
+		"Summary__c": {Label: "Summary", DataType: "String", HTMLFormatted: true, Calculated: true},
+	}}
+	win := renderComponent(t, RecordForm(RecordFormProps{
+		ObjectInfo: info,
+		Fields:     []string{"Notes__c", "Summary__c"},
+		Record: map[string]interface{}{
+			"Id":         "a01A",
+			"Notes__c":   "<p><i>Call back</i></p>",
+			"Summary__c": `<a href="https://example.com">Site</a><img src="x" onerror="alert(1)">`,
+		},
+	}))
+	if el := querySelector(t, win, ".slds-rich-text-area__content"); el == nil || el.InnerHTML() != "<p><i>Call back</i></p>" {
+		t.Error("expected a rich text editor for the Rich Text Area field")
+	}
+	if querySelector(t, win, `.slds-form-element__static .slds-rich-text-editor__output a[href="https://example.com"]`) == nil {
+		t.Error("expected the formula shown as rich text")
+	}
+	if img := querySelector(t, win, ".slds-rich-text-editor__output img"); img == nil {
+		t.Error("expected the formula's image")
+	} else if _, ok := img.GetAttribute("onerror"); ok {
+		t.Error("expected the image's event handler to be removed")
+	}
+}
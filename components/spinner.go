@@ -3,6 +3,7 @@ package components
 import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // Spinner renders an SLDS loading spinner.
@@ -21,7 +22,7 @@ func Spinner(size string) masc.ComponentOrHTML {
 		// Assistive text
 		elem.Span(
 			masc.Markup(masc.Class("slds-assistive-text")),
-			masc.Text("Loading"),
+			masc.Text(i18n.T("Thunder_Loading")),
 		),
 		// Spinner dots
 		elem.Div(masc.Markup(masc.Class("slds-spinner__dot-a"))),
@@ -38,7 +39,7 @@ func SmallSpinner() masc.ComponentOrHTML {
 		),
 		elem.Span(
 			masc.Markup(masc.Class("slds-assistive-text")),
-			masc.Text("Loading"),
+			masc.Text(i18n.T("Thunder_Loading")),
 		),
 		elem.Div(masc.Markup(masc.Class("slds-spinner__dot-a"))),
 		elem.Div(masc.Markup(masc.Class("slds-spinner__dot-b"))),
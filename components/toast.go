@@ -4,6 +4,7 @@ import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
 )
 
 // ToastVariant defines the SLDS theme for a toast notification.
@@ -27,40 +28,48 @@ func Toast(variant ToastVariant, header, message string, onClose func(*masc.Even
 	// Container for notify
 	return elem.Div(
 		masc.Markup(masc.Class("slds-notify_container")),
+		toastNotification(variant, header, message, nil, onClose),
+	)
+}
+
+// toastNotification renders a single toast. action, if non-nil, is shown
+// after the message.
+func toastNotification(variant ToastVariant, header, message string, action masc.ComponentOrHTML, onClose func(*masc.Event)) masc.ComponentOrHTML {
+	return elem.Div(
+		masc.Markup(
+			masc.Class("slds-notify", "slds-notify_toast", string(variant)),
+			masc.Property("role", "status"),
+		),
+		// Assistive text for screen readers
+		elem.Span(
+			masc.Markup(masc.Class("slds-assistive-text")),
+			masc.Text(header),
+		),
+		// Grouped content: title and message
 		elem.Div(
-			masc.Markup(
-				masc.Class("slds-notify", "slds-notify_toast", string(variant)),
-				masc.Property("role", "status"),
-			),
-			// Assistive text for screen readers
-			elem.Span(
-				masc.Markup(masc.Class("slds-assistive-text")),
+			masc.Markup(masc.Class("slds-notify__content")),
+			// Title
+			elem.Heading2(
+				masc.Markup(masc.Class("slds-notify__title", "slds-text-heading_small", "slds-truncate")),
 				masc.Text(header),
 			),
-			// Grouped content: title and message
+			// Message
 			elem.Div(
-				masc.Markup(masc.Class("slds-notify__content")),
-				// Title
-				elem.Heading2(
-					masc.Markup(masc.Class("slds-notify__title", "slds-text-heading_small", "slds-truncate")),
-					masc.Text(header),
-				),
-				// Message
-				elem.Div(
-					masc.Markup(masc.Class("slds-notify__message")),
-					masc.Text(message),
-				),
+				masc.Markup(masc.Class("slds-notify__message")),
+				masc.Text(message),
+				masc.If(action != nil, masc.Text(" ")),
+				action,
 			),
-			// Close button
-			elem.Button(
-				masc.Markup(
-					masc.Class("slds-button", "slds-button_icon", "slds-button_icon-inverse", "slds-notify__close"),
-					event.Click(onClose),
-				),
-				elem.Span(
-					masc.Markup(masc.Class("slds-assistive-text")),
-					masc.Text("Close"),
-				),
+		),
+		// Close button
+		elem.Button(
+			masc.Markup(
+				masc.Class("slds-button", "slds-button_icon", "slds-button_icon-inverse", "slds-notify__close"),
+				event.Click(onClose),
+			),
+			elem.Span(
+				masc.Markup(masc.Class("slds-assistive-text")),
+				masc.Text(i18n.T("Thunder_Close")),
 			),
 		),
 	)
@@ -0,0 +1,272 @@
+package components
+
+import (
+	"time"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// ToastConfig describes a toast queued with ShowToast.
+type ToastConfig struct {
+	Variant ToastVariant
+	Title   string
+	Message string
+	// Action adds a link after the message.
+	Action *ToastAction
+	// Duration overrides the variant's auto-dismiss delay. A negative
+	// duration keeps the toast open until it is closed.
+	Duration time.Duration
+}
+
+// ToastAction is a link shown in a toast. Either URL opens a page, or Msg is
+// sent to the app when the link is clicked.
+type ToastAction struct {
+	Label string
+	URL   string
+	Msg   masc.Msg
+}
+
+// DefaultToastDurations are the auto-dismiss delays used for each variant
+// unless overridden by ToastManager.Durations or ToastConfig.Duration.
+var DefaultToastDurations = map[ToastVariant]time.Duration{
+	VariantSuccess: 4 * time.Second,
+	VariantInfo:    4 * time.Second,
+	VariantWarning: 8 * time.Second,
+	VariantError:   10 * time.Second,
+}
+
+// ShowToastMsg queues a toast on a ToastManager.
+type ShowToastMsg struct {
+	Toast ToastConfig
+}
+
+// DismissToastMsg closes the toast with the given ID.
+type DismissToastMsg struct {
+	ID int
+}
+
+// platformToastFailedMsg reports that a toast could not be shown by the
+// platform and should be rendered in the app.
+type platformToastFailedMsg struct {
+	Toast ToastConfig
+}
+
+// ShowToast returns a command that queues toast on the app's ToastManager.
+func ShowToast(toast ToastConfig) masc.Cmd {
+	return func() masc.Msg {
+		return ShowToastMsg{Toast: toast}
+	}
+}
+
+// ToastManager queues toasts and dismisses them automatically. Embed it in
+// the app's model, pass every message to Update and render it with Render:
+//
+//	func (m *AppModel) Update(msg masc.Msg) (masc.Model, masc.Cmd) {
+//		if cmd := m.Toasts.Update(msg); cmd != nil {
+//			return m, cmd
+//		}
+//		switch msg := msg.(type) {
+//		case SavedMsg:
+//			return m, components.ShowToast(components.ToastConfig{
+//				Variant: components.VariantSuccess,
+//				Title:   "Saved",
+//			})
+//		}
+//		return m, nil
+//	}
+//
+// Up to MaxVisible toasts are stacked, newest first; the rest wait until one
+// closes. Each toast's auto-dismiss timer starts when it is shown.
+type ToastManager struct {
+	// MaxVisible is the number of toasts stacked at once. Defaults to 3.
+	MaxVisible int
+	// Durations overrides DefaultToastDurations by variant.
+	Durations map[ToastVariant]time.Duration
+	// UsePlatform shows toasts with Lightning's toast service when running
+	// in the thunder LWC, falling back to rendering them in the app
+	// elsewhere or when the toast's action sends a message.
+	UsePlatform bool
+
+	toasts []activeToast
+	nextID int
+}
+
+type activeToast struct {
+	ID      int
+	Config  ToastConfig
+	Visible bool
+}
+
+// Update handles toast messages, returning any command to run. Other
+// messages are ignored and return nil.
+func (m *ToastManager) Update(msg masc.Msg) masc.Cmd {
+	switch msg := msg.(type) {
+	case ShowToastMsg:
+		if m.UsePlatform && (msg.Toast.Action == nil || msg.Toast.Action.URL != "") {
+			toast := msg.Toast
+			return func() masc.Msg {
+				if api.ShowPlatformToast(platformToast(toast)) {
+					return nil
+				}
+				return platformToastFailedMsg{Toast: toast}
+			}
+		}
+		return m.add(msg.Toast)
+	case platformToastFailedMsg:
+		return m.add(msg.Toast)
+	case DismissToastMsg:
+		for i, t := range m.toasts {
+			if t.ID == msg.ID {
+				m.toasts = append(m.toasts[:i], m.toasts[i+1:]...)
+				return m.showQueued()
+			}
+		}
+	}
+	return nil
+}
+
+// Toasts returns the toasts currently shown, newest first.
+func (m *ToastManager) Toasts() []ToastConfig {
+	var shown []ToastConfig
+	for i := len(m.toasts) - 1; i >= 0; i-- {
+		if m.toasts[i].Visible {
+			shown = append(shown, m.toasts[i].Config)
+		}
+	}
+	return shown
+}
+
+// Render renders the visible toasts stacked in a notification container.
+func (m *ToastManager) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	var toasts []masc.MarkupOrChild
+	toasts = append(toasts, masc.Markup(masc.Class("slds-notify_container")))
+	for i := len(m.toasts) - 1; i >= 0; i-- {
+		t := m.toasts[i]
+		if !t.Visible {
+			continue
+		}
+		id := t.ID
+		toasts = append(toasts, toastNotification(t.Config.Variant, t.Config.Title, t.Config.Message,
+			toastActionLink(t.Config.Action, id, send),
+			func(e *masc.Event) { send(DismissToastMsg{ID: id}) },
+		))
+	}
+	if len(toasts) == 1 {
+		return nil
+	}
+	return elem.Div(toasts...)
+}
+
+// add queues toast and shows it if there is room.
+func (m *ToastManager) add(toast ToastConfig) masc.Cmd {
+	m.nextID++
+	m.toasts = append(m.toasts, activeToast{ID: m.nextID, Config: toast})
+	return m.showQueued()
+}
+
+// showQueued shows queued toasts while there is room, returning the
+// commands that dismiss them.
+func (m *ToastManager) showQueued() masc.Cmd {
+	maxVisible := m.MaxVisible
+	if maxVisible <= 0 {
+		maxVisible = 3
+	}
+	visible := 0
+	for _, t := range m.toasts {
+		if t.Visible {
+			visible++
+		}
+	}
+	var cmds []masc.Cmd
+	for i := range m.toasts {
+		if visible >= maxVisible {
+			break
+		}
+		if m.toasts[i].Visible {
+			continue
+		}
+		m.toasts[i].Visible = true
+		visible++
+		if d := m.duration(m.toasts[i].Config); d > 0 {
+			id := m.toasts[i].ID
+			cmds = append(cmds, masc.Tick(d, func(time.Time) masc.Msg {
+				return DismissToastMsg{ID: id}
+			}))
+		}
+	}
+	return masc.Batch(cmds...)
+}
+
+// duration returns how long toast stays open, or a negative duration if it
+// stays until closed.
+func (m *ToastManager) duration(toast ToastConfig) time.Duration {
+	if toast.Duration != 0 {
+		return toast.Duration
+	}
+	if d, ok := m.Durations[toast.Variant]; ok {
+		return d
+	}
+	if d, ok := DefaultToastDurations[toast.Variant]; ok {
+		return d
+	}
+	return DefaultToastDurations[VariantInfo]
+}
+
+// platformToast converts toast for Lightning's toast service.
+func platformToast(toast ToastConfig) api.PlatformToast {
+	p := api.PlatformToast{
+		Title:   toast.Title,
+		Message: toast.Message,
+		Mode:    "dismissible",
+	}
+	switch toast.Variant {
+	case VariantSuccess:
+		p.Variant = "success"
+	case VariantError:
+		p.Variant = "error"
+	case VariantWarning:
+		p.Variant = "warning"
+	default:
+		p.Variant = "info"
+	}
+	if toast.Duration < 0 {
+		p.Mode = "sticky"
+	}
+	if toast.Action != nil {
+		p.LinkLabel = toast.Action.Label
+		p.LinkURL = toast.Action.URL
+	}
+	return p
+}
+
+// toastActionLink renders a toast's action link, which also closes the
+// toast.
+func toastActionLink(action *ToastAction, id int, send func(masc.Msg)) masc.ComponentOrHTML {
+	if action == nil {
+		return nil
+	}
+	if action.URL != "" {
+		return elem.Anchor(
+			masc.Markup(
+				masc.Attribute("href", action.URL),
+				masc.Attribute("target", "_blank"),
+			),
+			masc.Text(action.Label),
+		)
+	}
+	return elem.Anchor(
+		masc.Markup(
+			masc.Attribute("href", "javascript:void(0);"),
+			event.Click(func(e *masc.Event) {
+				if action.Msg != nil {
+					send(action.Msg)
+				}
+				send(DismissToastMsg{ID: id})
+			}),
+		),
+		masc.Text(action.Label),
+	)
+}
@@ -0,0 +1,111 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/masc"
+)
+
+// showToast queues toast on m.
+func showToast(m *ToastManager, toast ToastConfig) {
+	m.Update(ShowToastMsg{Toast: toast})
+}
+
+// TestToastManagerStacksAndQueues verifies only MaxVisible toasts are shown,
+// newest first, and dismissing one shows the next queued toast.
+func TestToastManagerStacksAndQueues(t *testing.T) {
+	m := &ToastManager{MaxVisible: 2}
+	showToast(m, ToastConfig{Variant: VariantInfo, Title: "One"})
+	showToast(m, ToastConfig{Variant: VariantInfo, Title: "Two"})
+	showToast(m, ToastConfig{Variant: VariantInfo, Title: "Three"})
+
+	shown := m.Toasts()
+	if len(shown) != 2 || shown[0].Title != "Two" || shown[1].Title != "One" {
+		t.Fatalf("expected Two and One shown, got %+v", shown)
+	}
+
+	if cmd := m.Update(DismissToastMsg{ID: 1}); cmd == nil {
+		t.Error("expected a dismiss timer for the newly shown toast")
+	}
+	shown = m.Toasts()
+	if len(shown) != 2 || shown[0].Title != "Three" || shown[1].Title != "Two" {
+		t.Errorf("expected Three and Two shown, got %+v", shown)
+	}
+}
+
+// TestToastManagerDurations verifies per-toast, per-manager and default
+// auto-dismiss delays, and that sticky toasts get no timer.
+func TestToastManagerDurations(t *testing.T) {
+	m := &ToastManager{Durations: map[ToastVariant]time.Duration{VariantSuccess: time.Second}}
+	if got := m.duration(ToastConfig{Variant: VariantSuccess}); got != time.Second {
+		t.Errorf("expected manager override, got %v", got)
+	}
+	if got := m.duration(ToastConfig{Variant: VariantError}); got != DefaultToastDurations[VariantError] {
+		t.Errorf("expected default error duration, got %v", got)
+	}
+	if got := m.duration(ToastConfig{Variant: VariantSuccess, Duration: 2 * time.Second}); got != 2*time.Second {
+		t.Errorf("expected toast override, got %v", got)
+	}
+	if cmd := m.Update(ShowToastMsg{Toast: ToastConfig{Variant: VariantError, Duration: -1}}); cmd != nil {
+		t.Error("expected no dismiss timer for a sticky toast")
+	}
+}
+
+// TestToastManagerRender verifies toasts are stacked in one container and
+// the close button and action link send their messages.
+func TestToastManagerRender(t *testing.T) {
+	type undoMsg struct{}
+	m := &ToastManager{}
+	showToast(m, ToastConfig{Variant: VariantSuccess, Title: "Saved"})
+	showToast(m, ToastConfig{Variant: VariantWarning, Title: "Deleted", Action: &ToastAction{Label: "Undo", Msg: undoMsg{}}})
+
+	var sent []masc.Msg
+	win := renderComponent(t, m.Render(func(msg masc.Msg) { sent = append(sent, msg) }))
+
+	toasts, err := win.Document().QuerySelectorAll(".slds-notify_container .slds-notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := toasts.Length(); got != 2 {
+		t.Fatalf("expected 2 toasts, got %d", got)
+	}
+
+	querySelector(t, win, ".slds-notify__message a").(html.HTMLElement).Click()
+	if len(sent) != 2 || sent[0] != (undoMsg{}) || sent[1] != (DismissToastMsg{ID: 2}) {
+		t.Errorf("expected undo and dismiss messages, got %v", sent)
+	}
+}
+
+// TestToastManagerPlatformFallback verifies toasts fall back to the app when
+// the platform toast service is unavailable.
+func TestToastManagerPlatformFallback(t *testing.T) {
+	m := &ToastManager{UsePlatform: true}
+	cmd := m.Update(ShowToastMsg{Toast: ToastConfig{Variant: VariantSuccess, Title: "Saved"}})
+	if cmd == nil {
+		t.Fatal("expected a platform toast command")
+	}
+	if len(m.Toasts()) != 0 {
+		t.Fatal("expected no in-app toast before the platform is tried")
+	}
+	m.Update(cmd())
+	if shown := m.Toasts(); len(shown) != 1 || shown[0].Title != "Saved" {
+		t.Errorf("expected fallback toast, got %+v", shown)
+	}
+}
+
+// TestPlatformToast verifies toasts are converted for Lightning's toast
+// service.
+func TestPlatformToast(t *testing.T) {
+	p := platformToast(ToastConfig{
+		Variant:  VariantError,
+		Title:    "Failed",
+		Message:  "See the log",
+		Action:   &ToastAction{Label: "Open", URL: "/lightning/r/Log__c/a01/view"},
+		Duration: -1,
+	})
+	if p.Variant != "error" || p.Mode != "sticky" || p.LinkLabel != "Open" || p.LinkURL != "/lightning/r/Log__c/a01/view" {
+		t.Errorf("unexpected platform toast: %+v", p)
+	}
+}
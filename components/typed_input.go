@@ -0,0 +1,109 @@
+package components
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// IsValidEmail reports whether s is a bare email address such as
+// "ada@example.com".
+func IsValidEmail(s string) bool {
+	s = strings.TrimSpace(s)
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ().-]+$`)
+
+// IsValidPhone reports whether s is a phone number: at least seven digits,
+// optionally separated by spaces, dots, dashes or parentheses and with a
+// leading +.
+func IsValidPhone(s string) bool {
+	s = strings.TrimSpace(s)
+	digits := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	return phonePattern.MatchString(s) && digits >= 7
+}
+
+// IsValidURL reports whether s is a web address. As in Salesforce URL
+// fields, the scheme may be left off: "example.com/docs" is valid.
+func IsValidURL(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, " \t") {
+		return false
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && strings.Contains(u.Host, ".")
+}
+
+// EmailInput renders an email input. onChange receives the text entered and
+// whether it is a valid email address.
+func EmailInput(label, value string, onChange func(value string, valid bool)) masc.ComponentOrHTML {
+	return ValidatedEmailInput(label, value, ValidationState{}, onChange)
+}
+
+// ValidatedEmailInput renders an EmailInput with validation support. Text
+// that is not an email address is flagged with an error.
+func ValidatedEmailInput(label, value string, validation ValidationState, onChange func(value string, valid bool)) masc.ComponentOrHTML {
+	return typedTextInput("email", label, value, validation, IsValidEmail, i18n.T("Thunder_Enter_Email"), onChange)
+}
+
+// PhoneInput renders a phone number input. onChange receives the text
+// entered and whether it is a valid phone number.
+func PhoneInput(label, value string, onChange func(value string, valid bool)) masc.ComponentOrHTML {
+	return ValidatedPhoneInput(label, value, ValidationState{}, onChange)
+}
+
+// ValidatedPhoneInput renders a PhoneInput with validation support. Text
+// that is not a phone number is flagged with an error.
+func ValidatedPhoneInput(label, value string, validation ValidationState, onChange func(value string, valid bool)) masc.ComponentOrHTML {
+	return typedTextInput("tel", label, value, validation, IsValidPhone, i18n.T("Thunder_Enter_Phone"), onChange)
+}
+
+// URLInput renders a web address input. onChange receives the text entered
+// and whether it is a valid URL.
+func URLInput(label, value string, onChange func(value string, valid bool)) masc.ComponentOrHTML {
+	return ValidatedURLInput(label, value, ValidationState{}, onChange)
+}
+
+// ValidatedURLInput renders a URLInput with validation support. Text that is
+// not a web address is flagged with an error.
+func ValidatedURLInput(label, value string, validation ValidationState, onChange func(value string, valid bool)) masc.ComponentOrHTML {
+	return typedTextInput("url", label, value, validation, IsValidURL, i18n.T("Thunder_Enter_URL"), onChange)
+}
+
+// typedTextInput renders a text input of the given type whose non-empty
+// value is checked by valid.
+func typedTextInput(inputType, label, value string, validation ValidationState, valid func(string) bool, message string, onChange func(string, bool)) masc.ComponentOrHTML {
+	if !IsEmptyOrWhitespace(value) && !valid(value) && !validation.HasError {
+		validation = WithError(validation, message)
+	}
+	return validatedFormElement(label, validation, elem.Input(masc.Markup(
+		masc.Class("slds-input"),
+		masc.Property("type", inputType),
+		masc.Property("value", value),
+		masc.Property("placeholder", validation.Placeholder),
+		masc.Property("required", validation.Required),
+		event.Input(func(e *masc.Event) {
+			if onChange == nil {
+				return
+			}
+			v := e.Target.Get("value").String()
+			onChange(v, IsEmptyOrWhitespace(v) || valid(v))
+		}),
+	)))
+}
@@ -0,0 +1,48 @@
+package components
+
+import "testing"
+
+// TestTypedInputValidators verifies email, phone and URL checks.
+func TestTypedInputValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid func(string) bool
+		text  string
+		want  bool
+	}{
+		{"email", IsValidEmail, "ada@example.com", true},
+		{"email display name", IsValidEmail, "Ada <ada@example.com>", false},
+		{"email missing domain", IsValidEmail, "ada@", false},
+		{"phone", IsValidPhone, "+1 (415) 555-0100", true},
+		{"phone too short", IsValidPhone, "555-01", false},
+		{"phone letters", IsValidPhone, "555-CALL-NOW", false},
+		{"url", IsValidURL, "https://example.com/docs", true},
+		{"url without scheme", IsValidURL, "example.com", true},
+		{"url other scheme", IsValidURL, "ftp://example.com", false},
+		{"url no host", IsValidURL, "not a url", false},
+	}
+	for _, tt := range tests {
+		if got := tt.valid(tt.text); got != tt.want {
+			t.Errorf("%s: valid(%q) = %v, want %v", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestValidatedEmailInputFlagsInvalidText verifies an invalid address is
+// shown with an error and the email input type.
+func TestValidatedEmailInputFlagsInvalidText(t *testing.T) {
+	win := renderComponent(t, ValidatedEmailInput("Email", "ada@", Required(), nil))
+	input := querySelector(t, win, "input.slds-input")
+	if inputType, _ := input.GetAttribute("type"); inputType != "email" {
+		t.Errorf("expected email input, got %q", inputType)
+	}
+	help := querySelector(t, win, ".slds-form-element__help")
+	if help == nil || help.TextContent() != "Enter a valid email address, like name@example.com" {
+		t.Error("expected an invalid email error")
+	}
+
+	win = renderComponent(t, ValidatedEmailInput("Email", "", Required(), nil))
+	if querySelector(t, win, ".slds-form-element__help") != nil {
+		t.Error("expected no error for a blank value")
+	}
+}
@@ -19,6 +19,9 @@ type ValidationState struct {
 	HelpText     string
 	Tooltip      string
 	Placeholder  string
+	// Field is the API name of the field being edited. It marks the form
+	// element so the links of an ErrorPopover can find it.
+	Field string
 }
 
 // Convenience constructors for common ValidationState configurations
@@ -196,7 +199,7 @@ func ValidatedTextInput(label, value string, validation ValidationState, onInput
 	}
 
 	// Build final element
-	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...))}
+	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field))}
 	args = append(args, children...)
 	return elem.Div(args...)
 }
@@ -293,7 +296,7 @@ func ValidatedTextarea(label, value string, rows int, validation ValidationState
 	}
 
 	// Build final element
-	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...))}
+	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field))}
 	args = append(args, children...)
 	return elem.Div(args...)
 }
@@ -404,7 +407,7 @@ func ValidatedSelect(label string, options []SelectOption, selected string, vali
 	}
 
 	// Build final element
-	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...))}
+	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field))}
 	args = append(args, children...)
 	return elem.Div(args...)
 }
@@ -494,7 +497,62 @@ func ValidatedDatepicker(label string, value time.Time, validation ValidationSta
 	}
 
 	// Build final element
-	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...))}
+	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field))}
 	args = append(args, children...)
 	return elem.Div(args...)
 }
+
+// validatedFormElement wraps control in an SLDS form element with a label,
+// required marker and tooltip, and the error or help text from validation.
+func validatedFormElement(label string, validation ValidationState, control masc.ComponentOrHTML) masc.ComponentOrHTML {
+	formClasses := []string{"slds-form-element", "slds-m-bottom_small"}
+	if validation.HasError {
+		formClasses = append(formClasses, "slds-has-error")
+	}
+
+	labelContent := []masc.MarkupOrChild{
+		masc.Markup(masc.Class("slds-form-element__label")),
+		masc.Text(label),
+	}
+	if validation.Required {
+		labelContent = append(labelContent,
+			elem.Span(
+				masc.Markup(masc.Class("slds-required")),
+				masc.Text(" *"),
+			),
+		)
+	}
+	if validation.Tooltip != "" {
+		labelContent = append(labelContent,
+			elem.Span(
+				masc.Markup(
+					masc.Class("slds-m-left_xx-small", "slds-text-color_weak"),
+					masc.Property("title", validation.Tooltip),
+				),
+				masc.Text("ⓘ"),
+			),
+		)
+	}
+
+	children := []masc.MarkupOrChild{
+		masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field)),
+		elem.Label(labelContent...),
+		elem.Div(
+			masc.Markup(masc.Class("slds-form-element__control")),
+			control,
+		),
+	}
+	if validation.HasError && validation.ErrorMessage != "" {
+		children = append(children, elem.Div(
+			masc.Markup(masc.Class("slds-form-element__help")),
+			masc.Text(validation.ErrorMessage),
+		))
+	}
+	if !validation.HasError && validation.HelpText != "" {
+		children = append(children, elem.Div(
+			masc.Markup(masc.Class("slds-form-element__help")),
+			masc.Text(validation.HelpText),
+		))
+	}
+	return elem.Div(children...)
+}
@@ -70,7 +70,7 @@ func ValidatedRadioButtonGroup(label, name string, options []RadioButtonOption,
 	}
 
 	// Build final element
-	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...))}
+	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field))}
 	args = append(args, children...)
 	return elem.Div(args...)
 }
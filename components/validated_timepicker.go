@@ -90,7 +90,7 @@ func ValidatedTimepicker(label, value string, validation ValidationState, onChan
 	}
 
 	// Build final element
-	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...))}
+	args := []masc.MarkupOrChild{masc.Markup(masc.Class(formClasses...), fieldTarget(validation.Field))}
 	args = append(args, children...)
 	return elem.Div(args...)
 }
@@ -0,0 +1,272 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/masc/event"
+)
+
+// VirtualScroll configures virtual scrolling for large tables and lists. Only
+// the rows inside the scrolled viewport, plus Overscan rows either side, are
+// rendered; spacer elements stand in for the rest so the scrollbar still
+// reflects the full list.
+//
+// Like the other components, the scroll position lives in the app's model:
+// store the value passed to OnScroll and pass it back as ScrollTop. OnScroll
+// is only called when the rendered range needs to change, not on every
+// scroll event.
+type VirtualScroll struct {
+	// ViewportHeight is the height of the scroll container in pixels.
+	// Defaults to 400.
+	ViewportHeight int
+	// RowHeight is the height of each row in pixels, or the estimate for
+	// rows not yet measured when OnMeasure is set. Defaults to 32.
+	RowHeight int
+	// RowHeights holds measured row heights by row index.
+	RowHeights map[int]int
+	// ScrollTop is the container's scroll offset in pixels.
+	ScrollTop int
+	// Overscan is the number of extra rows rendered above and below the
+	// viewport to avoid flicker while scrolling. Defaults to 10.
+	Overscan int
+
+	// OnScroll receives the new scroll offset.
+	OnScroll func(scrollTop int)
+	// OnMeasure enables measured row heights for rows of varying height. It
+	// receives the heights of the rendered rows, by index, when the rows are
+	// first mounted, after scrolling and after a re-render changes which rows
+	// are shown, whenever they differ from RowHeights; merge them into the
+	// map passed back.
+	OnMeasure func(heights map[int]int)
+}
+
+const (
+	defaultViewportHeight = 400
+	defaultRowHeight      = 32
+	defaultOverscan       = 10
+)
+
+// withDefaults fills in unset sizes.
+func (vs VirtualScroll) withDefaults() VirtualScroll {
+	if vs.ViewportHeight <= 0 {
+		vs.ViewportHeight = defaultViewportHeight
+	}
+	if vs.RowHeight <= 0 {
+		vs.RowHeight = defaultRowHeight
+	}
+	if vs.Overscan <= 0 {
+		vs.Overscan = defaultOverscan
+	}
+	return vs
+}
+
+// heightOf returns the measured or estimated height of row i.
+func (vs VirtualScroll) heightOf(i int) int {
+	if h, ok := vs.RowHeights[i]; ok && h > 0 {
+		return h
+	}
+	return vs.RowHeight
+}
+
+// virtualRange is the slice of rows to render and the space taken by the
+// rows before and after it.
+type virtualRange struct {
+	Start, End    int // rows [Start, End) are rendered
+	Before, After int // spacer heights in pixels
+}
+
+// visibleRange computes which of count rows to render for the scroll position
+// in vs. A range after the first row always starts on an odd row so that,
+// following the spacer row, striped tables keep their alternating colours
+// while scrolling.
+func visibleRange(vs VirtualScroll, count int) virtualRange {
+	vs = vs.withDefaults()
+	if count <= 0 {
+		return virtualRange{}
+	}
+	scrollTop := max(vs.ScrollTop, 0)
+
+	// First row whose bottom edge is below the top of the viewport.
+	first, offset := 0, 0
+	for first < count-1 && offset+vs.heightOf(first) <= scrollTop {
+		offset += vs.heightOf(first)
+		first++
+	}
+	// First row starting below the bottom of the viewport.
+	last, bottom := first, offset
+	for last < count && bottom < scrollTop+vs.ViewportHeight {
+		bottom += vs.heightOf(last)
+		last++
+	}
+
+	r := virtualRange{
+		Start: max(first-vs.Overscan, 0),
+		End:   min(last+vs.Overscan, count),
+	}
+	if r.Start > 0 && r.Start%2 == 0 {
+		r.Start--
+	}
+	for i := 0; i < r.Start; i++ {
+		r.Before += vs.heightOf(i)
+	}
+	for i := r.End; i < count; i++ {
+		r.After += vs.heightOf(i)
+	}
+	return r
+}
+
+// virtualScrollContainer wraps content in the fixed-height scroll container
+// that drives vs. onScroll, if set, also receives every scroll event.
+func virtualScrollContainer(vs VirtualScroll, count int, current virtualRange, onScroll func(*masc.Event), content ...masc.MarkupOrChild) masc.ComponentOrHTML {
+	return &virtualScrollComponent{
+		VS:       vs.withDefaults(),
+		Count:    count,
+		Current:  current,
+		OnScroll: onScroll,
+		Content:  content,
+	}
+}
+
+// virtualScrollComponent is the scroll container rendered by
+// virtualScrollContainer. It is a component so the rows can be measured once
+// they are first mounted, before any scrolling, and again when a re-render
+// brings other rows into view.
+type virtualScrollComponent struct {
+	masc.Core
+
+	VS       VirtualScroll        `masc:"prop"`
+	Count    int                  `masc:"prop"`
+	Current  virtualRange         `masc:"prop"`
+	OnScroll func(*masc.Event)    `masc:"prop"`
+	Content  []masc.MarkupOrChild `masc:"prop"`
+	root     *masc.HTML
+
+	// The row count and range of the last render, to detect re-renders that
+	// change the rendered rows.
+	renderedCount int
+	renderedRange virtualRange
+}
+
+func (c *virtualScrollComponent) Render(send func(masc.Msg)) masc.ComponentOrHTML {
+	vs, count, current, onScroll := c.VS, c.Count, c.Current, c.OnScroll
+	args := []masc.MarkupOrChild{
+		masc.Markup(
+			masc.Class("slds-scrollable"),
+			masc.Style("height", fmt.Sprintf("%dpx", vs.ViewportHeight)),
+			masc.Style("overflow-y", "auto"),
+			event.Scroll(func(e *masc.Event) {
+				if onScroll != nil {
+					onScroll(e)
+				}
+				scrollTop := e.Target.Get("scrollTop").Int()
+				if vs.OnScroll != nil {
+					next := vs
+					next.ScrollTop = scrollTop
+					if r := visibleRange(next, count); r.Start != current.Start || r.End != current.End {
+						vs.OnScroll(scrollTop)
+					}
+				}
+				c.measure(e.Target)
+			}),
+		),
+	}
+	// Rows brought into view by a re-render (the app updating ScrollTop, or
+	// rows being added or removed) are measured once the DOM is updated. The
+	// container element is reused across renders, so the node from the
+	// previous render is the one that will hold the new rows.
+	if c.root != nil && (count != c.renderedCount || current != c.renderedRange) {
+		c.measure(c.root.Node())
+	}
+	c.renderedCount, c.renderedRange = count, current
+	c.root = elem.Div(append(args, c.Content...)...)
+	return c.root
+}
+
+func (c *virtualScrollComponent) Mount() {
+	if c.root != nil {
+		c.measure(c.root.Node())
+	}
+}
+
+// measure reports the heights of the rows rendered in container that differ
+// from the known heights, when measuring is enabled.
+func (c *virtualScrollComponent) measure(container masc.SyscallJSValue) {
+	vs := c.VS
+	if vs.OnMeasure == nil {
+		return
+	}
+	queueRowMeasurement(container, func(heights map[int]int) {
+		if changed := changedHeights(vs.RowHeights, heights); len(changed) > 0 {
+			vs.OnMeasure(changed)
+		}
+	})
+}
+
+// changedHeights returns the entries of measured that differ from known.
+func changedHeights(known, measured map[int]int) map[int]int {
+	changed := make(map[int]int)
+	for i, h := range measured {
+		if h > 0 && known[i] != h {
+			changed[i] = h
+		}
+	}
+	return changed
+}
+
+// virtualIndex marks a rendered row with its index so it can be measured.
+func virtualIndex(i int) masc.Applyer {
+	return masc.Data("virtual-index", strconv.Itoa(i))
+}
+
+// spacerRow renders an empty table row of the given height standing in for
+// rows that are scrolled out of view.
+func spacerRow(height, colspan int) masc.ComponentOrHTML {
+	if height <= 0 {
+		return nil
+	}
+	return elem.TableRow(
+		masc.Markup(
+			masc.Attribute("aria-hidden", "true"),
+			masc.Style("height", fmt.Sprintf("%dpx", height)),
+		),
+		elem.TableData(masc.Markup(
+			masc.Attribute("colspan", colspan),
+			masc.Style("padding", "0"),
+			masc.Style("border", "none"),
+		)),
+	)
+}
+
+// VirtualList renders count items in a scrolling list, calling renderItem only
+// for the items in view.
+func VirtualList(vs VirtualScroll, count int, renderItem func(index int) masc.ComponentOrHTML) masc.ComponentOrHTML {
+	r := visibleRange(vs, count)
+	items := []masc.MarkupOrChild{
+		masc.Markup(masc.Attribute("role", "list")),
+	}
+	if r.Before > 0 {
+		items = append(items, elem.Div(masc.Markup(
+			masc.Attribute("aria-hidden", "true"),
+			masc.Style("height", fmt.Sprintf("%dpx", r.Before)),
+		)))
+	}
+	for i := r.Start; i < r.End; i++ {
+		items = append(items, elem.Div(
+			masc.Markup(
+				masc.Attribute("role", "listitem"),
+				virtualIndex(i),
+			),
+			renderItem(i),
+		))
+	}
+	if r.After > 0 {
+		items = append(items, elem.Div(masc.Markup(
+			masc.Attribute("aria-hidden", "true"),
+			masc.Style("height", fmt.Sprintf("%dpx", r.After)),
+		)))
+	}
+	return virtualScrollContainer(vs, count, r, nil, elem.Div(items...))
+}
@@ -0,0 +1,32 @@
+//go:build js
+
+package components
+
+import (
+	"strconv"
+	"syscall/js"
+
+	"github.com/octoberswimmer/masc"
+)
+
+// queueRowMeasurement measures the rendered rows inside container on the next
+// animation frame, once the rows for the new scroll position are in the DOM,
+// and passes their heights by row index to callback.
+func queueRowMeasurement(container js.Value, callback func(heights map[int]int)) {
+	if callback == nil || !container.Truthy() {
+		return
+	}
+	queueViewportMeasurement(func(int, int, *masc.Event) {
+		rows := container.Call("querySelectorAll", "[data-virtual-index]")
+		heights := make(map[int]int, rows.Length())
+		for i := 0; i < rows.Length(); i++ {
+			row := rows.Index(i)
+			index, err := strconv.Atoi(row.Get("dataset").Get("virtualIndex").String())
+			if err != nil {
+				continue
+			}
+			heights[index] = int(row.Call("getBoundingClientRect").Get("height").Float() + 0.5)
+		}
+		callback(heights)
+	})
+}
@@ -0,0 +1,7 @@
+//go:build !js
+
+package components
+
+import "github.com/octoberswimmer/masc"
+
+func queueRowMeasurement(container masc.SyscallJSValue, callback func(heights map[int]int)) {}
@@ -0,0 +1,142 @@
+package components
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/octoberswimmer/masc"
+)
+
+// TestVisibleRangeFixedHeights verifies the rendered range and spacer heights
+// for fixed row heights.
+func TestVisibleRangeFixedHeights(t *testing.T) {
+	vs := VirtualScroll{ViewportHeight: 100, RowHeight: 10, Overscan: 2}
+
+	got := visibleRange(vs, 1000)
+	if want := (virtualRange{Start: 0, End: 12, Before: 0, After: 9880}); got != want {
+		t.Errorf("at top: expected %+v, got %+v", want, got)
+	}
+
+	vs.ScrollTop = 505
+	got = visibleRange(vs, 1000)
+	// Row 50 is at the top of the viewport; the start moves back to an odd
+	// row so striping is preserved after the spacer row.
+	if want := (virtualRange{Start: 47, End: 63, Before: 470, After: 9370}); got != want {
+		t.Errorf("scrolled: expected %+v, got %+v", want, got)
+	}
+
+	vs.ScrollTop = 1_000_000
+	got = visibleRange(vs, 1000)
+	if got.End != 1000 || got.After != 0 {
+		t.Errorf("past the end: expected the range to end at the last row, got %+v", got)
+	}
+}
+
+// TestVisibleRangeMeasuredHeights verifies measured heights replace the
+// estimate when computing the range.
+func TestVisibleRangeMeasuredHeights(t *testing.T) {
+	vs := VirtualScroll{
+		ViewportHeight: 100,
+		RowHeight:      10,
+		Overscan:       1,
+		RowHeights:     map[int]int{0: 100, 1: 100},
+		ScrollTop:      200,
+	}
+	got := visibleRange(vs, 50)
+	if want := (virtualRange{Start: 1, End: 13, Before: 100, After: 370}); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+// TestChangedHeights verifies only new or different heights are reported.
+func TestChangedHeights(t *testing.T) {
+	got := changedHeights(map[int]int{1: 20, 2: 30}, map[int]int{1: 20, 2: 35, 3: 40, 4: 0})
+	if want := map[int]int{2: 35, 3: 40}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestVirtualListRendersVisibleItems verifies only the items in view are
+// rendered.
+func TestVirtualListRendersVisibleItems(t *testing.T) {
+	var rendered int
+	win := renderComponent(t, VirtualList(VirtualScroll{ViewportHeight: 100, RowHeight: 20, Overscan: 1}, 5000, func(i int) masc.ComponentOrHTML {
+		rendered++
+		return masc.Text(fmt.Sprintf("Item %d", i))
+	}))
+
+	items, err := win.Document().QuerySelectorAll("[role=listitem]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items.Length() != 6 || rendered != 6 {
+		t.Errorf("expected 6 items rendered, got %d in the DOM and %d renders", items.Length(), rendered)
+	}
+}
+
+// TestVirtualDataTableWithMenuRendersSpacers verifies a scrolled virtual table
+// renders the visible rows between spacer rows.
+func TestVirtualDataTableWithMenuRendersSpacers(t *testing.T) {
+	columns := []DataTableColumn{{Label: "Name", FieldName: "Name"}}
+	var rows []map[string]interface{}
+	for i := 0; i < 5000; i++ {
+		rows = append(rows, map[string]interface{}{"Name": fmt.Sprintf("Row %d", i)})
+	}
+	win := renderComponent(t, VirtualDataTableWithMenu(columns, rows, nil, VirtualScroll{
+		ViewportHeight: 320,
+		RowHeight:      32,
+		ScrollTop:      3200,
+	}))
+
+	dataRows, err := win.Document().QuerySelectorAll("tbody tr[data-virtual-index]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dataRows.Length(); got != 31 {
+		t.Errorf("expected 31 rendered rows, got %d", got)
+	}
+	first, err := win.Document().QuerySelector("tbody tr[data-virtual-index]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := first.GetAttribute("data-virtual-index"); got != "89" {
+		t.Errorf("expected the first rendered row to be 89, got %s", got)
+	}
+	spacers, err := win.Document().QuerySelectorAll("tbody tr[aria-hidden=true]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := spacers.Length(); got != 2 {
+		t.Errorf("expected spacer rows above and below, got %d", got)
+	}
+}
+
+// TestVirtualAdvancedDataTableMarksRowIndexes verifies a virtualized advanced
+// table tags its rendered rows with their indexes for measuring.
+func TestVirtualAdvancedDataTableMarksRowIndexes(t *testing.T) {
+	var rows []map[string]interface{}
+	for i := 0; i < 5000; i++ {
+		rows = append(rows, map[string]interface{}{"Id": fmt.Sprint(i), "Name": fmt.Sprintf("Row %d", i)})
+	}
+	win := renderComponent(t, AdvancedDataTable(AdvancedDataTableProps{
+		Columns:    []DataTableColumn{{Label: "Name", FieldName: "Name"}},
+		Rows:       rows,
+		Virtualize: &VirtualScroll{ViewportHeight: 320, RowHeight: 32, ScrollTop: 3200},
+	}))
+
+	dataRows, err := win.Document().QuerySelectorAll("tbody tr[data-virtual-index]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dataRows.Length(); got != 31 {
+		t.Errorf("expected 31 rendered rows, got %d", got)
+	}
+	first, err := win.Document().QuerySelector("tbody tr[data-virtual-index]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := first.GetAttribute("data-virtual-index"); got != "89" {
+		t.Errorf("expected the first rendered row to be 89, got %s", got)
+	}
+}
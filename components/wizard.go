@@ -0,0 +1,141 @@
+package components
+
+import (
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/masc/elem"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// WizardStep is one step of a Wizard.
+type WizardStep struct {
+	Label   string
+	Content masc.ComponentOrHTML
+	// Validate runs when Next or Submit is clicked on the step and reports
+	// whether the user may go on. It runs in the click handler, like
+	// RecordForm's validation, so it should only check and mark fields,
+	// e.g. with validation.Form.SubmitFields. A nil Validate always passes.
+	Validate func() bool
+}
+
+// WizardProps configures a Wizard. Like the other components it keeps no
+// state: the app stores the current step and updates it from OnStep.
+type WizardProps struct {
+	Steps []WizardStep
+	// Current is the index of the step shown.
+	Current int
+	// SubmitLabel labels the last step's button. Defaults to "Submit".
+	SubmitLabel string
+	// IsSubmitting shows a spinner on the Submit button.
+	IsSubmitting bool
+
+	// OnStep receives the index of the step to show: the next step once the
+	// current one validates, the previous step, or a completed step clicked
+	// in the progress indicator.
+	OnStep func(index int)
+	// OnInvalid receives the index of the current step when its Validate
+	// fails, so the app can re-render to show the errors.
+	OnInvalid func(index int)
+	// OnSubmit is called when the last step validates.
+	OnSubmit func()
+	// OnCancel adds a Cancel button when set.
+	OnCancel func()
+}
+
+// Wizard renders a multi-step flow: a horizontal progress indicator, the
+// current step's heading and content, and Previous, Next and Submit buttons.
+// Next and Submit only go on once the step's Validate passes; going back
+// needs no validation.
+func Wizard(props WizardProps) masc.ComponentOrHTML {
+	if len(props.Steps) == 0 {
+		return elem.Div()
+	}
+	current := props.Current
+	if current < 0 {
+		current = 0
+	}
+	if current >= len(props.Steps) {
+		current = len(props.Steps) - 1
+	}
+	step := props.Steps[current]
+	last := current == len(props.Steps)-1
+
+	progress := make([]ProgressStep, len(props.Steps))
+	for i, s := range props.Steps {
+		progress[i] = ProgressStep{Name: s.Label, IsActive: i == current, IsCompleted: i < current}
+	}
+	goTo := func(index int) {
+		if props.OnStep != nil {
+			props.OnStep(index)
+		}
+	}
+	proceed := func() {
+		if step.Validate != nil && !step.Validate() {
+			if props.OnInvalid != nil {
+				props.OnInvalid(current)
+			}
+			return
+		}
+		if last {
+			if props.OnSubmit != nil {
+				props.OnSubmit()
+			}
+			return
+		}
+		goTo(current + 1)
+	}
+
+	var left, right []masc.ComponentOrHTML
+	if props.OnCancel != nil {
+		left = append(left, Button(i18n.T("Thunder_Cancel"), VariantNeutral, func(*masc.Event) { props.OnCancel() }))
+	}
+	if current > 0 {
+		previous := Button(i18n.T("Thunder_Previous"), VariantNeutral, func(*masc.Event) { goTo(current - 1) })
+		if props.IsSubmitting {
+			previous = DisabledButton(i18n.T("Thunder_Previous"), VariantNeutral)
+		}
+		right = append(right, previous)
+	}
+	switch {
+	case !last:
+		right = append(right, Button(i18n.T("Thunder_Next"), VariantBrand, func(*masc.Event) { proceed() }))
+	case props.IsSubmitting:
+		right = append(right, LoadingButton(wizardSubmitLabel(props), VariantBrand))
+	default:
+		right = append(right, Button(wizardSubmitLabel(props), VariantBrand, func(*masc.Event) { proceed() }))
+	}
+
+	return elem.Div(
+		elem.Div(
+			masc.Markup(masc.Class("slds-p-horizontal_large", "slds-p-vertical_medium")),
+			HorizontalProgress(progress, goTo),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-m-bottom_medium")),
+			elem.Paragraph(
+				masc.Markup(masc.Class("slds-text-title")),
+				masc.Text(i18n.T("Thunder_Step_Of", current+1, len(props.Steps))),
+			),
+			elem.Heading2(
+				masc.Markup(masc.Class("slds-text-heading_medium")),
+				masc.Text(step.Label),
+			),
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-m-bottom_medium")),
+			step.Content,
+		),
+		elem.Div(
+			masc.Markup(masc.Class("slds-grid", "slds-grid_align-spread", "slds-border_top", "slds-p-top_medium")),
+			ButtonGroupSpaced(left...),
+			ButtonGroupSpaced(right...),
+		),
+	)
+}
+
+// wizardSubmitLabel returns the label of a Wizard's Submit button.
+func wizardSubmitLabel(props WizardProps) string {
+	if props.SubmitLabel != "" {
+		return props.SubmitLabel
+	}
+	return i18n.T("Thunder_Submit")
+}
@@ -0,0 +1,80 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/octoberswimmer/masc"
+)
+
+func wizardSteps(valid *bool) []WizardStep {
+	return []WizardStep{
+		{Label: "Contact", Content: masc.Text("contact"), Validate: func() bool { return *valid }},
+		{Label: "Details", Content: masc.Text("details")},
+		{Label: "Review", Content: masc.Text("review")},
+	}
+}
+
+// TestWizardGatesNextOnValidation verifies Next only moves on once the step
+// validates.
+func TestWizardGatesNextOnValidation(t *testing.T) {
+	valid := false
+	step, invalid := -1, -1
+	win := renderComponent(t, Wizard(WizardProps{
+		Steps:     wizardSteps(&valid),
+		OnStep:    func(i int) { step = i },
+		OnInvalid: func(i int) { invalid = i },
+	}))
+
+	if heading := querySelector(t, win, "h2"); heading == nil || heading.TextContent() != "Contact" {
+		t.Error("expected the first step's heading")
+	}
+	if prev := querySelector(t, win, ".slds-button_neutral"); prev != nil {
+		t.Error("expected no Previous button on the first step")
+	}
+	next := querySelector(t, win, ".slds-button_brand").(html.HTMLElement)
+	next.Click()
+	if step != -1 || invalid != 0 {
+		t.Errorf("expected the invalid step to stay put, got step %d invalid %d", step, invalid)
+	}
+	valid = true
+	next.Click()
+	if step != 1 {
+		t.Errorf("expected to move to step 1, got %d", step)
+	}
+}
+
+// TestWizardLastStepSubmits verifies the last step submits and earlier steps
+// can be revisited.
+func TestWizardLastStepSubmits(t *testing.T) {
+	valid := true
+	submitted := false
+	step := -1
+	win := renderComponent(t, Wizard(WizardProps{
+		Steps:       wizardSteps(&valid),
+		Current:     2,
+		SubmitLabel: "Create Case",
+		OnStep:      func(i int) { step = i },
+		OnSubmit:    func() { submitted = true },
+	}))
+
+	if text := querySelector(t, win, ".slds-text-title"); text == nil || text.TextContent() != "Step 3 of 3" {
+		t.Error("expected a step counter")
+	}
+	submit := querySelector(t, win, ".slds-button_brand")
+	if submit == nil || submit.TextContent() != "Create Case" {
+		t.Fatal("expected a Create Case button")
+	}
+	submit.(html.HTMLElement).Click()
+	if !submitted {
+		t.Error("expected the wizard to submit")
+	}
+	querySelector(t, win, ".slds-button_neutral").(html.HTMLElement).Click()
+	if step != 1 {
+		t.Errorf("expected Previous to go to step 1, got %d", step)
+	}
+	querySelector(t, win, ".slds-progress__item.slds-is-completed .slds-progress__marker").(html.HTMLElement).Click()
+	if step != 0 {
+		t.Errorf("expected clicking a completed step to go to it, got %d", step)
+	}
+}
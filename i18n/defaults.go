@@ -0,0 +1,124 @@
+package i18n
+
+// Defaults holds the English text of the messages built into Thunder's
+// components. Apps translate them by registering bundles with the same keys,
+// and admins override them with Custom Labels of the same names.
+var Defaults = Bundle{
+	// Buttons and controls
+	"Thunder_Actions":                "Actions",
+	"Thunder_Show_Actions":           "Show Actions",
+	"Thunder_Choose_A_Row":           "Choose a row",
+	"Thunder_Sort_By":                "Sort by:",
+	"Thunder_Loading":                "Loading",
+	"Thunder_Checking":               "Checking...",
+	"Thunder_Save":                   "Save",
+	"Thunder_Saving":                 "Saving",
+	"Thunder_Edit":                   "Edit {0}",
+	"Thunder_Cancel":                 "Cancel",
+	"Thunder_Confirm":                "Confirm",
+	"Thunder_OK":                     "OK",
+	"Thunder_Close":                  "Close",
+	"Thunder_Close_Dialog":           "Close dialog",
+	"Thunder_None":                   "--None--",
+	"Thunder_Select_An_Option":       "Select an Option",
+	"Thunder_Search_For":             "Search {0}...",
+	"Thunder_No_Results_For":         "No results for \"{0}\"",
+	"Thunder_Remove_Selected_Option": "Remove selected option",
+	"Thunder_Previous":               "Previous",
+	"Thunder_Next":                   "Next",
+	"Thunder_Submit":                 "Submit",
+	"Thunder_Load_More":              "Load More",
+	"Thunder_Yes":                    "Yes",
+	"Thunder_No":                     "No",
+	"Thunder_Add_Value":              "Add \"{0}\"",
+	"Thunder_Object":                 "Object",
+	"Thunder_Remove":                 "Remove",
+	"Thunder_Breadcrumbs":            "Breadcrumbs",
+	"Thunder_Date_Of":                "{0} Date",
+	"Thunder_Time_Of":                "{0} Time",
+
+	// Tables and multi-value selection
+	"Thunder_Select_All_Rows":   "Select all rows",
+	"Thunder_Select_Item":       "Select item {0}",
+	"Thunder_Choose_Item":       "Choose item {0}",
+	"Thunder_Column_Width":      "{0} column width",
+	"Thunder_Select_Options":    "Select Options",
+	"Thunder_Options_Selected":  "{0} options selected",
+	"Thunder_Selected_Options":  "Selected Options",
+	"Thunder_Move_Selection_To": "Move selection to {0}",
+
+	// Error popover
+	"Thunder_Resolve_Error":  "Resolve error",
+	"Thunder_Resolve_Errors": "Resolve {0} errors",
+
+	// Path and wizard
+	"Thunder_Current_Stage":       "Current Stage",
+	"Thunder_Stage_Complete":      "Stage Complete",
+	"Thunder_Mark_Current_Stage":  "Mark as Current Stage",
+	"Thunder_Mark_Stage_Complete": "Mark Stage as Complete",
+	"Thunder_Step_Of":             "Step {0} of {1}",
+	"Thunder_Step_Completed":      "{0} - Completed",
+	"Thunder_Step_Active":         "{0} - Active",
+
+	// Rich text editor
+	"Thunder_Format_Text":    "Format text",
+	"Thunder_Format_Body":    "Format body",
+	"Thunder_Compose_Text":   "Compose text",
+	"Thunder_Bold":           "Bold",
+	"Thunder_Italic":         "Italic",
+	"Thunder_Underline":      "Underline",
+	"Thunder_Bulleted_List":  "Bulleted List",
+	"Thunder_Numbered_List":  "Numbered List",
+	"Thunder_Link":           "Link",
+	"Thunder_Remove_Link":    "Remove Link",
+	"Thunder_Enter_Link_URL": "Enter the link URL",
+
+	// File selector
+	"Thunder_Upload_Files":    "Upload Files",
+	"Thunder_Or_Drop_Files":   "or Drop Files",
+	"Thunder_File_Too_Large":  "{0} is larger than {1}",
+	"Thunder_File_Unreadable": "{0} could not be read",
+
+	// Related files
+	"Thunder_Files":           "Files",
+	"Thunder_Files_Count":     "{0} ({1})",
+	"Thunder_No_Files":        "No files",
+	"Thunder_Download_File":   "Download {0}",
+	"Thunder_Close_Preview":   "Close preview",
+	"Thunder_No_Preview":      "No preview available",
+	"Thunder_Preview_Blocked": "This file can't be previewed here",
+
+	// Application error modal
+	"Thunder_Application_Error": "Application Error",
+	"Thunder_Unexpected_Error":  "An unexpected error occurred:",
+	"Thunder_Stack_Trace":       "Stack Trace",
+
+	// Validation errors naming the field
+	"Thunder_Required":           "{0} is required",
+	"Thunder_Min_Length":         "{0} must be at least {1} characters",
+	"Thunder_Max_Length":         "{0} must be {1} characters or fewer",
+	"Thunder_Bad_Format":         "{0} is not in the expected format",
+	"Thunder_Bad_Email":          "{0} must be a valid email address",
+	"Thunder_Bad_Phone":          "{0} must be a valid phone number",
+	"Thunder_Bad_URL":            "{0} must be a valid URL",
+	"Thunder_Bad_Number":         "{0} must be a number",
+	"Thunder_Bad_Whole_Number":   "{0} must be a whole number",
+	"Thunder_Bad_Date":           "{0} must be a valid date",
+	"Thunder_Out_Of_Range":       "{0} must be between {1} and {2}",
+	"Thunder_Too_Early":          "{0} must be on or after {1}",
+	"Thunder_Too_Late":           "{0} must be on or before {1}",
+	"Thunder_Must_Match":         "{0} must match {1}",
+	"Thunder_Max_Decimal_Places": "{0} can have at most {1} decimal places",
+	"Thunder_Max_Integer_Digits": "{0} can have at most {1} digits before the decimal point",
+
+	// Input errors
+	"Thunder_Enter_Number":             "Enter a valid number",
+	"Thunder_Enter_Whole_Number":       "Enter a whole number",
+	"Thunder_Enter_Max_Decimal_Places": "Enter a number with at most {0} decimal places",
+	"Thunder_Enter_Max_Integer_Digits": "Enter a number with at most {0} digits before the decimal point",
+	"Thunder_Enter_Min":                "Enter a number no less than {0}",
+	"Thunder_Enter_Max":                "Enter a number no greater than {0}",
+	"Thunder_Enter_Email":              "Enter a valid email address, like name@example.com",
+	"Thunder_Enter_Phone":              "Enter a valid phone number",
+	"Thunder_Enter_URL":                "Enter a valid URL, like example.com",
+}
@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// dateLayouts holds the short date formats of common Salesforce locales, by
+// full locale and by language. Others use ISO dates.
+var dateLayouts = map[string]string{
+	"en_US": "1/2/2006",
+	"en_CA": "2006-01-02",
+	"en":    "02/01/2006",
+	"de":    "2.1.2006",
+	"fr":    "02/01/2006",
+	"fr_CA": "2006-01-02",
+	"es":    "2/1/2006",
+	"it":    "2/1/2006",
+	"pt":    "02/01/2006",
+	"nl":    "2-1-2006",
+	"da":    "02.01.2006",
+	"nb":    "02.01.2006",
+	"no":    "02.01.2006",
+	"fi":    "2.1.2006",
+	"pl":    "2.01.2006",
+	"ru":    "02.01.2006",
+	"tr":    "2.01.2006",
+	"ja":    "2006/01/02",
+	"zh":    "2006/1/2",
+	"ko":    "2006. 1. 2.",
+}
+
+// twelveHourLocales hold the locales that write times with AM and PM.
+var twelveHourLocales = map[string]bool{
+	"en_US": true,
+	"en_CA": true,
+	"en_AU": true,
+	"en_NZ": true,
+	"en_IN": true,
+	"en_PH": true,
+	"es_MX": true,
+}
+
+// The Format functions write t as it is, without converting it to another
+// time zone; show an instant in the user's time zone with
+// FormatDateTime(t.In(i18n.Location())).
+
+// FormatDate writes t's date in the user's locale, e.g. 3/14/2025 in en_US
+// and 14.3.2025 in de_DE.
+func FormatDate(t time.Time) string {
+	return t.Format(dateLayout(Locale()))
+}
+
+// FormatTime writes t's time of day in the user's locale, e.g. 1:30 PM in
+// en_US and 13:30 in de_DE.
+func FormatTime(t time.Time) string {
+	return t.Format(timeLayout(Locale()))
+}
+
+// FormatDateTime writes t's date and time in the user's locale, e.g.
+// 3/14/2025 1:30 PM in en_US.
+func FormatDateTime(t time.Time) string {
+	l := Locale()
+	return t.Format(dateLayout(l) + " " + timeLayout(l))
+}
+
+// dateLayout returns the date layout for locale.
+func dateLayout(locale string) string {
+	if layout, ok := dateLayouts[locale]; ok {
+		return layout
+	}
+	lang, _, _ := strings.Cut(locale, "_")
+	if layout, ok := dateLayouts[lang]; ok {
+		return layout
+	}
+	return "2006-01-02"
+}
+
+// timeLayout returns the time layout for locale.
+func timeLayout(locale string) string {
+	if twelveHourLocales[locale] {
+		return "3:04 PM"
+	}
+	return "15:04"
+}
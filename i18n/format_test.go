@@ -0,0 +1,29 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFormatDateTimeByLocale verifies dates and times are written in the
+// locale's order and clock.
+func TestFormatDateTimeByLocale(t *testing.T) {
+	reset(t)
+	tm := time.Date(2025, 3, 14, 13, 30, 0, 0, time.UTC)
+	tests := []struct{ locale, date, dateTime string }{
+		{"en_US", "3/14/2025", "3/14/2025 1:30 PM"},
+		{"en_GB", "14/03/2025", "14/03/2025 13:30"},
+		{"de_DE", "14.3.2025", "14.3.2025 13:30"},
+		{"ja_JP", "2025/03/14", "2025/03/14 13:30"},
+		{"xx_XX", "2025-03-14", "2025-03-14 13:30"},
+	}
+	for _, tt := range tests {
+		SetLocale(tt.locale)
+		if got := FormatDate(tm); got != tt.date {
+			t.Errorf("%s: FormatDate() = %q; want %q", tt.locale, got, tt.date)
+		}
+		if got := FormatDateTime(tm); got != tt.dateTime {
+			t.Errorf("%s: FormatDateTime() = %q; want %q", tt.locale, got, tt.dateTime)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package i18n translates the text of Thunder apps and components into the
+// running user's language and formats dates for their locale.
+//
+// T looks a message up by key in, from first to last: Custom Labels
+// translated into the user's language (see Load), bundles registered for the
+// user's language and then its base language, the Custom Labels' default
+// values, bundles registered for English, and Thunder's built-in Defaults.
+// A key found nowhere is returned unchanged.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octoberswimmer/thunder/api"
+)
+
+// Bundle maps message keys to messages. Messages may refer to T's arguments
+// as {0}, {1} and so on, as Custom Labels do.
+type Bundle map[string]string
+
+var (
+	mu       sync.RWMutex
+	language = "en_US"
+	locale   = "en_US"
+	currency = "USD"
+	location = time.Local
+	bundles  = map[string]Bundle{}
+	// labels holds Custom Labels translated into language; labelDefaults
+	// holds their default values.
+	labels        = Bundle{}
+	labelDefaults = Bundle{}
+)
+
+// T returns the message for key in the user's language, with {0}, {1}, ...
+// replaced by args.
+func T(key string, args ...interface{}) string {
+	mu.RLock()
+	msg, ok := lookup(key)
+	mu.RUnlock()
+	if !ok {
+		msg = key
+	}
+	return format(msg, args)
+}
+
+// lookup finds the message for key. mu must be held.
+func lookup(key string) (string, bool) {
+	base, _, _ := strings.Cut(language, "_")
+	for _, b := range []Bundle{labels, bundles[language], bundles[base], labelDefaults, bundles["en_US"], bundles["en"], Defaults} {
+		if msg, ok := b[key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// format replaces the {N} placeholders in msg with args.
+func format(msg string, args []interface{}) string {
+	if len(args) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+	pairs := make([]string, 0, 2*len(args))
+	for i, arg := range args {
+		pairs = append(pairs, "{"+strconv.Itoa(i)+"}", fmt.Sprint(arg))
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// Register adds the messages of bundle to those for lang, a Salesforce
+// language code such as "de" or "pt_BR". Later registrations override
+// earlier ones.
+func Register(lang string, bundle Bundle) {
+	mu.Lock()
+	defer mu.Unlock()
+	lang = normalize(lang)
+	if bundles[lang] == nil {
+		bundles[lang] = Bundle{}
+	}
+	for key, msg := range bundle {
+		bundles[lang][key] = msg
+	}
+}
+
+// RegisterFS registers the bundles stored as JSON objects in dir of fsys,
+// one file per language named after it, e.g. labels/de.json. Apps usually
+// embed the directory:
+//
+//	//go:embed labels/*.json
+//	var labelFS embed.FS
+//
+//	i18n.RegisterFS(labelFS, "labels")
+func RegisterFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		var bundle Bundle
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		Register(strings.TrimSuffix(path.Base(file), ".json"), bundle)
+	}
+	return nil
+}
+
+// Load reads the running user's language, locale, currency and time zone
+// and loads Thunder's Custom Labels (named after the keys of Defaults) and
+// the named app labels in the user's language. Call it before the first
+// render, or re-render once it returns.
+func Load(names ...string) error {
+	info, err := api.GetUserInfo()
+	if err != nil {
+		return err
+	}
+	loc, err := info.Location()
+	mu.Lock()
+	if info.Language != "" {
+		setLanguage(info.Language)
+	}
+	if info.Locale != "" {
+		locale = normalize(info.Locale)
+	}
+	if info.Currency != "" {
+		currency = info.Currency
+	}
+	location = loc
+	mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return LoadLabels(names...)
+}
+
+// LoadLabels loads the named Custom Labels, along with those overriding
+// Thunder's Defaults, in the current language.
+func LoadLabels(names ...string) error {
+	all := make([]string, 0, len(Defaults)+len(names))
+	for key := range Defaults {
+		all = append(all, key)
+	}
+	all = append(all, names...)
+	result, err := api.GetLabels(Language(), all...)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for key, msg := range result.Translated {
+		labels[key] = msg
+	}
+	for key, msg := range result.Defaults {
+		labelDefaults[key] = msg
+	}
+	return nil
+}
+
+// SetLanguage sets the language messages are shown in, e.g. "de". Custom
+// Labels loaded for the previous language are dropped.
+func SetLanguage(lang string) {
+	mu.Lock()
+	defer mu.Unlock()
+	setLanguage(lang)
+}
+
+func setLanguage(lang string) {
+	lang = normalize(lang)
+	if lang != language {
+		labels = Bundle{}
+	}
+	language = lang
+}
+
+// Language returns the language messages are shown in. It defaults to
+// "en_US".
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return language
+}
+
+// SetLocale sets the locale dates and numbers are formatted for, e.g.
+// "de_DE".
+func SetLocale(l string) {
+	mu.Lock()
+	defer mu.Unlock()
+	locale = normalize(l)
+}
+
+// Locale returns the locale dates and numbers are formatted for. It
+// defaults to "en_US".
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// SetCurrency sets the ISO code of the user's currency, e.g. "EUR".
+func SetCurrency(code string) {
+	mu.Lock()
+	defer mu.Unlock()
+	currency = code
+}
+
+// Currency returns the ISO code of the user's currency. It defaults to
+// "USD".
+func Currency() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return currency
+}
+
+// SetLocation sets the user's time zone.
+func SetLocation(loc *time.Location) {
+	mu.Lock()
+	defer mu.Unlock()
+	location = loc
+}
+
+// Location returns the user's time zone. It defaults to time.Local.
+func Location() *time.Location {
+	mu.RLock()
+	defer mu.RUnlock()
+	return location
+}
+
+// normalize writes a language or locale code in Salesforce's form, e.g.
+// "pt-br" as "pt_BR".
+func normalize(code string) string {
+	lang, region, ok := strings.Cut(strings.ReplaceAll(code, "-", "_"), "_")
+	if !ok {
+		return strings.ToLower(lang)
+	}
+	if len(region) == 2 {
+		region = strings.ToUpper(region)
+	}
+	return strings.ToLower(lang) + "_" + region
+}
@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// reset restores the package defaults when the test ends.
+func reset(t *testing.T) {
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		language, locale, currency, location = "en_US", "en_US", "USD", time.Local
+		bundles = map[string]Bundle{}
+		labels, labelDefaults = Bundle{}, Bundle{}
+	})
+}
+
+// TestTFallsBackThroughBundles verifies messages are found in the user's
+// language, then its base language, then English and the built-in defaults.
+func TestTFallsBackThroughBundles(t *testing.T) {
+	reset(t)
+	Register("en", Bundle{"Greeting": "Hello {0}", "Farewell": "Goodbye"})
+	Register("pt", Bundle{"Greeting": "Olá {0}"})
+	Register("pt-br", Bundle{"Thunder_Save": "Salvar"})
+	SetLanguage("pt_BR")
+
+	tests := []struct{ key, want string }{
+		{"Greeting", "Olá Ana"},
+		{"Farewell", "Goodbye"},
+		{"Thunder_Save", "Salvar"},
+		{"Thunder_Cancel", "Cancel"},
+		{"Missing", "Missing"},
+	}
+	for _, tt := range tests {
+		if got := T(tt.key, "Ana"); got != tt.want {
+			t.Errorf("T(%q) = %q; want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+// TestTPrefersTranslatedLabels verifies Custom Labels translated into the
+// user's language win over bundles, while their default values only win over
+// English.
+func TestTPrefersTranslatedLabels(t *testing.T) {
+	reset(t)
+	Register("de", Bundle{"Thunder_Save": "Sichern", "Thunder_Cancel": "Abbrechen"})
+	SetLanguage("de")
+	mu.Lock()
+	labels["Thunder_Save"] = "Speichern"
+	labelDefaults["Thunder_Cancel"] = "Cancel it"
+	labelDefaults["Thunder_Close"] = "Close it"
+	mu.Unlock()
+
+	for key, want := range map[string]string{
+		"Thunder_Save":   "Speichern",
+		"Thunder_Cancel": "Abbrechen",
+		"Thunder_Close":  "Close it",
+	} {
+		if got := T(key); got != want {
+			t.Errorf("T(%q) = %q; want %q", key, got, want)
+		}
+	}
+
+	SetLanguage("fr")
+	if got := T("Thunder_Save"); got != "Save" {
+		t.Errorf("expected labels for the old language to be dropped, got %q", got)
+	}
+}
+
+// TestRegisterFS verifies bundles are read from JSON files named after their
+// language.
+func TestRegisterFS(t *testing.T) {
+	reset(t)
+	fsys := fstest.MapFS{
+		"labels/de.json":    {Data: []byte(`{"Greeting": "Hallo {0}"}`)},
+		"labels/en_US.json": {Data: []byte(`{"Greeting": "Hello {0}"}`)},
+		"labels/notes.txt":  {Data: []byte("ignored")},
+	}
+	if err := RegisterFS(fsys, "labels"); err != nil {
+		t.Fatal(err)
+	}
+	SetLanguage("de_DE")
+	if got := T("Greeting", "Welt"); got != "Hallo Welt" {
+		t.Errorf("expected the German bundle, got %q", got)
+	}
+
+	bad := fstest.MapFS{"labels/fr.json": {Data: []byte(`{`)}}
+	if err := RegisterFS(bad, "labels"); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+// TestLoadReadsUserSettings verifies Load takes the language, locale,
+// currency and time zone from the running user.
+func TestLoadReadsUserSettings(t *testing.T) {
+	reset(t)
+	SetLanguage("de")
+	if err := Load("Greeting"); err != nil {
+		t.Fatal(err)
+	}
+	if Language() != "en_US" || Locale() != "en_US" || Currency() != "USD" || Location() != time.UTC {
+		t.Errorf("unexpected settings %s %s %s %v", Language(), Locale(), Currency(), Location())
+	}
+}
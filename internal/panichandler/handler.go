@@ -8,6 +8,7 @@ import (
 	"github.com/octoberswimmer/masc"
 	"github.com/octoberswimmer/masc/elem"
 	"github.com/octoberswimmer/thunder/components"
+	"github.com/octoberswimmer/thunder/i18n"
 	"github.com/octoberswimmer/thunder/internal/runtime"
 )
 
@@ -29,11 +30,11 @@ func (m *panicModel) Render(send func(masc.Msg)) masc.ComponentOrHTML {
 	// Wrap the modal in a div since Modal returns a List
 	return elem.Div(
 		components.Modal(
-			"Application Error",
+			i18n.T("Thunder_Application_Error"),
 			elem.Div(
 				elem.Div(
 					masc.Markup(masc.Class("slds-text-color_error", "slds-m-bottom_small")),
-					elem.Strong(masc.Text("An unexpected error occurred:")),
+					elem.Strong(masc.Text(i18n.T("Thunder_Unexpected_Error"))),
 				),
 				elem.Div(
 					masc.Markup(masc.Class("slds-box", "slds-theme_shade", "slds-m-bottom_small")),
@@ -49,7 +50,7 @@ func (m *panicModel) Render(send func(masc.Msg)) masc.ComponentOrHTML {
 				elem.Details(
 					elem.Summary(
 						masc.Markup(masc.Class("slds-text-link")),
-						masc.Text("Stack Trace"),
+						masc.Text(i18n.T("Thunder_Stack_Trace")),
 					),
 					elem.Code(
 						masc.Markup(
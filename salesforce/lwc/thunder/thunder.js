@@ -2,6 +2,8 @@ import { LightningElement, wire, api } from 'lwc';
 import { setTabLabel, setTabIcon, IsConsoleNavigation, getFocusedTabInfo } from 'lightning/platformWorkspaceApi';
 import { NavigationMixin } from 'lightning/navigation';
 import { CloseActionScreenEvent } from 'lightning/actions';
+import { ShowToastEvent } from 'lightning/platformShowToastEvent';
+import { loadScript } from 'lightning/platformResourceLoader';
 
 import { getPicklistValuesByRecordType } from './ui.js';
 
@@ -10,9 +12,70 @@ import Go from 'c/go';
 // Apex proxy for REST calls
 import callRest from '@salesforce/apex/GoBridge.callRest';
 
+// Reduce an Apex error to its message, so Go receives a string. Failed saves
+// report the REST API's JSON error list, naming the fields that failed.
+function restErrorMessage(err) {
+	if (err && err.body && err.body.message) {
+		return err.body.message;
+	}
+	if (err && err.message) {
+		return err.message;
+	}
+	return String(err);
+}
+
 // WeakMap to store instance-specific recordId associated with div elements
 const divRecordIdMap = new WeakMap();
 
+// Cache Storage holding downloaded WASM chunks keyed by content hash, one cache
+// per app static resource.
+const CHUNK_CACHE_PREFIX = 'thunder-wasm-';
+
+// Open the chunk cache for an app, or resolve to null where Cache Storage is
+// unavailable (e.g. insecure contexts or a restricted sandbox).
+async function openChunkCache(resourceName) {
+	try {
+		if (typeof caches === 'undefined') {
+			return null;
+		}
+		return await caches.open(CHUNK_CACHE_PREFIX + resourceName);
+	} catch (e) {
+		return null;
+	}
+}
+
+function chunkCacheKey(hash) {
+	return '/thunder-wasm/' + hash;
+}
+
+// Remove cached chunks the current manifest no longer references.
+async function evictStaleChunks(cache, chunks) {
+	try {
+		const wanted = new Set(chunks.map((chunk) => chunk.sha256));
+		const keys = await cache.keys();
+		await Promise.all(keys
+			.filter((req) => !wanted.has(req.url.slice(req.url.lastIndexOf('/') + 1)))
+			.map((req) => cache.delete(req)));
+	} catch (e) {
+		// Eviction is best effort.
+	}
+}
+
+// Verify a downloaded chunk against its manifest hash before caching it. Where
+// SubtleCrypto is unavailable the chunk is trusted.
+async function hashMatches(buf, expected) {
+	if (!globalThis.crypto || !globalThis.crypto.subtle) {
+		return true;
+	}
+	try {
+		const digest = new Uint8Array(await globalThis.crypto.subtle.digest('SHA-256', buf));
+		const hex = Array.from(digest, (b) => b.toString(16).padStart(2, '0')).join('');
+		return hex === expected;
+	} catch (e) {
+		return false;
+	}
+}
+
 // Concatenate ArrayBuffers into a single ArrayBuffer, preserving order. Used to
 // reassemble a WASM bundle that was split across multiple static resources to
 // stay under Salesforce's 5MB per-resource limit.
@@ -54,11 +117,26 @@ export default class Thunder extends NavigationMixin(LightningElement) {
 	renderMode = "shadow";
 	initialized = false;
 	isLoading = true;
+	// Download progress (0-100) while the WASM bundle streams in
+	progress = 0;
+	loadedBytes = 0;
+	totalBytes = 0;
+	// Compiler recorded in the bundle manifest ("tinygo", or unset for Go)
+	compiler;
 
 	get appContainerClass() {
 		return this.isLoading ? 'slds-hide' : '';
 	}
 
+	// The progress bar is shown when the manifest reports the bundle size.
+	get showProgress() {
+		return this.totalBytes > 0;
+	}
+
+	get progressStyle() {
+		return 'width: ' + this.progress + '%';
+	}
+
 	renderedCallback() {
 		if (this.isConsoleNavigation && this.appName && !this.isQuickAction()) {
 			getFocusedTabInfo().then((tabInfo) => {
@@ -99,9 +177,12 @@ export default class Thunder extends NavigationMixin(LightningElement) {
 					throw err;
 				});
 		};
-		globalThis.post = (url, body) => callRest({ method: 'POST', url, body });
-		globalThis.patch = (url, body) => callRest({ method: 'PATCH', url, body });
-		globalThis.delete = (url) => callRest({ method: 'DELETE', url, body: null });
+		globalThis.post = (url, body) => callRest({ method: 'POST', url, body })
+			.catch((err) => { throw restErrorMessage(err); });
+		globalThis.patch = (url, body) => callRest({ method: 'PATCH', url, body })
+			.catch((err) => { throw restErrorMessage(err); });
+		globalThis.delete = (url) => callRest({ method: 'DELETE', url, body: null })
+			.catch((err) => { throw restErrorMessage(err); });
 
 		globalThis.getPicklistValuesByRecordType = getPicklistValuesByRecordType;
 
@@ -110,59 +191,134 @@ export default class Thunder extends NavigationMixin(LightningElement) {
 		globalThis.thunderExitToRecord = (recordId) => this.exitToRecord(recordId);
 		globalThis.thunderCloseModal = () => this.closeModal();
 
+		// Expose Lightning toasts to Go WASM
+		globalThis.thunderShowToast = (toast) => this.showToast(toast);
+
 		// Expose function to get recordId for a specific div
 		globalThis.getRecordIdForDiv = (div) => divRecordIdMap.get(div);
 
 		// A WASM bundle larger than Salesforce's 5MB static resource limit is
 		// split across several resources. The base resource carries a parts.json
-		// manifest recording the total count; the remaining chunks live in
-		// sibling resources named <base>Part1, <base>Part2, ... Fetch the base
-		// chunk and the manifest together, then any remaining chunks, and
-		// concatenate them before instantiating. A base resource without a
-		// manifest (apps deployed before this feature) is loaded as a single part.
+		// manifest recording the total count and each chunk's content hash; the
+		// remaining chunks live in sibling resources named <base>Part1,
+		// <base>Part2, ... Load every chunk (from the cache when its hash is
+		// unchanged) and concatenate them before instantiating. A base resource
+		// without a manifest (apps deployed before this feature) is loaded as a
+		// single part.
+		let src;
+		let GoRuntime = Go;
+		try {
+			src = await this.loadBundle();
+			// TinyGo bundles need TinyGo's runtime rather than the standard
+			// one in c/go; it is packed next to bundle.wasm and defines
+			// globalThis.Go when loaded.
+			if (this.compiler === 'tinygo') {
+				await loadScript(this, this.app.slice(0, this.app.lastIndexOf('/')) + '/wasm_exec.js');
+				GoRuntime = globalThis.Go;
+			}
+		} catch (e) {
+			this.isLoading = false;
+			const pre = document.createElement('pre');
+			pre.innerText = e.message;
+			divElement.appendChild(pre);
+			return;
+		}
+		const go = new GoRuntime();
+		const result = await WebAssembly.instantiate(src, go.importObject);
+		go.run(result.instance);
+		await new Promise(resolve => setTimeout(resolve, 1000));
+		startWithDiv(divElement);
+		this.isLoading = false;
+	}
+
+	// Fetch the bundle's manifest and chunks, reporting download progress and
+	// caching chunks by content hash so a redeploy only downloads the chunks
+	// that changed.
+	async loadBundle() {
 		const baseResourceUrl = this.app.slice(0, this.app.lastIndexOf('/'));
-		const [firstResp, manifestResp] = await Promise.all([
-			fetch(this.app),
-			fetch(baseResourceUrl + '/parts.json').catch(() => null)
-		]);
+		let manifest = null;
+		try {
+			const resp = await fetch(baseResourceUrl + '/parts.json');
+			if (resp.ok) {
+				manifest = await resp.json();
+			}
+		} catch (e) {
+			// Missing or invalid manifest: load as a single-part bundle.
+		}
 
-		let partCount = 1;
-		if (manifestResp && manifestResp.ok) {
+		this.compiler = manifest && manifest.compiler;
+		const partCount = manifest && manifest.parts > 1 ? manifest.parts : 1;
+		const urls = [this.app];
+		for (let i = 1; i < partCount; i++) {
+			urls.push(baseResourceUrl + 'Part' + i + '/bundle.wasm');
+		}
+		// Only manifests that describe every chunk enable caching and a
+		// determinate progress bar.
+		const chunks = manifest && Array.isArray(manifest.chunks) && manifest.chunks.length === partCount
+			? manifest.chunks
+			: null;
+		this.loadedBytes = 0;
+		this.totalBytes = chunks ? chunks.reduce((sum, chunk) => sum + chunk.size, 0) : 0;
+
+		const resourceName = baseResourceUrl.slice(baseResourceUrl.lastIndexOf('/') + 1);
+		const cache = chunks ? await openChunkCache(resourceName) : null;
+		const buffers = await Promise.all(urls.map((url, i) => this.loadChunk(url, chunks && chunks[i], cache)));
+		if (cache) {
+			evictStaleChunks(cache, chunks);
+		}
+		return concatBuffers(buffers);
+	}
+
+	// Load one chunk, from the cache when a chunk with the same hash was
+	// downloaded before, otherwise from its static resource.
+	async loadChunk(url, chunk, cache) {
+		if (cache && chunk) {
 			try {
-				const manifest = await manifestResp.json();
-				if (manifest && manifest.parts > 1) {
-					partCount = manifest.parts;
+				const hit = await cache.match(chunkCacheKey(chunk.sha256));
+				if (hit) {
+					const buf = await hit.arrayBuffer();
+					this.addProgress(buf.byteLength);
+					return buf;
 				}
 			} catch (e) {
-				// Missing or invalid manifest: load as a single-part bundle.
+				// Unreadable cache entry: fall back to the network.
 			}
 		}
+		const resp = await fetch(url);
+		if (!resp.ok) {
+			throw new Error(await resp.text());
+		}
+		const buf = await this.readWithProgress(resp);
+		if (cache && chunk && await hashMatches(buf, chunk.sha256)) {
+			cache.put(chunkCacheKey(chunk.sha256), new Response(new Blob([buf]))).catch(() => {});
+		}
+		return buf;
+	}
 
-		const responses = [firstResp];
-		if (partCount > 1) {
-			const restUrls = [];
-			for (let i = 1; i < partCount; i++) {
-				restUrls.push(baseResourceUrl + 'Part' + i + '/bundle.wasm');
+	// Read a response body, reporting progress as it streams in.
+	async readWithProgress(resp) {
+		if (!resp.body || !resp.body.getReader) {
+			const buf = await resp.arrayBuffer();
+			this.addProgress(buf.byteLength);
+			return buf;
+		}
+		const reader = resp.body.getReader();
+		const pieces = [];
+		for (;;) {
+			const { done, value } = await reader.read();
+			if (done) {
+				break;
 			}
-			const rest = await Promise.all(restUrls.map((url) => fetch(url)));
-			responses.push(...rest);
+			pieces.push(value);
+			this.addProgress(value.byteLength);
 		}
+		return concatBuffers(pieces.map((piece) => piece.buffer.slice(piece.byteOffset, piece.byteOffset + piece.byteLength)));
+	}
 
-		const failed = responses.find((resp) => !resp.ok);
-		if (failed) {
-			this.isLoading = false;
-			const pre = document.createElement('pre');
-			pre.innerText = await failed.text();
-			divElement.appendChild(pre);
-		} else {
-			const buffers = await Promise.all(responses.map((resp) => resp.arrayBuffer()));
-			const src = concatBuffers(buffers);
-			const go = new Go();
-			const result = await WebAssembly.instantiate(src, go.importObject);
-			go.run(result.instance);
-			await new Promise(resolve => setTimeout(resolve, 1000));
-			startWithDiv(divElement);
-			this.isLoading = false;
+	addProgress(bytes) {
+		this.loadedBytes += bytes;
+		if (this.totalBytes > 0) {
+			this.progress = Math.min(100, Math.floor(this.loadedBytes * 100 / this.totalBytes));
 		}
 	}
 
@@ -198,6 +354,21 @@ export default class Thunder extends NavigationMixin(LightningElement) {
 		this.dispatchEvent(new CloseActionScreenEvent());
 	}
 
+	// Show a toast using the platform's toast service
+	showToast(toast) {
+		const config = {
+			title: toast.title,
+			message: toast.message,
+			variant: toast.variant || 'info',
+			mode: toast.mode || 'dismissible'
+		};
+		if (toast.linkUrl) {
+			config.message = (toast.message ? toast.message + ' ' : '') + '{0}';
+			config.messageData = [{ url: toast.linkUrl, label: toast.linkLabel || toast.linkUrl }];
+		}
+		this.dispatchEvent(new ShowToastEvent(config));
+	}
+
 	// Determine if running in a quick action context
 	isQuickAction() {
 		// Quick actions typically run in overlays or modals
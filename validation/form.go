@@ -0,0 +1,372 @@
+package validation
+
+import (
+	"reflect"
+
+	"github.com/octoberswimmer/masc"
+	"github.com/octoberswimmer/thunder/api"
+	"github.com/octoberswimmer/thunder/components"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// Trigger controls when a field's errors start to show.
+type Trigger int
+
+const (
+	// OnChange shows errors once the field's value has been changed.
+	OnChange Trigger = iota
+	// OnBlur shows errors once the field has lost focus.
+	OnBlur
+	// OnSubmit shows errors only after the form has been submitted.
+	OnSubmit
+)
+
+// Field declares a form field and its rules.
+type Field struct {
+	Name  string
+	Label string
+	Rules []Rule
+	// Async runs a server-side check, such as whether a username is taken,
+	// after the field's other rules pass. It runs in a masc.Cmd and returns
+	// an error message, or "" if the value is valid.
+	Async func(value interface{}, values Values) string
+	// Trigger sets when errors show. Every field's errors show after Submit.
+	Trigger Trigger
+	// Tooltip and Placeholder are passed through to the field's
+	// ValidationState.
+	Tooltip     string
+	Placeholder string
+}
+
+// FieldError is an error in a form's summary.
+type FieldError struct {
+	Field   string
+	Label   string
+	Message string
+}
+
+// asyncResultMsg carries the result of a field's Async check.
+type asyncResultMsg struct {
+	form    *Form
+	field   string
+	seq     int
+	message string
+}
+
+// Form tracks the values, rule results and touched/dirty state of a set of
+// fields. Keep it in the app's model, report edits with Change and Blur,
+// pass every message to Update, and render each field with State:
+//
+//	case nameChangedMsg:
+//		return m, m.form.Change("Name", string(msg))
+//	...
+//	components.ValidatedTextInput("Name", m.form.String("Name"), m.form.State("Name"),
+//		func(e *masc.Event) { send(nameChangedMsg(e.Target.Get("value").String())) })
+type Form struct {
+	fields    []Field
+	values    Values
+	initial   Values
+	changed   map[string]bool
+	touched   map[string]bool
+	errors    map[string]string
+	async     map[string]string
+	server    map[string]string
+	pending   map[string]int
+	seq       int
+	submitted bool
+	// checked holds the fields submitted with SubmitFields.
+	checked map[string]bool
+}
+
+// New returns a form with the given fields and no values.
+func New(fields ...Field) *Form {
+	f := &Form{fields: fields}
+	f.SetValues(nil)
+	return f
+}
+
+// SetValues loads the form's values, e.g. from a record being edited, and
+// clears touched, dirty and submitted state.
+func (f *Form) SetValues(values Values) {
+	f.values = Values{}
+	f.initial = Values{}
+	for name, v := range values {
+		f.values[name] = v
+		f.initial[name] = v
+	}
+	f.changed = map[string]bool{}
+	f.touched = map[string]bool{}
+	f.async = map[string]string{}
+	f.server = map[string]string{}
+	f.pending = map[string]int{}
+	f.submitted = false
+	f.checked = map[string]bool{}
+	f.validate()
+}
+
+// Reset restores the values last loaded with SetValues.
+func (f *Form) Reset() {
+	f.SetValues(f.initial)
+}
+
+// Change sets a field's value and revalidates the form, so rules comparing
+// fields stay current. It returns the command running the field's Async
+// check, if any.
+func (f *Form) Change(name string, value interface{}) masc.Cmd {
+	f.values[name] = value
+	f.changed[name] = true
+	delete(f.server, name)
+	f.validate()
+	return f.runAsync(name)
+}
+
+// Blur marks a field as touched, showing errors for OnBlur fields.
+func (f *Form) Blur(name string) {
+	f.touched[name] = true
+}
+
+// Submit marks the form as submitted, showing every field's errors, and
+// reports whether it is valid. A form with Async checks still running is not
+// valid.
+func (f *Form) Submit() bool {
+	f.submitted = true
+	f.server = map[string]string{}
+	f.validate()
+	return f.Valid()
+}
+
+// SubmitFields submits only the named fields, showing their errors, and
+// reports whether they are valid, as for one step of a multi-step form (see
+// components.Wizard). Fields with Async checks still running are not valid.
+func (f *Form) SubmitFields(names ...string) bool {
+	f.validate()
+	valid := true
+	for _, name := range names {
+		f.checked[name] = true
+		if f.Error(name) != "" || f.Pending(name) {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// SetSaveErrors shows the errors of a failed save (see api.SaveErrors) on
+// the fields they name. They show at once and last until the field changes
+// or the form is submitted again. The messages of errors naming no field of
+// the form are returned, to show at the page level.
+func (f *Form) SetSaveErrors(errs []api.SaveError) []string {
+	var known []api.SaveError
+	var other []string
+	for _, se := range errs {
+		fields := se.Fields
+		se.Fields = nil
+		for _, name := range fields {
+			if _, ok := f.field(name); ok {
+				se.Fields = append(se.Fields, name)
+			}
+		}
+		if len(se.Fields) > 0 {
+			known = append(known, se)
+		} else {
+			other = append(other, se.Message)
+		}
+	}
+	f.server, _ = api.FieldErrors(known)
+	return other
+}
+
+// Update records the results of Async checks, reporting whether msg was one.
+func (f *Form) Update(msg masc.Msg) bool {
+	result, ok := msg.(asyncResultMsg)
+	if !ok || result.form != f {
+		return false
+	}
+	// Ignore results for values that have since changed
+	if f.pending[result.field] != result.seq {
+		return true
+	}
+	delete(f.pending, result.field)
+	if result.message != "" {
+		f.async[result.field] = result.message
+	} else {
+		delete(f.async, result.field)
+	}
+	return true
+}
+
+// Value returns a field's value.
+func (f *Form) Value(name string) interface{} {
+	return f.values[name]
+}
+
+// String returns a field's value as text, or "" if it is not a string.
+func (f *Form) String(name string) string {
+	s, _ := f.values[name].(string)
+	return s
+}
+
+// Values returns a copy of every field's value.
+func (f *Form) Values() Values {
+	values := make(Values, len(f.values))
+	for name, v := range f.values {
+		values[name] = v
+	}
+	return values
+}
+
+// Changes returns the values that differ from those loaded with SetValues.
+func (f *Form) Changes() Values {
+	changes := Values{}
+	for name, v := range f.values {
+		if f.Dirty(name) {
+			changes[name] = v
+		}
+	}
+	return changes
+}
+
+// Touched reports whether a field has lost focus since the values were set.
+func (f *Form) Touched(name string) bool {
+	return f.touched[name]
+}
+
+// Dirty reports whether a field's value differs from the one loaded with
+// SetValues.
+func (f *Form) Dirty(name string) bool {
+	return !reflect.DeepEqual(f.values[name], f.initial[name])
+}
+
+// IsDirty reports whether any field is dirty.
+func (f *Form) IsDirty() bool {
+	for name := range f.values {
+		if f.Dirty(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pending reports whether a field's Async check is still running.
+func (f *Form) Pending(name string) bool {
+	_, ok := f.pending[name]
+	return ok
+}
+
+// Error returns a field's error message, whether or not it is shown yet.
+func (f *Form) Error(name string) string {
+	if msg := f.errors[name]; msg != "" {
+		return msg
+	}
+	if msg := f.server[name]; msg != "" {
+		return msg
+	}
+	return f.async[name]
+}
+
+// Valid reports whether every field passes its rules and no Async check is
+// running.
+func (f *Form) Valid() bool {
+	if len(f.pending) > 0 {
+		return false
+	}
+	for _, field := range f.fields {
+		if f.Error(field.Name) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// State returns the ValidationState to render a field with. Errors appear
+// once the field's Trigger has fired or the form has been submitted.
+func (f *Form) State(name string) components.ValidationState {
+	field, _ := f.field(name)
+	state := components.ValidationState{
+		Required:    field.required(),
+		Tooltip:     field.Tooltip,
+		Placeholder: field.Placeholder,
+		Field:       name,
+	}
+	if f.Pending(name) {
+		state.HelpText = i18n.T("Thunder_Checking")
+	}
+	if msg := f.Error(name); msg != "" && f.shown(field) {
+		state.HasError = true
+		state.ErrorMessage = msg
+	}
+	return state
+}
+
+// Summary returns the errors currently shown, in field order, for an error
+// summary at the top or bottom of the form.
+func (f *Form) Summary() []FieldError {
+	var errs []FieldError
+	for _, field := range f.fields {
+		if msg := f.Error(field.Name); msg != "" && f.shown(field) {
+			errs = append(errs, FieldError{Field: field.Name, Label: field.Label, Message: msg})
+		}
+	}
+	return errs
+}
+
+// validate runs every field's rules, stopping at each field's first error.
+func (f *Form) validate() {
+	f.errors = map[string]string{}
+	for _, field := range f.fields {
+		for _, rule := range field.Rules {
+			if msg := rule.Check(field.Label, f.values[field.Name], f.values); msg != "" {
+				f.errors[field.Name] = msg
+				break
+			}
+		}
+	}
+}
+
+// runAsync starts a field's Async check once its other rules pass.
+func (f *Form) runAsync(name string) masc.Cmd {
+	field, ok := f.field(name)
+	delete(f.async, name)
+	delete(f.pending, name)
+	if !ok || field.Async == nil || f.errors[name] != "" || IsEmpty(f.values[name]) {
+		return nil
+	}
+	f.seq++
+	seq := f.seq
+	f.pending[name] = seq
+	value, values := f.values[name], f.Values()
+	return func() masc.Msg {
+		return asyncResultMsg{form: f, field: name, seq: seq, message: field.Async(value, values)}
+	}
+}
+
+// shown reports whether field's errors should be displayed.
+func (f *Form) shown(field Field) bool {
+	if f.submitted || f.checked[field.Name] || f.server[field.Name] != "" {
+		return true
+	}
+	switch field.Trigger {
+	case OnChange:
+		return f.changed[field.Name] || f.touched[field.Name]
+	case OnBlur:
+		return f.touched[field.Name]
+	}
+	return false
+}
+
+func (f *Form) field(name string) (Field, bool) {
+	for _, field := range f.fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{Name: name, Label: name}, false
+}
+
+func (field Field) required() bool {
+	for _, rule := range field.Rules {
+		if rule.required {
+			return true
+		}
+	}
+	return false
+}
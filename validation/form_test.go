@@ -0,0 +1,170 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/octoberswimmer/thunder/api"
+)
+
+func newSignupForm() *Form {
+	return New(
+		Field{Name: "Name", Label: "Name", Rules: []Rule{Required(), MaxLength(10)}},
+		Field{Name: "Email", Label: "Email", Rules: []Rule{Required(), Email()}, Trigger: OnBlur},
+		Field{Name: "Confirm", Label: "Confirm Email", Rules: []Rule{SameAs("Email", "Email")}},
+		Field{Name: "Notes", Label: "Notes", Trigger: OnSubmit, Rules: []Rule{Required()}},
+	)
+}
+
+func TestFormShowsErrorsByTrigger(t *testing.T) {
+	f := newSignupForm()
+	if state := f.State("Name"); state.HasError || !state.Required || state.Field != "Name" {
+		t.Errorf("expected untouched required field without error, got %+v", state)
+	}
+
+	f.Change("Name", "")
+	if state := f.State("Name"); !state.HasError || state.ErrorMessage != "Name is required" {
+		t.Errorf("expected error after change, got %+v", state)
+	}
+
+	f.Change("Email", "nope")
+	if f.State("Email").HasError {
+		t.Error("expected OnBlur field to hide errors until blurred")
+	}
+	f.Blur("Email")
+	if state := f.State("Email"); state.ErrorMessage != "Email must be a valid email address" {
+		t.Errorf("expected error after blur, got %+v", state)
+	}
+
+	if f.State("Notes").HasError {
+		t.Error("expected OnSubmit field to hide errors before submit")
+	}
+	if f.Submit() {
+		t.Error("expected invalid form")
+	}
+	summary := f.Summary()
+	if len(summary) != 3 || summary[2].Field != "Notes" {
+		t.Errorf("expected Name, Email and Notes errors in summary, got %+v", summary)
+	}
+}
+
+func TestFormSubmitFields(t *testing.T) {
+	f := newSignupForm()
+	f.Change("Name", "Ada")
+	if !f.SubmitFields("Name") {
+		t.Error("expected the Name step to be valid")
+	}
+	if f.SubmitFields("Email", "Notes") {
+		t.Error("expected the Email and Notes step to be invalid")
+	}
+	if !f.State("Notes").HasError || !f.State("Email").HasError {
+		t.Error("expected the submitted fields to show their errors")
+	}
+	if f.State("Confirm").HasError || len(f.Summary()) != 2 {
+		t.Errorf("expected only the submitted fields' errors, got %+v", f.Summary())
+	}
+}
+
+func TestFormRevalidatesCrossFieldRules(t *testing.T) {
+	f := newSignupForm()
+	f.Change("Email", "ada@example.com")
+	f.Change("Confirm", "ada@example.com")
+	if f.Error("Confirm") != "" {
+		t.Fatalf("expected matching emails, got %q", f.Error("Confirm"))
+	}
+	f.Change("Email", "ada@example.org")
+	if f.Error("Confirm") != "Confirm Email must match Email" {
+		t.Errorf("expected Confirm to be revalidated, got %q", f.Error("Confirm"))
+	}
+}
+
+func TestFormTracksDirtyState(t *testing.T) {
+	f := newSignupForm()
+	f.SetValues(Values{"Name": "Acme"})
+	if f.IsDirty() {
+		t.Error("expected loaded values to be clean")
+	}
+	f.Change("Name", "Acme Corp")
+	f.Blur("Name")
+	if !f.Dirty("Name") || !f.Touched("Name") {
+		t.Error("expected Name to be dirty and touched")
+	}
+	if changes := f.Changes(); len(changes) != 1 || changes["Name"] != "Acme Corp" {
+		t.Errorf("unexpected changes %v", changes)
+	}
+	f.Change("Name", "Acme")
+	if f.IsDirty() {
+		t.Error("expected restoring the value to clear dirty state")
+	}
+	f.Change("Name", "Other")
+	f.Reset()
+	if f.String("Name") != "Acme" || f.Touched("Name") {
+		t.Errorf("expected reset to restore loaded values, got %q", f.String("Name"))
+	}
+}
+
+func TestFormAsyncCheck(t *testing.T) {
+	taken := map[string]bool{"ada": true}
+	f := New(Field{
+		Name:  "Username",
+		Label: "Username",
+		Rules: []Rule{Required(), MinLength(3)},
+		Async: func(value interface{}, _ Values) string {
+			if taken[value.(string)] {
+				return "Username is taken"
+			}
+			return ""
+		},
+	})
+
+	if cmd := f.Change("Username", "ad"); cmd != nil {
+		t.Error("expected no server check while other rules fail")
+	}
+
+	stale := f.Change("Username", "ada")
+	if stale == nil {
+		t.Fatal("expected a server check command")
+	}
+	if !f.Pending("Username") || f.Valid() || f.State("Username").HelpText == "" {
+		t.Error("expected the field to be pending")
+	}
+	current := f.Change("Username", "grace")
+
+	if !f.Update(stale()) || f.Error("Username") != "" || !f.Pending("Username") {
+		t.Error("expected stale result to be ignored")
+	}
+	f.Update(current())
+	if f.Pending("Username") || !f.Valid() {
+		t.Errorf("expected valid form, got error %q", f.Error("Username"))
+	}
+
+	f.Update(f.Change("Username", "ada")())
+	if state := f.State("Username"); state.ErrorMessage != "Username is taken" {
+		t.Errorf("expected server error, got %+v", state)
+	}
+	if f.Update(struct{}{}) {
+		t.Error("expected other messages to be ignored")
+	}
+}
+
+func TestFormSaveErrors(t *testing.T) {
+	f := newSignupForm()
+	f.Change("Name", "Acme")
+	other := f.SetSaveErrors([]api.SaveError{
+		{Message: "Name already exists", ErrorCode: "DUPLICATE_VALUE", Fields: []string{"Name"}},
+		{Message: "Owner is inactive", Fields: []string{"OwnerId"}},
+		{Message: "Record is locked"},
+	})
+	if len(other) != 2 || other[0] != "Owner is inactive" || other[1] != "Record is locked" {
+		t.Errorf("expected errors without form fields returned, got %v", other)
+	}
+	if state := f.State("Name"); state.ErrorMessage != "Name already exists" {
+		t.Errorf("expected save error on Name, got %+v", state)
+	}
+	if f.Valid() {
+		t.Error("expected save errors to make the form invalid")
+	}
+	f.Change("Name", "Acme Corp")
+	if f.Error("Name") != "" {
+		t.Errorf("expected change to clear the save error, got %q", f.Error("Name"))
+	}
+}
@@ -0,0 +1,253 @@
+// Package validation declares validation rules for the fields of a form and
+// tracks their results as the user edits, producing the
+// components.ValidationState values rendered by the Validated* components.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octoberswimmer/thunder/components"
+	"github.com/octoberswimmer/thunder/i18n"
+)
+
+// Values holds a form's field values by field name.
+type Values map[string]interface{}
+
+// Rule checks the value of one field. Rules other than Required pass empty
+// values, so optional fields are only checked once filled in.
+type Rule struct {
+	check    func(label string, value interface{}, values Values) string
+	required bool
+	message  string
+}
+
+// Check returns the error message for value, or "" if it is valid. label is
+// the field's label, used in the default messages.
+func (r Rule) Check(label string, value interface{}, values Values) string {
+	if r.check == nil {
+		return ""
+	}
+	if !r.required && IsEmpty(value) {
+		return ""
+	}
+	msg := r.check(label, value, values)
+	if msg != "" && r.message != "" {
+		return r.message
+	}
+	return msg
+}
+
+// WithMessage returns a copy of the rule that reports message instead of its
+// default error message.
+func (r Rule) WithMessage(message string) Rule {
+	r.message = message
+	return r
+}
+
+// Required rejects empty values: nil, blank strings, zero times and empty
+// slices.
+func Required() Rule {
+	return Rule{
+		required: true,
+		check: func(label string, value interface{}, _ Values) string {
+			if IsEmpty(value) {
+				return i18n.T("Thunder_Required", label)
+			}
+			return ""
+		},
+	}
+}
+
+// MinLength rejects text shorter than n characters.
+func MinLength(n int) Rule {
+	return textRule(func(label, s string) string {
+		if len([]rune(s)) < n {
+			return i18n.T("Thunder_Min_Length", label, n)
+		}
+		return ""
+	})
+}
+
+// MaxLength rejects text longer than n characters.
+func MaxLength(n int) Rule {
+	return textRule(func(label, s string) string {
+		if len([]rune(s)) > n {
+			return i18n.T("Thunder_Max_Length", label, n)
+		}
+		return ""
+	})
+}
+
+// Pattern rejects text that does not match re.
+func Pattern(re *regexp.Regexp) Rule {
+	return textRule(func(label, s string) string {
+		if !re.MatchString(s) {
+			return i18n.T("Thunder_Bad_Format", label)
+		}
+		return ""
+	})
+}
+
+// Email rejects text that is not a bare email address.
+func Email() Rule {
+	return textRule(func(label, s string) string {
+		if !components.IsValidEmail(s) {
+			return i18n.T("Thunder_Bad_Email", label)
+		}
+		return ""
+	})
+}
+
+// Phone rejects text that is not a phone number: digits with optional
+// spaces, dots, dashes, parentheses and a leading +, and at least seven
+// digits.
+func Phone() Rule {
+	return textRule(func(label, s string) string {
+		if !components.IsValidPhone(s) {
+			return i18n.T("Thunder_Bad_Phone", label)
+		}
+		return ""
+	})
+}
+
+// URL rejects text that is not a web address; the scheme may be left off.
+func URL() Rule {
+	return textRule(func(label, s string) string {
+		if !components.IsValidURL(s) {
+			return i18n.T("Thunder_Bad_URL", label)
+		}
+		return ""
+	})
+}
+
+// Range rejects numbers outside min to max, inclusive, and values that are
+// not numbers. Numeric strings are parsed.
+func Range(min, max float64) Rule {
+	return Rule{check: func(label string, value interface{}, _ Values) string {
+		n, ok := toFloat(value)
+		if !ok {
+			return i18n.T("Thunder_Bad_Number", label)
+		}
+		if n < min || n > max {
+			return i18n.T("Thunder_Out_Of_Range", label, formatFloat(min), formatFloat(max))
+		}
+		return ""
+	}}
+}
+
+// DateRange rejects dates before min or after max. A zero min or max leaves
+// that end open. Values are time.Time or strings in 2006-01-02 form.
+func DateRange(min, max time.Time) Rule {
+	return Rule{check: func(label string, value interface{}, _ Values) string {
+		t, ok := toTime(value)
+		if !ok {
+			return i18n.T("Thunder_Bad_Date", label)
+		}
+		if !min.IsZero() && t.Before(min) {
+			return i18n.T("Thunder_Too_Early", label, i18n.FormatDate(min))
+		}
+		if !max.IsZero() && t.After(max) {
+			return i18n.T("Thunder_Too_Late", label, i18n.FormatDate(max))
+		}
+		return ""
+	}}
+}
+
+// SameAs rejects values that differ from the value of the other field, as
+// when confirming a password or email address.
+func SameAs(other, otherLabel string) Rule {
+	return Rule{check: func(label string, value interface{}, values Values) string {
+		if !reflect.DeepEqual(value, values[other]) {
+			return i18n.T("Thunder_Must_Match", label, otherLabel)
+		}
+		return ""
+	}}
+}
+
+// Custom builds a rule from fn, which receives the field's value and the
+// values of every field, so it can compare fields. fn returns an error
+// message, or "" if the value is valid.
+func Custom(fn func(value interface{}, values Values) string) Rule {
+	return Rule{check: func(_ string, value interface{}, values Values) string {
+		return fn(value, values)
+	}}
+}
+
+// IsEmpty reports whether value counts as missing for Required.
+func IsEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case time.Time:
+		return v.IsZero()
+	case *time.Time:
+		return v == nil || v.IsZero()
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Pointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// textRule builds a rule that checks a value's text.
+func textRule(fn func(label, s string) string) Rule {
+	return Rule{check: func(label string, value interface{}, _ Values) string {
+		return fn(label, toString(value))
+	}}
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		t, err := time.Parse("2006-01-02", strings.TrimSpace(v))
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+func formatFloat(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
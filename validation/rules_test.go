@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRules(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	dec := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		rule  Rule
+		value interface{}
+		want  string
+	}{
+		{"required blank", Required(), "  ", "Name is required"},
+		{"required nil", Required(), nil, "Name is required"},
+		{"required empty slice", Required(), []string{}, "Name is required"},
+		{"required filled", Required(), "Acme", ""},
+		{"min length", MinLength(3), "ab", "Name must be at least 3 characters"},
+		{"min length skips empty", MinLength(3), "", ""},
+		{"max length", MaxLength(3), "abcd", "Name must be 3 characters or fewer"},
+		{"max length counts runes", MaxLength(3), "héé", ""},
+		{"pattern", Pattern(regexp.MustCompile(`^[A-Z]{3}$`)), "abc", "Name is not in the expected format"},
+		{"email", Email(), "not an email", "Name must be a valid email address"},
+		{"email with display name", Email(), "Ada <ada@example.com>", "Name must be a valid email address"},
+		{"email valid", Email(), "ada@example.com", ""},
+		{"phone", Phone(), "555-12", "Name must be a valid phone number"},
+		{"phone valid", Phone(), "+1 (415) 555-0100", ""},
+		{"url", URL(), "not a url", "Name must be a valid URL"},
+		{"url without scheme", URL(), "example.com", ""},
+		{"range", Range(1, 10), 11, "Name must be between 1 and 10"},
+		{"range string", Range(1, 10), "2.5", ""},
+		{"range not number", Range(1, 10), "x", "Name must be a number"},
+		{"date before", DateRange(jan, dec), "2024-12-31", "Name must be on or after 1/1/2025"},
+		{"date after", DateRange(jan, dec), dec.AddDate(0, 0, 1), "Name must be on or before 12/31/2025"},
+		{"date open end", DateRange(jan, time.Time{}), "2030-01-01", ""},
+		{"custom message", MinLength(3).WithMessage("Too short"), "a", "Too short"},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Check("Name", tt.value, Values{}); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	values := Values{"Password": "secret1", "Confirm": "secret2"}
+	if got := SameAs("Password", "Password").Check("Confirm", values["Confirm"], values); got != "Confirm must match Password" {
+		t.Errorf("unexpected message %q", got)
+	}
+	later := Custom(func(value interface{}, values Values) string {
+		if value.(string) < values["Start"].(string) {
+			return "End must be after Start"
+		}
+		return ""
+	})
+	if got := later.Check("End", "2025-01-01", Values{"Start": "2025-02-01"}); got != "End must be after Start" {
+		t.Errorf("unexpected message %q", got)
+	}
+}